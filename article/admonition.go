@@ -0,0 +1,203 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AdmonitionKind 提示框类型
+type AdmonitionKind string
+
+const (
+	AdmonitionNote    AdmonitionKind = "NOTE"
+	AdmonitionTip     AdmonitionKind = "TIP"
+	AdmonitionWarning AdmonitionKind = "WARNING"
+	AdmonitionDanger  AdmonitionKind = "DANGER"
+)
+
+var (
+	// quotedAdmonitionRegex GitHub 风格的提示框首行，如 > [!NOTE] 可选标题
+	quotedAdmonitionRegex = regexp.MustCompile(`^>\s*\[!([A-Za-z]+)\]\s*(.*)$`)
+	// containerAdmonitionRegex 容器风格的提示框首行，如 :::tip 可选标题
+	containerAdmonitionRegex = regexp.MustCompile(`^:::\s*([A-Za-z]+)\s*(.*)$`)
+)
+
+// admonitionKinds 提示框类型名（不区分大小写）到类型的映射，包含 GitHub、VuePress 等常用的别名
+var admonitionKinds = map[string]AdmonitionKind{
+	"note": AdmonitionNote, "info": AdmonitionNote,
+	"tip": AdmonitionTip, "hint": AdmonitionTip,
+	"warning": AdmonitionWarning, "important": AdmonitionWarning,
+	"danger": AdmonitionDanger, "caution": AdmonitionDanger, "error": AdmonitionDanger,
+}
+
+// Icon 提示框类型的图标前缀
+func (k AdmonitionKind) Icon() string {
+	switch k {
+	case AdmonitionTip:
+		return "💡"
+	case AdmonitionWarning:
+		return "⚠️"
+	case AdmonitionDanger:
+		return "🚫"
+	default:
+		return "ℹ️"
+	}
+}
+
+// Label 提示框类型的默认标题
+func (k AdmonitionKind) Label() string {
+	switch k {
+	case AdmonitionTip:
+		return "提示"
+	case AdmonitionWarning:
+		return "警告"
+	case AdmonitionDanger:
+		return "危险"
+	default:
+		return "注意"
+	}
+}
+
+// Admonition 正文中的一个提示框，支持 > [!NOTE] 和 :::tip ... ::: 两种写法
+type Admonition struct {
+	Kind   AdmonitionKind
+	Title  string // 首行类型名之后的自定义标题，为空时使用类型的默认标题
+	Start  int    // 首行行号
+	End    int    // 提示框结束后的下一行行号
+	quoted bool   // > [!NOTE] 写法，正文行带引用标记，没有结束行
+}
+
+// Heading 提示框的标题行文字：图标加标题
+func (adm Admonition) Heading() string {
+	title := adm.Title
+	if title == "" {
+		title = adm.Kind.Label()
+	}
+	return adm.Kind.Icon() + " " + title
+}
+
+// bodyRange 提示框正文的行号区间 [start, end)
+func (adm Admonition) bodyRange() (int, int) {
+	if adm.quoted {
+		return adm.Start + 1, adm.End
+	}
+	return adm.Start + 1, adm.End - 1
+}
+
+// AdmonitionAt 判断第 start 行是否开始一个提示框，不认识的类型名不视为提示框。
+// > [!NOTE] 写法到第一个不以 > 开头的行结束；:::tip 写法到单独的 ::: 行结束，找不到结束行时不视为提示框
+func AdmonitionAt(lines []string, start int) (Admonition, bool) {
+	trimmed := strings.TrimSpace(lines[start])
+
+	if match := quotedAdmonitionRegex.FindStringSubmatch(trimmed); match != nil {
+		kind, ok := admonitionKinds[strings.ToLower(match[1])]
+		if !ok {
+			return Admonition{}, false
+		}
+		end := start + 1
+		for end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), ">") {
+			end++
+		}
+		return Admonition{Kind: kind, Title: strings.TrimSpace(match[2]), Start: start, End: end, quoted: true}, true
+	}
+
+	if match := containerAdmonitionRegex.FindStringSubmatch(trimmed); match != nil {
+		kind, ok := admonitionKinds[strings.ToLower(match[1])]
+		if !ok {
+			return Admonition{}, false
+		}
+		for i := start + 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == ":::" {
+				return Admonition{Kind: kind, Title: strings.TrimSpace(match[2]), Start: start, End: i + 1}, true
+			}
+		}
+	}
+	return Admonition{}, false
+}
+
+// RenderAdmonitionBody 渲染提示框的正文，每行一项（空行为空字符串），
+// > [!NOTE] 写法会去掉行首的引用标记，渲染方式与 RenderLine 相同
+func (a *Article) RenderAdmonitionBody(adm Admonition, text func(string) string, image func(index int, img Image) string) []string {
+	start, end := adm.bodyRange()
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		skip := 0
+		if adm.quoted {
+			skip = quoteMarkerLength(a.Content[i])
+		}
+		// 引用标记总在行首，RenderLine 第一次处理的文字片段就包含它
+		stripped := func(segment string) string {
+			n := skip
+			if n > len(segment) {
+				n = len(segment)
+			}
+			skip -= n
+			return text(segment[n:])
+		}
+		lines = append(lines, strings.TrimSpace(a.RenderLine(i, stripped, image)))
+	}
+	return lines
+}
+
+// quoteMarkerLength 返回行首引用标记（缩进、> 和其后的一个空格）的字节长度
+func quoteMarkerLength(line string) int {
+	n := len(line) - len(strings.TrimLeft(line, " \t"))
+	if n < len(line) && line[n] == '>' {
+		n++
+		if n < len(line) && line[n] == ' ' {
+			n++
+		}
+	}
+	return n
+}
+
+// WithQuotedAdmonitions 返回提示框改写为普通引用块的文章副本，供不支持提示框语法的 Markdown 平台使用：
+// 首行改为带图标的加粗标题，:::tip 写法的正文行加上引用标记、结束行留空（行号不变），原文章不受影响
+func (a *Article) WithQuotedAdmonitions() *Article {
+	content := make([]string, len(a.Content))
+	copy(content, a.Content)
+	images := make([]Image, len(a.Images))
+	copy(images, a.Images)
+
+	var opening CodeFence
+	inCodeBlock := false
+	for i := 0; i < len(content); i++ {
+		trimmed := strings.TrimSpace(content[i])
+		if fence, ok := ParseCodeFence(trimmed); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		adm, ok := AdmonitionAt(content, i)
+		if !ok {
+			continue
+		}
+		content[i] = "> **" + adm.Heading() + "**"
+		if !adm.quoted {
+			start, end := adm.bodyRange()
+			for j := start; j < end; j++ {
+				if strings.TrimSpace(content[j]) == "" {
+					content[j] = ">"
+					continue
+				}
+				content[j] = "> " + content[j]
+				shiftImageColumns(images, j, []lineShift{{position: -1, delta: 2}})
+			}
+			content[end] = ""
+		}
+		i = adm.End - 1
+	}
+
+	quoted := *a
+	quoted.Content = content
+	quoted.Images = images
+	return &quoted
+}
@@ -0,0 +1,122 @@
+package article
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AltTextMode 图片 alt 为空时自动生成描述的方式
+type AltTextMode string
+
+const (
+	AltTextOff      AltTextMode = "off"      // 不生成（默认）
+	AltTextFilename AltTextMode = "filename" // 用文件名生成，文件名没有意义（如 IMG_1234）时改用上下文
+	AltTextContext  AltTextMode = "context"  // 用图片所在段落或前一段文字生成，找不到文字时改用文件名
+)
+
+// maxGeneratedAlt 由上下文生成的 alt 最多保留的字符数
+const maxGeneratedAlt = 30
+
+// altContextLines 向前查找上下文段落的最大行数
+const altContextLines = 5
+
+var (
+	// genericNameRegex 相机、截图工具等自动生成的无意义文件名
+	genericNameRegex = regexp.MustCompile(`(?i)^(img|image|images|pic|photo|screenshot|screen shot|snipaste|pasted|untitled|截图|截屏|屏幕截图|微信图片|图片)?[\s\d._-]*(at[\s\d._-]*)?$`)
+	// hexNameRegex 哈希或随机串形式的文件名
+	hexNameRegex = regexp.MustCompile(`(?i)^[0-9a-f]{8,}$`)
+)
+
+// ParseAltTextMode 解析 alt 生成方式，空字符串返回默认的不生成
+func ParseAltTextMode(value string) (AltTextMode, error) {
+	switch mode := AltTextMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return AltTextOff, nil
+	case AltTextOff, AltTextFilename, AltTextContext:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("未知的 alt 生成方式: %s（可选 off/filename/context）", value)
+	}
+}
+
+// FillMissingAltText 为 alt 为空的图片按指定方式生成描述，生成的 alt 用于图片占位符和最终的图注。
+// 返回生成 alt 的图片数
+func (a *Article) FillMissingAltText(mode AltTextMode) int {
+	if mode != AltTextFilename && mode != AltTextContext {
+		return 0
+	}
+
+	count := 0
+	for i, img := range a.Images {
+		if strings.TrimSpace(img.AltText) != "" {
+			continue
+		}
+		fromName := altFromFilename(img.RelativePath)
+		fromContext := a.altFromContext(img.LineIndex)
+		alt := fromName
+		if mode == AltTextContext || alt == "" {
+			alt = fromContext
+		}
+		if alt == "" {
+			alt = fromName
+		}
+		if alt == "" {
+			continue
+		}
+		a.Images[i].AltText = alt
+		count++
+	}
+	return count
+}
+
+// altFromFilename 由文件名生成 alt：去掉扩展名，连字符和下划线换成空格。
+// 文件名是相机编号、截图时间或哈希等无意义的名字时返回空字符串
+func altFromFilename(path string) string {
+	name := filepath.Base(strings.ReplaceAll(path, "\\", "/"))
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || unicode.IsSpace(r)
+	}), " ")
+	if name == "" || genericNameRegex.MatchString(name) || hexNameRegex.MatchString(strings.ReplaceAll(name, " ", "")) {
+		return ""
+	}
+	return name
+}
+
+// altFromContext 由图片所在行的文字生成 alt，该行没有文字时向前查找最近的一段文字（标题也可以），
+// 去掉 Markdown 标记后截取前 maxGeneratedAlt 个字符
+func (a *Article) altFromContext(lineIndex int) string {
+	for i := lineIndex; i >= 0 && i > lineIndex-altContextLines; i-- {
+		if i >= len(a.Content) {
+			continue
+		}
+		trimmed := strings.TrimSpace(a.Content[i])
+		if fence := codeFenceOf(trimmed); fence != "" {
+			// 到代码块为止，不把代码当作描述
+			break
+		}
+		if _, heading, ok := parseHeading(trimmed); ok {
+			trimmed = heading
+		}
+		text := placeholderRegex.ReplaceAllString(trimmed, "")
+		text = markdownLinkRegex.ReplaceAllString(text, "$1")
+		text = listMarkerRegex.ReplaceAllString(text, "")
+		text = strings.TrimSpace(markdownMarkRegex.ReplaceAllString(text, ""))
+		text = strings.TrimRight(text, "：:，,。.")
+		if text == "" || strings.HasPrefix(text, "<") || strings.HasPrefix(text, "|") {
+			continue
+		}
+		if utf8.RuneCountInString(text) > maxGeneratedAlt {
+			text = strings.TrimSpace(string([]rune(text)[:maxGeneratedAlt])) + "…"
+		}
+		return text
+	}
+	return ""
+}
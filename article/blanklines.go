@@ -0,0 +1,75 @@
+package article
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BlankLineMode 正文空行处理方式
+type BlankLineMode string
+
+const (
+	BlankLinesKeep     BlankLineMode = "keep"     // 保留原样（默认）
+	BlankLinesCollapse BlankLineMode = "collapse" // 连续空行压缩为一个
+)
+
+// ParseBlankLineMode 解析空行处理方式，空字符串返回默认的保留原样
+func ParseBlankLineMode(value string) (BlankLineMode, error) {
+	switch mode := BlankLineMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return BlankLinesKeep, nil
+	case BlankLinesKeep, BlankLinesCollapse:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("未知的空行处理方式: %s（可选 keep/collapse）", value)
+	}
+}
+
+// NormalizeBlankLines 按指定方式规范化正文空行，代码块内部的空行保持不变，
+// 图片所在行号随之调整。返回删除的空行数
+func (a *Article) NormalizeBlankLines(mode BlankLineMode) int {
+	if mode != BlankLinesCollapse {
+		return 0
+	}
+
+	// newIndex[i] 为原第 i 行在规范化后的行号
+	newIndex := make([]int, len(a.Content))
+	content := make([]string, 0, len(a.Content))
+	inCodeBlock := false
+	codeFence := ""
+	previousBlank := false
+
+	for i, line := range a.Content {
+		trimmed := strings.TrimSpace(line)
+
+		if fence := codeFenceOf(trimmed); fence != "" {
+			if !inCodeBlock {
+				inCodeBlock = true
+				codeFence = fence
+			} else if strings.HasPrefix(trimmed, codeFence) && strings.TrimSpace(strings.TrimLeft(trimmed, codeFence[:1])) == "" {
+				inCodeBlock = false
+			}
+		}
+
+		blank := trimmed == "" && !inCodeBlock
+		if blank && previousBlank {
+			newIndex[i] = len(content) - 1
+			continue
+		}
+		previousBlank = blank
+
+		newIndex[i] = len(content)
+		content = append(content, line)
+	}
+
+	removed := len(a.Content) - len(content)
+	if removed == 0 {
+		return 0
+	}
+
+	for i := range a.Images {
+		a.Images[i].LineIndex = newIndex[a.Images[i].LineIndex]
+	}
+	a.Content = content
+	return removed
+}
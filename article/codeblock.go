@@ -0,0 +1,82 @@
+package article
+
+import "strings"
+
+// CodeFence 代码块围栏行，如 ```js {run} 解析为
+// Marker=```、Language=js、Params={run}
+type CodeFence struct {
+	Marker   string // 围栏标记（``` 或 ~~~，可多于三个字符）
+	Language string // 语言名，info string 的第一个词
+	Params   string // 语言名之后的额外参数，原样保留
+}
+
+// ParseCodeFence 解析代码块围栏行（传入去掉首尾空白的行），不是围栏时返回 false。
+// 语言名在第一个空白或 { 处结束，例如 ```js{run} 的语言名为 js
+func ParseCodeFence(trimmed string) (CodeFence, bool) {
+	marker := codeFenceOf(trimmed)
+	if marker == "" {
+		return CodeFence{}, false
+	}
+
+	info := strings.TrimSpace(trimmed[len(marker):])
+	end := strings.IndexAny(info, " \t{")
+	if end < 0 {
+		end = len(info)
+	}
+	return CodeFence{
+		Marker:   marker,
+		Language: info[:end],
+		Params:   strings.TrimSpace(info[end:]),
+	}, true
+}
+
+// Closes 判断该围栏能否结束以 opening 开始的代码块：
+// 标记字符相同、长度不短于起始围栏且没有 info string
+func (f CodeFence) Closes(opening CodeFence) bool {
+	return f.Language == "" && f.Params == "" &&
+		f.Marker[0] == opening.Marker[0] && len(f.Marker) >= len(opening.Marker)
+}
+
+// String 还原围栏行
+func (f CodeFence) String() string {
+	line := f.Marker + f.Language
+	if f.Params != "" {
+		line += " " + f.Params
+	}
+	return line
+}
+
+// WithoutCodeParams 返回去掉代码块 info string 额外参数的文章副本（只保留语言名），
+// 供不支持这些参数的平台使用，原文章不受影响
+func (a *Article) WithoutCodeParams() *Article {
+	content := make([]string, len(a.Content))
+	copy(content, a.Content)
+
+	var opening CodeFence
+	inCodeBlock := false
+	for i, line := range content {
+		trimmed := strings.TrimSpace(line)
+		fence, ok := ParseCodeFence(trimmed)
+		if !ok {
+			continue
+		}
+		if inCodeBlock {
+			if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+
+		inCodeBlock = true
+		opening = fence
+		if fence.Params != "" {
+			indent := line[:strings.Index(line, trimmed)]
+			fence.Params = ""
+			content[i] = indent + fence.String()
+		}
+	}
+
+	stripped := *a
+	stripped.Content = content
+	return &stripped
+}
@@ -0,0 +1,212 @@
+package article
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const (
+	minLanguageScore  = 4 // 得分低于该值视为无法判断
+	minLanguageMargin = 2 // 最高分需领先第二名的分数，避免在相近语言间误判
+)
+
+// languageRule 语言特征：匹配到一次即加上对应分值（同一特征只计一次）
+type languageRule struct {
+	pattern *regexp.Regexp
+	score   int
+}
+
+// rule 构造多行模式的语言特征
+func rule(pattern string, score int) languageRule {
+	return languageRule{pattern: regexp.MustCompile("(?m)" + pattern), score: score}
+}
+
+// languageRules 各语言的关键字和语法特征
+var languageRules = map[string][]languageRule{
+	"go": {
+		rule(`^package \w+\s*$`, 3),
+		rule(`\bfunc\s+(\(\w+ \*?\w+\)\s*)?\w+\(`, 3),
+		rule(`^import \($`, 2),
+		rule(`\bfmt\.\w+\(`, 2),
+		rule(`\berr != nil\b`, 3),
+		rule(`\w+ := `, 1),
+		rule(`\bgo func\(|\bchan \w+|<-\w+`, 2),
+	},
+	"python": {
+		rule(`^\s*def \w+\(.*\)( -> .+)?:\s*$`, 3),
+		rule(`^\s*class \w+(\(.*\))?:\s*$`, 3),
+		rule(`^\s*(elif .*|except.*|try|finally):\s*$`, 3),
+		rule(`^\s*from [\w.]+ import \w+`, 2),
+		rule(`\bself\.\w+`, 2),
+		rule(`__name__ == ['"]__main__['"]`, 3),
+		rule(`\bprint\(`, 1),
+		rule(`\b(None|True|False)\b`, 1),
+	},
+	"javascript": {
+		rule(`\bconsole\.log\(`, 3),
+		rule(`\brequire\(['"]`, 3),
+		rule(`^\s*(export )?(async )?function\s*\w*\(`, 2),
+		rule(`^\s*import .* from ['"]`, 2),
+		rule(`\b(const|let) \w+ = `, 1),
+		rule(`=> ?[{(\w]`, 1),
+		rule(`===|!==`, 2),
+		rule(`\b(document|window)\.\w+`, 2),
+	},
+	"java": {
+		rule(`\bpublic (static |final |abstract )*(class|interface|void|enum)\b`, 3),
+		rule(`\bSystem\.out\.print`, 3),
+		rule(`^\s*import [\w.]+(\.\*)?;\s*$`, 3),
+		rule(`^\s*@Override\b`, 3),
+		rule(`\bString\[\] args\b`, 3),
+		rule(`\bprivate (static |final )*\w+(<.*>)? \w+( = .*)?;`, 2),
+	},
+	"c": {
+		rule(`^#include\s*[<"]\w+\.h[>"]`, 3),
+		rule(`\bprintf\(`, 2),
+		rule(`\b(malloc|free|sizeof)\(`, 2),
+		rule(`\bint main\(`, 2),
+	},
+	"cpp": {
+		rule(`^#include\s*<(iostream|vector|string|map|memory|algorithm)>`, 3),
+		rule(`\bstd::`, 3),
+		rule(`\b(cout|cin)\s*(<<|>>)`, 3),
+		rule(`\btemplate\s*<`, 3),
+		rule(`^\s*using namespace \w+;`, 3),
+		rule(`\bint main\(`, 2),
+	},
+	"rust": {
+		rule(`\bfn \w+(<.*>)?\(`, 3),
+		rule(`\blet mut\b`, 3),
+		rule(`\w+!\(`, 2),
+		rule(`^\s*use \w+(::\w+)+`, 3),
+		rule(`^\s*impl\b`, 2),
+		rule(`&(mut )?(str|self)\b`, 2),
+	},
+	"bash": {
+		rule(`^#!/(usr/)?bin/(env )?(ba)?sh`, 5),
+		rule(`^\s*\$ \w+`, 2),
+		rule(`^\s*(sudo|apt(-get)?|yum|brew|npm|yarn|pip3?|go (get|install|run|build|mod)|docker|kubectl|git|curl|wget|cd|ls|mkdir|echo|export|chmod) `, 3),
+		rule(`^\s*(fi|done|esac)\s*$|; (then|do)\s*$`, 2),
+		rule(`\$\{?\w+\}?`, 1),
+	},
+	"sql": {
+		rule(`(?i)^\s*(select .+ from|insert into|update \w+ set|delete from|create (table|index|database|view)|alter table|drop table)\b`, 4),
+		rule(`(?i)\bwhere\b`, 1),
+		rule(`(?i)\b(inner join|left join|group by|order by)\b`, 1),
+	},
+	"html": {
+		rule(`(?i)^\s*<!DOCTYPE html`, 5),
+		rule(`</?(html|head|body|div|span|p|a|ul|li|script|style|table)\b[^>]*>`, 4),
+	},
+	"xml": {
+		rule(`^\s*<\?xml `, 5),
+	},
+	"css": {
+		rule(`^\s*[.#]?[\w-]+([ ,>+~]+[.#]?[\w-:]+)*\s*\{\s*$`, 2),
+		rule(`^\s*[\w-]+\s*:\s*[^;]+;\s*$`, 2),
+		rule(`^\s*@(media|import|keyframes)\b`, 3),
+	},
+	"dockerfile": {
+		rule(`^FROM \S+`, 3),
+		rule(`^(RUN|CMD|ENTRYPOINT|COPY|ADD|WORKDIR|EXPOSE|ENV) `, 2),
+	},
+}
+
+// yamlLineRegex 匹配 YAML 的键值行和列表行
+var yamlLineRegex = regexp.MustCompile(`^\s*(- )?[\w.-]+:( .*)?$|^\s*- .+$|^\s*#|^---\s*$`)
+
+// DetectLanguage 根据关键字和语法特征猜测代码的语言，无法可靠判断时返回空字符串
+func DetectLanguage(code []string) string {
+	text := strings.TrimSpace(strings.Join(code, "\n"))
+	if text == "" {
+		return ""
+	}
+
+	// 结构化格式直接校验
+	if (strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[")) && json.Valid([]byte(text)) {
+		return "json"
+	}
+	if isYAML(code) {
+		return "yaml"
+	}
+
+	best, bestScore, secondScore := "", 0, 0
+	for language, features := range languageRules {
+		score := 0
+		for _, feature := range features {
+			if feature.pattern.MatchString(text) {
+				score += feature.score
+			}
+		}
+		switch {
+		case score > bestScore:
+			secondScore = bestScore
+			best, bestScore = language, score
+		case score > secondScore:
+			secondScore = score
+		}
+	}
+
+	if bestScore < minLanguageScore || bestScore-secondScore < minLanguageMargin {
+		return ""
+	}
+	return best
+}
+
+// isYAML 所有非空行都符合 YAML 的键值或列表格式，且至少包含一个键值行
+func isYAML(code []string) bool {
+	hasKey := false
+	lines := 0
+	for _, line := range code {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !yamlLineRegex.MatchString(line) {
+			return false
+		}
+		if strings.Contains(line, ":") {
+			hasKey = true
+		}
+		lines++
+	}
+	return hasKey && lines >= 2
+}
+
+// DetectCodeLanguages 为未标注语言的代码块补上自动检测到的语言，返回补充的代码块数量
+func (a *Article) DetectCodeLanguages() int {
+	count := 0
+	start := -1
+	var opening CodeFence
+
+	for i, line := range a.Content {
+		trimmed := strings.TrimSpace(line)
+		fence, ok := ParseCodeFence(trimmed)
+		if !ok {
+			continue
+		}
+
+		if start < 0 {
+			start = i
+			opening = fence
+			continue
+		}
+		if !fence.Closes(opening) {
+			continue
+		}
+
+		// 代码块结束：起始围栏没有语言标注时尝试检测，保留额外参数
+		if opening.Language == "" {
+			if language := DetectLanguage(a.Content[start+1 : i]); language != "" {
+				openingLine := a.Content[start]
+				indent := openingLine[:strings.Index(openingLine, strings.TrimSpace(openingLine))]
+				opening.Language = language
+				a.Content[start] = indent + opening.String()
+				count++
+			}
+		}
+		start = -1
+	}
+
+	return count
+}
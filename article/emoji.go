@@ -0,0 +1,180 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+)
+
+// emojiShortcodeRegex emoji 短代码，如 :smile:、:+1:
+var emojiShortcodeRegex = regexp.MustCompile(`:([a-z0-9_+-]+):`)
+
+// emojiShortcodes 常用 emoji 短代码（与 GitHub 的命名一致）
+var emojiShortcodes = map[string]string{
+	// 表情
+	"smile": "😄", "smiley": "😃", "grinning": "😀", "grin": "😁", "laughing": "😆", "satisfied": "😆",
+	"joy": "😂", "rofl": "🤣", "sweat_smile": "😅", "blush": "😊", "innocent": "😇", "wink": "😉",
+	"slightly_smiling_face": "🙂", "upside_down_face": "🙃", "relaxed": "☺️", "heart_eyes": "😍",
+	"star_struck": "🤩", "kissing_heart": "😘", "yum": "😋", "stuck_out_tongue": "😛",
+	"stuck_out_tongue_winking_eye": "😜", "thinking": "🤔", "neutral_face": "😐", "expressionless": "😑",
+	"no_mouth": "😶", "smirk": "😏", "unamused": "😒", "roll_eyes": "🙄", "grimacing": "😬",
+	"relieved": "😌", "pensive": "😔", "sleepy": "😪", "sleeping": "😴", "mask": "😷",
+	"nerd_face": "🤓", "sunglasses": "😎", "confused": "😕", "worried": "😟", "frowning_face": "☹️",
+	"open_mouth": "😮", "astonished": "😲", "flushed": "😳", "scream": "😱", "cry": "😢", "sob": "😭",
+	"disappointed": "😞", "sweat": "😓", "weary": "😩", "tired_face": "😫", "triumph": "😤",
+	"rage": "😡", "angry": "😠", "exploding_head": "🤯", "partying_face": "🥳", "hugs": "🤗",
+	"shushing_face": "🤫", "face_with_monocle": "🧐", "skull": "💀", "poop": "💩", "hankey": "💩",
+	"clown_face": "🤡", "ghost": "👻", "alien": "👽", "robot": "🤖", "see_no_evil": "🙈",
+	// 手势
+	"+1": "👍", "thumbsup": "👍", "-1": "👎", "thumbsdown": "👎", "ok_hand": "👌", "wave": "👋",
+	"clap": "👏", "raised_hands": "🙌", "pray": "🙏", "muscle": "💪", "v": "✌️", "fist": "✊",
+	"point_right": "👉", "point_left": "👈", "point_up": "☝️", "point_down": "👇", "handshake": "🤝",
+	"crossed_fingers": "🤞", "eyes": "👀", "writing_hand": "✍️",
+	// 符号
+	"heart": "❤️", "broken_heart": "💔", "sparkling_heart": "💖", "100": "💯", "fire": "🔥",
+	"star": "⭐", "star2": "🌟", "sparkles": "✨", "zap": "⚡", "boom": "💥", "tada": "🎉",
+	"confetti_ball": "🎊", "white_check_mark": "✅", "heavy_check_mark": "✔️", "x": "❌",
+	"negative_squared_cross_mark": "❎", "warning": "⚠️", "no_entry": "⛔", "question": "❓",
+	"exclamation": "❗", "heavy_exclamation_mark": "❗", "bangbang": "‼️", "bulb": "💡",
+	"memo": "📝", "pencil": "📝", "pushpin": "📌", "link": "🔗", "lock": "🔒", "unlock": "🔓",
+	"key": "🔑", "bell": "🔔", "mag": "🔍", "chart_with_upwards_trend": "📈",
+	"chart_with_downwards_trend": "📉", "bar_chart": "📊", "calendar": "📆", "clipboard": "📋",
+	"books": "📚", "book": "📖", "bookmark": "🔖", "package": "📦", "email": "📧", "envelope": "✉️",
+	"arrow_right": "➡️", "arrow_left": "⬅️", "arrow_up": "⬆️", "arrow_down": "⬇️",
+	"recycle": "♻️", "heavy_plus_sign": "➕", "heavy_minus_sign": "➖", "new": "🆕", "top": "🔝",
+	"soon": "🔜", "sos": "🆘", "red_circle": "🔴", "large_blue_circle": "🔵", "green_circle": "🟢",
+	"checkered_flag": "🏁", "triangular_flag_on_post": "🚩",
+	// 物品与技术
+	"rocket": "🚀", "bug": "🐛", "wrench": "🔧", "hammer": "🔨", "hammer_and_wrench": "🛠️",
+	"gear": "⚙️", "computer": "💻", "desktop_computer": "🖥️", "keyboard": "⌨️", "iphone": "📱",
+	"floppy_disk": "💾", "cd": "💿", "battery": "🔋", "electric_plug": "🔌", "satellite": "📡",
+	"construction": "🚧", "art": "🎨", "lipstick": "💄", "rotating_light": "🚨", "trophy": "🏆",
+	"medal_sports": "🏅", "dart": "🎯", "gift": "🎁", "coffee": "☕", "beer": "🍺", "beers": "🍻",
+	"pizza": "🍕", "cake": "🍰", "hourglass": "⌛", "alarm_clock": "⏰", "stopwatch": "⏱️",
+	"moneybag": "💰", "gem": "💎", "crown": "👑", "test_tube": "🧪", "microscope": "🔬",
+	"telescope": "🔭", "globe_with_meridians": "🌐", "earth_asia": "🌏",
+	// 自然
+	"sunny": "☀️", "cloud": "☁️", "umbrella": "☔", "snowflake": "❄️", "rainbow": "🌈",
+	"seedling": "🌱", "herb": "🌿", "four_leaf_clover": "🍀", "cherry_blossom": "🌸", "rose": "🌹",
+	"sunflower": "🌻", "cat": "🐱", "dog": "🐶", "panda_face": "🐼", "pig": "🐷", "snake": "🐍",
+	"whale": "🐳", "penguin": "🐧", "turtle": "🐢", "zzz": "💤",
+}
+
+// EmojiFor 返回 emoji 短代码（不含冒号）对应的 Unicode 表情，未收录时返回 false
+func EmojiFor(shortcode string) (string, bool) {
+	emoji, ok := emojiShortcodes[shortcode]
+	return emoji, ok
+}
+
+// ConvertEmojiShortcodes 将正文中的 emoji 短代码（如 :smile:）转换为 Unicode 表情，
+// 代码块、行内代码和公式中的内容保持不变，未收录的短代码原样保留，
+// 同一行中图片占位符的位置随之调整。返回转换的短代码数量
+func (a *Article) ConvertEmojiShortcodes() int {
+	count := 0
+	var opening CodeFence
+	inCodeBlock := false
+	mathEnd := -1
+
+	for i, line := range a.Content {
+		trimmed := strings.TrimSpace(line)
+		if i < mathEnd {
+			continue
+		}
+		if fence, ok := ParseCodeFence(trimmed); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+		if end := MathBlockEnd(a.Content, i); end > 0 {
+			mathEnd = end
+			continue
+		}
+		if !strings.Contains(line, ":") {
+			continue
+		}
+
+		converted, shifts := convertEmojiLine(line)
+		if len(shifts) == 0 {
+			continue
+		}
+		a.Content[i] = converted
+		count += len(shifts)
+		shiftImageColumns(a.Images, i, shifts)
+	}
+	return count
+}
+
+// convertEmojiLine 转换一行中行内代码以外的 emoji 短代码
+func convertEmojiLine(line string) (string, []lineShift) {
+	var builder strings.Builder
+	var shifts []lineShift
+	last := 0
+	formulas := InlineMathSpans(line)
+
+	for _, span := range textSpans(line) {
+		for _, match := range emojiShortcodeRegex.FindAllStringSubmatchIndex(line[span[0]:span[1]], -1) {
+			emoji, ok := emojiShortcodes[line[span[0]+match[2]:span[0]+match[3]]]
+			if !ok {
+				continue
+			}
+			start, end := span[0]+match[0], span[0]+match[1]
+			if insideSpans(formulas, start) {
+				continue
+			}
+			builder.WriteString(line[last:start])
+			builder.WriteString(emoji)
+			shifts = append(shifts, lineShift{position: start, delta: len(emoji) - (end - start)})
+			last = end
+		}
+	}
+	if len(shifts) == 0 {
+		return line, nil
+	}
+	builder.WriteString(line[last:])
+	return builder.String(), shifts
+}
+
+// textSpans 返回一行中行内代码（`code`、“code“）以外的区间，
+// 行内代码以相同数量的反引号开始和结束，找不到结束反引号时视为普通文本
+func textSpans(line string) [][2]int {
+	var spans [][2]int
+	start := 0
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		open := i
+		i = backtickRunEnd(line, i)
+		length := i - open
+
+		for j := i; j < len(line); {
+			if line[j] != '`' {
+				j++
+				continue
+			}
+			end := backtickRunEnd(line, j)
+			if end-j == length {
+				spans = append(spans, [2]int{start, open})
+				start, i = end, end
+				break
+			}
+			j = end
+		}
+	}
+	return append(spans, [2]int{start, len(line)})
+}
+
+// backtickRunEnd 返回从 i 开始的连续反引号之后的位置
+func backtickRunEnd(line string, i int) int {
+	for i < len(line) && line[i] == '`' {
+		i++
+	}
+	return i
+}
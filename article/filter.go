@@ -0,0 +1,59 @@
+package article
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Filter 按草稿状态和标签筛选要发布的文章
+type Filter struct {
+	IncludeTags []string // 只保留带有其中任一标签的文章，为空时不限制
+	ExcludeTags []string // 排除带有其中任一标签的文章，优先于 IncludeTags
+	KeepDrafts  bool     // 保留 frontmatter 中 draft: true 的草稿，默认跳过
+}
+
+// HasTag 判断文章是否带有标签（忽略大小写和首尾空白）
+func (a *Article) HasTag(tag string) bool {
+	tag = strings.TrimSpace(tag)
+	for _, t := range a.Meta.Tags {
+		if strings.EqualFold(strings.TrimSpace(t), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match 判断文章是否通过筛选，未通过时返回原因
+func (f Filter) Match(a *Article) (bool, string) {
+	if a.Meta.Draft && !f.KeepDrafts {
+		return false, "草稿（draft: true）"
+	}
+	for _, tag := range f.ExcludeTags {
+		if a.HasTag(tag) {
+			return false, fmt.Sprintf("带有排除的标签 %q", tag)
+		}
+	}
+	if len(f.IncludeTags) == 0 {
+		return true, ""
+	}
+	for _, tag := range f.IncludeTags {
+		if a.HasTag(tag) {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("不含标签 %s", strings.Join(f.IncludeTags, "、"))
+}
+
+// Apply 返回通过筛选的文章，按原顺序排列，跳过的文章输出日志
+func (f Filter) Apply(articles []*Article) []*Article {
+	kept := make([]*Article, 0, len(articles))
+	for _, a := range articles {
+		if ok, reason := f.Match(a); !ok {
+			log.Printf("⏭️ 跳过《%s》: %s", a.Title, reason)
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
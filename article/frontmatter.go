@@ -0,0 +1,182 @@
+package article
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatter 文章开头的 YAML 元数据（可选）
+//
+//	---
+//	original: true
+//	---
+//	文章标题
+//	正文...
+type FrontMatter struct {
+	Title       string            `yaml:"title" json:"title,omitempty"`               // 文章标题（设置后不再把第一行当标题）
+	Original    bool              `yaml:"original" json:"original,omitempty"`         // 是否声明原创
+	PublishAt   string            `yaml:"publish_at" json:"publish_at,omitempty"`     // 定时发布时间（本地时间），如 2024-06-01 08:00
+	Weight      int               `yaml:"weight" json:"weight,omitempty"`             // 排序权重，越小越先发布（按 weight 排序时生效）
+	Date        string            `yaml:"date" json:"date,omitempty"`                 // 文章日期，如 2024-06-01（按 date 排序时生效）
+	Cover       string            `yaml:"cover" json:"cover,omitempty"`               // 封面图路径（相对文章所在目录），未设置时可自动生成
+	Description string            `yaml:"description" json:"description,omitempty"`   // 文章摘要，未设置时从正文自动提取
+	Titles      map[string]string `yaml:"titles" json:"titles,omitempty"`             // 各平台使用的标题（平台标识或名称 -> 标题），未配置的平台使用 Title
+	Series      string            `yaml:"series" json:"series,omitempty"`             // 所属系列（连载）名称
+	Order       int               `yaml:"order" json:"order,omitempty"`               // 在系列中的序号，从 1 开始
+	OriginalURL string            `yaml:"original_url" json:"original_url,omitempty"` // 原文（全文）链接，正文按平台截断时附在引流文字中
+	Lang        string            `yaml:"lang" json:"lang,omitempty"`                 // 文章语言，如 zh、en，用于按语言路由平台；未设置时按正文推断
+	Platforms   StringList        `yaml:"platforms" json:"platforms,omitempty"`       // 文章发布的平台（平台标识或名称），未设置时发布到所有启用的平台
+	Tags        StringList        `yaml:"tags" json:"tags,omitempty"`                 // 文章标签
+	Draft       bool              `yaml:"draft" json:"draft,omitempty"`               // 草稿，发布时自动跳过
+}
+
+// StringList frontmatter 中的列表字段（platforms、tags），支持 YAML 列表和逗号分隔的字符串两种写法：
+//
+//	platforms: [juejin, zhihu]
+//	platforms: juejin, zhihu
+type StringList []string
+
+// UnmarshalYAML 解析列表或逗号分隔的字符串，去掉空白和空项
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	var items []string
+	if value.Kind == yaml.ScalarNode {
+		items = strings.Split(value.Value, ",")
+	} else if err := value.Decode(&items); err != nil {
+		return fmt.Errorf("应为列表或逗号分隔的字符串: %v", err)
+	}
+	list := make(StringList, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	*l = list
+	return nil
+}
+
+// publishTimeLayouts 支持的定时发布时间格式
+var publishTimeLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+}
+
+// ParsePublishTime 解析定时发布时间，未带时区的时间按本地时区处理
+func ParsePublishTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range publishTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法识别的发布时间: %s（格式如 2024-06-01 08:00）", value)
+}
+
+// ScheduledTime 返回文章的定时发布时间，未设置时第二个返回值为 false
+func (a *Article) ScheduledTime() (time.Time, bool) {
+	if a.Meta.PublishAt == "" {
+		return time.Time{}, false
+	}
+	t, err := ParsePublishTime(a.Meta.PublishAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ArticleDate 返回 frontmatter 中 date 指定的文章日期，未设置或格式有误时第二个返回值为 false
+func (a *Article) ArticleDate() (time.Time, bool) {
+	t, err := parseArticleDate(a.Meta.Date)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// TitleFor 返回文章在指定平台使用的标题：按平台标识或名称（不区分大小写）在 frontmatter 的 titles 中查找，
+// 未配置时使用默认标题
+func (a *Article) TitleFor(platformKeys ...string) string {
+	for key, title := range a.Meta.Titles {
+		title = strings.TrimSpace(title)
+		if title == "" {
+			continue
+		}
+		for _, platformKey := range platformKeys {
+			if strings.EqualFold(strings.TrimSpace(key), platformKey) {
+				return title
+			}
+		}
+	}
+	return a.Title
+}
+
+// PublishesTo 判断文章是否发布到平台，platformKeys 为平台的标识和名称；
+// frontmatter 未设置 platforms 时发布到所有启用的平台
+func (a *Article) PublishesTo(platformKeys ...string) bool {
+	if len(a.Meta.Platforms) == 0 {
+		return true
+	}
+	for _, target := range a.Meta.Platforms {
+		for _, platformKey := range platformKeys {
+			if strings.EqualFold(target, platformKey) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithTitle 返回使用指定标题的文章副本，原文章不受影响
+func (a *Article) WithTitle(title string) *Article {
+	titled := *a
+	titled.Title = title
+	return &titled
+}
+
+// CoverPath 返回 frontmatter 中指定的封面图路径（相对路径基于文章所在目录解析），未指定时返回空字符串
+func (a *Article) CoverPath() string {
+	cover := strings.TrimSpace(a.Meta.Cover)
+	if cover == "" || isRemoteImage(cover) || filepath.IsAbs(cover) {
+		return cover
+	}
+	return filepath.Join(filepath.Dir(a.Path), cover)
+}
+
+// splitFrontMatter 拆分文件开头的 frontmatter，返回元数据和剩余行数。
+// 只有文件第一行是 --- 且后面存在闭合的 ---（或 ...）时才视为 frontmatter，
+// 正文中的 --- 分割线不会被误判。
+func splitFrontMatter(lines []string) (FrontMatter, int, error) {
+	var meta FrontMatter
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return meta, 0, nil
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "---" || trimmed == "..." {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		// 没有闭合分隔符，按普通正文处理
+		return meta, 0, nil
+	}
+
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &meta); err != nil {
+		return meta, 0, fmt.Errorf("解析 frontmatter 失败: %v", err)
+	}
+	if meta.PublishAt != "" {
+		if _, err := ParsePublishTime(meta.PublishAt); err != nil {
+			return meta, 0, fmt.Errorf("解析 frontmatter 失败: %v", err)
+		}
+	}
+	return meta, end + 1, nil
+}
@@ -0,0 +1,80 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// htmlTagLineRegex 以开始或结束标签开头的行，捕获标签名
+	htmlTagLineRegex = regexp.MustCompile(`^</?([a-zA-Z][a-zA-Z0-9-]*)(\s|/?>|$)`)
+	// htmlCompleteTagRegex 整行只有一个完整的开始或结束标签
+	htmlCompleteTagRegex = regexp.MustCompile(`^(<[a-zA-Z][a-zA-Z0-9-]*(\s+[a-zA-Z_:][a-zA-Z0-9_.:-]*(\s*=\s*("[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*\s*/?>|</[a-zA-Z][a-zA-Z0-9-]*\s*>)$`)
+)
+
+// rawTextTags 内容中可以有空行、直到结束标签才结束的元素
+var rawTextTags = []string{"pre", "script", "style", "textarea"}
+
+// blockTags 块级 HTML 元素（与 CommonMark 的 HTML 块规则一致），
+// 以这些标签开头的行无论后面跟什么都视为 HTML 块
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "body": true,
+	"caption": true, "center": true, "col": true, "colgroup": true, "dd": true,
+	"details": true, "dialog": true, "div": true, "dl": true, "dt": true,
+	"fieldset": true, "figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "iframe": true, "legend": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "section": true,
+	"summary": true, "table": true, "tbody": true, "td": true, "tfoot": true,
+	"th": true, "thead": true, "tr": true, "ul": true, "video": true, "audio": true,
+	"source": true, "picture": true,
+}
+
+// HTMLBlockEnd 判断第 start 行是否开始一个 HTML 原样块，是则返回块结束后的下一行行号，否则返回 -1。
+// 块的范围按 CommonMark 规则：<pre>/<script>/<style>/<textarea> 到对应结束标签，
+// 注释到 -->，其余块级标签或整行只有一个标签时到下一个空行
+func HTMLBlockEnd(lines []string, start int) int {
+	trimmed := strings.TrimSpace(lines[start])
+	if !strings.HasPrefix(trimmed, "<") {
+		return -1
+	}
+
+	if strings.HasPrefix(trimmed, "<!--") {
+		return blockEndAt(lines, start, "-->")
+	}
+
+	match := htmlTagLineRegex.FindStringSubmatch(trimmed)
+	if match == nil {
+		return -1
+	}
+	tag := strings.ToLower(match[1])
+
+	if !strings.HasPrefix(trimmed, "</") {
+		for _, rawTag := range rawTextTags {
+			if tag == rawTag {
+				return blockEndAt(lines, start, "</"+rawTag+">")
+			}
+		}
+	}
+
+	if !blockTags[tag] && !htmlCompleteTagRegex.MatchString(trimmed) {
+		// 以行内标签开头的普通段落，如 <b>加粗</b> 文字
+		return -1
+	}
+
+	end := start + 1
+	for end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+		end++
+	}
+	return end
+}
+
+// blockEndAt 从第 start 行开始查找包含 terminator 的行（不区分大小写），返回其下一行行号，找不到时到文末
+func blockEndAt(lines []string, start int, terminator string) int {
+	for i := start; i < len(lines); i++ {
+		if strings.Contains(strings.ToLower(lines[i]), terminator) {
+			return i + 1
+		}
+	}
+	return len(lines)
+}
@@ -0,0 +1,77 @@
+package article
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 按内容推断出的文章语言
+const (
+	LanguageChinese = "zh"
+	LanguageEnglish = "en"
+)
+
+// chineseRatioThreshold 汉字占文字（汉字和拉丁字母）的比例达到该值时判定为中文文章。
+// 中文技术文章夹杂大量英文术语和代码标识符，阈值取得较低
+const chineseRatioThreshold = 0.2
+
+// NormalizeLanguage 把语言代码规范化为小写的主语言标签，如 zh-CN、zh_Hans -> zh，EN-us -> en
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// Language 返回文章语言：frontmatter 声明了 lang 时使用声明的语言，否则按正文中的汉字占比推断
+func (a *Article) Language() string {
+	if lang := NormalizeLanguage(a.Meta.Lang); lang != "" {
+		return lang
+	}
+	return DetectArticleLanguage(a.Title, a.Content)
+}
+
+// DetectArticleLanguage 按汉字占文字的比例推断语言，达到阈值为中文，否则为英文。代码块中的内容不计入
+func DetectArticleLanguage(title string, content []string) string {
+	han, latin := countScripts(title)
+
+	var opening CodeFence
+	inCodeBlock := false
+	for _, line := range content {
+		if fence, ok := ParseCodeFence(strings.TrimSpace(line)); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+		lineHan, lineLatin := countScripts(line)
+		han += lineHan
+		latin += lineLatin
+	}
+
+	if han > 0 && float64(han) >= float64(han+latin)*chineseRatioThreshold {
+		return LanguageChinese
+	}
+	return LanguageEnglish
+}
+
+// countScripts 统计文字中的汉字和拉丁字母数
+func countScripts(text string) (int, int) {
+	han, latin := 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	return han, latin
+}
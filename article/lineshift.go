@@ -0,0 +1,91 @@
+package article
+
+import "strings"
+
+// lineShift 行内一处文本替换：原行中的字节位置和替换后长度的变化
+type lineShift struct {
+	position int
+	delta    int
+}
+
+// shiftImageColumns 行内文本被替换后，调整该行图片占位符的字节偏移
+func shiftImageColumns(images []Image, lineIndex int, shifts []lineShift) {
+	for i := range images {
+		if images[i].LineIndex != lineIndex {
+			continue
+		}
+		column := images[i].Column
+		for _, shift := range shifts {
+			if shift.position < column {
+				images[i].Column += shift.delta
+			}
+		}
+	}
+}
+
+// insideSpans 判断字节位置是否落在任一区间 [start, end) 内
+func insideSpans(spans [][2]int, position int) bool {
+	for _, span := range spans {
+		if position >= span[0] && position < span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// LineEdit 对正文一行中字节区间 [Start, End) 的替换
+type LineEdit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// EditLine 按 edits 替换正文第 lineIndex 行的文本（edits 按位置排列、互不重叠），同一行图片占位符的位置随之调整。
+// 与图片占位符重叠的替换会被跳过，避免破坏占位符。返回实际应用的替换数
+func (a *Article) EditLine(lineIndex int, edits []LineEdit) int {
+	line := a.Content[lineIndex]
+	placeholders := a.imagePlaceholderSpans(lineIndex)
+	var builder strings.Builder
+	var shifts []lineShift
+	last := 0
+	for _, edit := range edits {
+		if edit.Start < last || edit.End > len(line) || overlapsSpans(placeholders, edit.Start, edit.End) {
+			continue
+		}
+		builder.WriteString(line[last:edit.Start])
+		builder.WriteString(edit.Text)
+		shifts = append(shifts, lineShift{position: edit.Start, delta: len(edit.Text) - (edit.End - edit.Start)})
+		last = edit.End
+	}
+	if len(shifts) == 0 {
+		return 0
+	}
+	builder.WriteString(line[last:])
+	a.Content[lineIndex] = builder.String()
+	shiftImageColumns(a.Images, lineIndex, shifts)
+	return len(shifts)
+}
+
+// imagePlaceholderSpans 返回一行中图片占位符的字节区间
+func (a *Article) imagePlaceholderSpans(lineIndex int) [][2]int {
+	line := a.Content[lineIndex]
+	var spans [][2]int
+	for _, index := range a.ImagesOnLine(lineIndex) {
+		column := a.Images[index].Column
+		placeholder := PlaceholderFor(index)
+		if column >= 0 && column+len(placeholder) <= len(line) && line[column:column+len(placeholder)] == placeholder {
+			spans = append(spans, [2]int{column, column + len(placeholder)})
+		}
+	}
+	return spans
+}
+
+// overlapsSpans 判断字节区间 [start, end) 是否与任一区间重叠
+func overlapsSpans(spans [][2]int, start, end int) bool {
+	for _, span := range spans {
+		if start < span[1] && end > span[0] {
+			return true
+		}
+	}
+	return false
+}
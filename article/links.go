@@ -0,0 +1,90 @@
+package article
+
+import (
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// localLinkRegex 指向本地 Markdown 文件的链接，如 [见](./other.md)、[见](other.md#章节)
+var localLinkRegex = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*([^)\s]+?\.md)(#[^)\s]*)?\s*\)`)
+
+// WithResolvedLinks 返回相对链接替换后的文章副本：指向其他本地文章的链接替换为 urlFor 返回的已发布链接
+// （urlFor 的参数为目标文章的路径，与 Article.Path 的写法一致），返回空字符串时只保留链接文字。
+// 代码块和行内代码中的内容保持不变，同一行中图片占位符的位置随之调整，原文章不受影响。
+// 返回的两个数量分别为替换为链接和转为纯文本的链接数
+func (a *Article) WithResolvedLinks(urlFor func(path string) string) (*Article, int, int) {
+	content := make([]string, len(a.Content))
+	copy(content, a.Content)
+	images := make([]Image, len(a.Images))
+	copy(images, a.Images)
+
+	dir := filepath.Dir(a.Path)
+	resolved, unresolved := 0, 0
+	var opening CodeFence
+	inCodeBlock := false
+	for i, line := range content {
+		if fence, ok := ParseCodeFence(strings.TrimSpace(line)); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock || !strings.Contains(line, ".md") {
+			continue
+		}
+
+		var builder strings.Builder
+		var shifts []lineShift
+		last := 0
+		for _, span := range textSpans(line) {
+			for _, match := range localLinkRegex.FindAllStringSubmatchIndex(line[span[0]:span[1]], -1) {
+				target := line[span[0]+match[6] : span[0]+match[7]]
+				if match[3] > match[2] || !isRelativeLink(target) {
+					continue
+				}
+				if unescaped, err := url.PathUnescape(target); err == nil {
+					target = unescaped
+				}
+
+				text := line[span[0]+match[4] : span[0]+match[5]]
+				replacement := text
+				if link := urlFor(filepath.Join(dir, target)); link != "" {
+					replacement = "[" + text + "](" + link + ")"
+					resolved++
+				} else {
+					unresolved++
+				}
+
+				start, end := span[0]+match[0], span[0]+match[1]
+				builder.WriteString(line[last:start])
+				builder.WriteString(replacement)
+				shifts = append(shifts, lineShift{position: start, delta: len(replacement) - (end - start)})
+				last = end
+			}
+		}
+		if len(shifts) == 0 {
+			continue
+		}
+		builder.WriteString(line[last:])
+		content[i] = builder.String()
+		shiftImageColumns(images, i, shifts)
+	}
+
+	if resolved == 0 && unresolved == 0 {
+		return a, 0, 0
+	}
+	converted := *a
+	converted.Content = content
+	converted.Images = images
+	return &converted, resolved, unresolved
+}
+
+// isRelativeLink 判断链接是否为相对路径（不含协议，也不以 / 开头）
+func isRelativeLink(target string) bool {
+	return !strings.Contains(target, "://") && !strings.HasPrefix(target, "/")
+}
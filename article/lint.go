@@ -0,0 +1,168 @@
+package article
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineLength 单行最大字符数，超过则提示
+const maxLineLength = 500
+
+// placeholderRegex 匹配正文中的图片占位符关键字
+var placeholderRegex = regexp.MustCompile(`IMAGE_PLACEHOLDER(?:_(\d+))?`)
+
+// LintWarning 文章检查警告
+type LintWarning struct {
+	Line    int    `json:"line"`    // 所在文件行号（从1开始，0表示整篇文章）
+	Message string `json:"message"` // 警告内容
+}
+
+// String 警告的字符串表示
+func (w LintWarning) String() string {
+	if w.Line > 0 {
+		return fmt.Sprintf("第%d行: %s", w.Line, w.Message)
+	}
+	return w.Message
+}
+
+// Lint 检查文章中可能导致发布异常的 Markdown 问题，返回警告列表
+func (a *Article) Lint() []LintWarning {
+	warnings := make([]LintWarning, 0)
+
+	// 检查图片文件是否存在
+	for _, img := range a.Images {
+		if isRemoteImage(img.RelativePath) {
+			continue
+		}
+		if _, err := os.Stat(img.AbsolutePath); err != nil {
+			warnings = append(warnings, LintWarning{
+				Line:    a.fileLineNumber(img.LineIndex),
+				Message: fmt.Sprintf("图片文件不存在: %s", img.RelativePath),
+			})
+		}
+	}
+
+	inCodeBlock := false
+	codeFence := ""
+	codeBlockStart := 0
+	lastHeadingLevel := 1 // 第一行标题视为一级标题
+
+	for i, line := range a.Content {
+		lineNumber := a.fileLineNumber(i)
+		trimmed := strings.TrimSpace(line)
+
+		// 超长行
+		if length := utf8.RuneCountInString(line); length > maxLineLength {
+			warnings = append(warnings, LintWarning{
+				Line:    lineNumber,
+				Message: fmt.Sprintf("行过长（%d 字符），部分平台可能截断或卡顿", length),
+			})
+		}
+
+		// 代码块开始/结束
+		if fence := codeFenceOf(trimmed); fence != "" {
+			if !inCodeBlock {
+				inCodeBlock = true
+				codeFence = fence
+				codeBlockStart = lineNumber
+			} else if strings.HasPrefix(trimmed, codeFence) && strings.TrimSpace(strings.TrimLeft(trimmed, codeFence[:1])) == "" {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		// 公式块未闭合时 $$ 会被当作普通文本
+		if strings.HasPrefix(trimmed, "$$") && MathBlockEnd(a.Content, i) < 0 {
+			warnings = append(warnings, LintWarning{Line: lineNumber, Message: "公式块 $$ 未闭合，公式会按普通文本发布"})
+		}
+
+		// 标题检查
+		if level, text, ok := parseHeading(trimmed); ok {
+			if text == "" {
+				warnings = append(warnings, LintWarning{Line: lineNumber, Message: "空标题"})
+			}
+			if level > lastHeadingLevel+1 {
+				warnings = append(warnings, LintWarning{
+					Line:    lineNumber,
+					Message: fmt.Sprintf("标题层级跳跃: 从 %d 级直接跳到 %d 级", lastHeadingLevel, level),
+				})
+			}
+			lastHeadingLevel = level
+		}
+
+		// 占位符关键字冲突（解析器生成的占位符除外）
+		for _, match := range placeholderRegex.FindAllStringSubmatchIndex(line, -1) {
+			if match[2] >= 0 {
+				index, err := strconv.Atoi(line[match[2]:match[3]])
+				if err == nil && index < len(a.Images) && a.Images[index].LineIndex == i && a.Images[index].Column == match[0] {
+					continue
+				}
+			}
+			warnings = append(warnings, LintWarning{
+				Line:    lineNumber,
+				Message: "正文包含保留关键字 IMAGE_PLACEHOLDER，会与图片占位符冲突",
+			})
+			break
+		}
+	}
+
+	if inCodeBlock {
+		warnings = append(warnings, LintWarning{
+			Line:    codeBlockStart,
+			Message: "代码块未闭合，后续内容会被当作代码",
+		})
+	}
+
+	return warnings
+}
+
+// fileLineNumber 将正文行索引转换为文件行号（考虑标题和 frontmatter 占用的行）
+func (a *Article) fileLineNumber(contentIndex int) int {
+	if a.ContentStartLine == 0 {
+		return contentIndex + 2
+	}
+	return a.ContentStartLine + contentIndex
+}
+
+// codeFenceOf 返回行首的代码块围栏（``` 或 ~~~），不是围栏则返回空字符串
+func codeFenceOf(trimmed string) string {
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, fence) {
+			end := 0
+			for end < len(trimmed) && trimmed[end] == fence[0] {
+				end++
+			}
+			return trimmed[:end]
+		}
+	}
+	return ""
+}
+
+// parseHeading 解析 ATX 风格标题，返回层级和标题文本
+func parseHeading(trimmed string) (int, string, bool) {
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+	rest := trimmed[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "", false
+	}
+	return level, strings.TrimSpace(strings.TrimRight(strings.TrimSpace(rest), "#")), true
+}
+
+// isRemoteImage 判断图片是否为网络地址
+func isRemoteImage(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
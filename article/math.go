@@ -0,0 +1,164 @@
+package article
+
+import "strings"
+
+// MathBlockEnd 判断第 start 行是否开始一个 $$ 公式块，是则返回块结束后的下一行行号，否则返回 -1。
+// 公式块以 $$ 开头、以 $$ 结尾的行结束（可以在同一行，如 $$E=mc^2$$），找不到结尾时不视为公式块
+func MathBlockEnd(lines []string, start int) int {
+	trimmed := strings.TrimSpace(lines[start])
+	if !strings.HasPrefix(trimmed, "$$") {
+		return -1
+	}
+	if len(trimmed) >= 4 && strings.HasSuffix(trimmed, "$$") {
+		return start + 1
+	}
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasSuffix(strings.TrimSpace(lines[i]), "$$") {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// InlineMathSpans 返回一行中行内公式的区间（包含 $ 或 $$ 分隔符），行内代码和转义的 \$ 除外。
+// 与 Pandoc 的规则一致：开始的 $ 后面和结束的 $ 前面不能是空白，结束的 $ 后面不能紧跟数字，
+// 因此 "价格 $5 到 $10" 这样的金额不会被当成公式
+func InlineMathSpans(line string) [][2]int {
+	if !strings.Contains(line, "$") {
+		return nil
+	}
+
+	var spans [][2]int
+	for _, text := range textSpans(line) {
+		for i := text[0]; i < text[1]; i++ {
+			if line[i] == '\\' {
+				i++
+				continue
+			}
+			if line[i] != '$' {
+				continue
+			}
+
+			delimiter := "$"
+			if i+1 < text[1] && line[i+1] == '$' {
+				delimiter = "$$"
+			}
+			if end := closingDollar(line[:text[1]], i+len(delimiter), delimiter); end > 0 {
+				spans = append(spans, [2]int{i, end})
+				i = end - 1
+				continue
+			}
+			i += len(delimiter) - 1
+		}
+	}
+	return spans
+}
+
+// closingDollar 从 start 开始查找与 delimiter 配对的结束分隔符，返回公式结束后的位置，找不到时返回 -1
+func closingDollar(line string, start int, delimiter string) int {
+	if start >= len(line) || isSpaceByte(line[start]) {
+		return -1
+	}
+	for i := start; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if !strings.HasPrefix(line[i:], delimiter) {
+			continue
+		}
+		end := i + len(delimiter)
+		if delimiter == "$" {
+			if end < len(line) && line[end] == '$' {
+				// $x$$ 不是合法的行内公式结尾
+				return -1
+			}
+			if isSpaceByte(line[i-1]) || (end < len(line) && line[end] >= '0' && line[end] <= '9') {
+				continue
+			}
+		}
+		if i == start {
+			return -1
+		}
+		return end
+	}
+	return -1
+}
+
+// isSpaceByte 判断字节是否为空白字符
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+// WithDoubleDollarMath 返回公式统一改写为单行 $$...$$ 的文章副本：行内公式 $x$ 改为 $$x$$，
+// 跨多行的公式块合并到第一行（其余行留空，行号不变）。知乎的 Markdown 导入只识别这种写法，
+// 原文章不受影响
+func (a *Article) WithDoubleDollarMath() *Article {
+	content := make([]string, len(a.Content))
+	copy(content, a.Content)
+	images := make([]Image, len(a.Images))
+	copy(images, a.Images)
+
+	var opening CodeFence
+	inCodeBlock := false
+	for i := 0; i < len(content); i++ {
+		line := content[i]
+		trimmed := strings.TrimSpace(line)
+		if fence, ok := ParseCodeFence(trimmed); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		if end := MathBlockEnd(content, i); end > 0 {
+			parts := make([]string, 0, end-i)
+			for j := i; j < end; j++ {
+				part := strings.TrimSpace(content[j])
+				if j == i {
+					part = strings.TrimPrefix(part, "$$")
+				}
+				if j == end-1 {
+					part = strings.TrimSuffix(part, "$$")
+				}
+				if part = strings.TrimSpace(part); part != "" {
+					parts = append(parts, part)
+				}
+				content[j] = ""
+			}
+			content[i] = "$$" + strings.Join(parts, " ") + "$$"
+			i = end - 1
+			continue
+		}
+
+		var builder strings.Builder
+		var shifts []lineShift
+		last := 0
+		for _, span := range InlineMathSpans(line) {
+			if strings.HasPrefix(line[span[0]:], "$$") {
+				continue
+			}
+			builder.WriteString(line[last:span[0]])
+			builder.WriteString("$" + line[span[0]:span[1]] + "$")
+			shifts = append(shifts, lineShift{position: span[0], delta: 1}, lineShift{position: span[1] - 1, delta: 1})
+			last = span[1]
+		}
+		if len(shifts) == 0 {
+			continue
+		}
+		builder.WriteString(line[last:])
+		content[i] = builder.String()
+		shiftImageColumns(images, i, shifts)
+	}
+
+	converted := *a
+	converted.Content = content
+	converted.Images = images
+	return &converted
+}
@@ -0,0 +1,186 @@
+package article
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MentionKind 正文中社交标记的类型
+type MentionKind string
+
+const (
+	MentionUser  MentionKind = "user"  // @用户名
+	MentionTopic MentionKind = "topic" // #话题#
+)
+
+// maxTopicLength 话题名的最大字符数，更长的 #...# 多半不是话题
+const maxTopicLength = 32
+
+var (
+	// userMentionRegex @ 后的用户名：字母、数字、汉字、下划线和连字符
+	userMentionRegex = regexp.MustCompile(`@([\p{L}\p{N}_-]+)`)
+	// topicMentionRegex #话题#，话题名首尾不能是空白
+	topicMentionRegex = regexp.MustCompile(`#([^#\s](?:[^#]*[^#\s])?)#`)
+	// linkTargetRegex 链接地址（[text](url)、<url>）和裸网址，其中的 @ 和 # 不是社交标记
+	linkTargetRegex = regexp.MustCompile(`\]\([^)]*\)|<[a-zA-Z]+:[^>\s]*>|https?://\S+`)
+)
+
+// Mention 正文中的一个 @提及或 #话题#
+type Mention struct {
+	Kind MentionKind
+	Name string // 用户名或话题名，不含 @ 和 #
+}
+
+// Text 标记在正文中的原文，如 @张三、#Go语言#
+func (m Mention) Text() string {
+	if m.Kind == MentionTopic {
+		return "#" + m.Name + "#"
+	}
+	return "@" + m.Name
+}
+
+// MentionPlaceholderFor 返回第 index 个社交标记在正文中的占位符
+func MentionPlaceholderFor(index int) string {
+	return fmt.Sprintf("MENTION_PLACEHOLDER_%d", index)
+}
+
+// mentionSpan 一行中一个社交标记的字节区间
+type mentionSpan struct {
+	Mention
+	start, end int
+}
+
+// mentionSpans 返回一行中的社交标记，按出现顺序排列。行内代码、公式、链接地址和网址中的内容不识别；
+// @ 前面是字母、数字或 .、_、-、/ 等字符时（如邮箱 a@b.com）不视为提及，# 前面是字母或数字时（如 C#）不视为话题
+func mentionSpans(line string, kinds map[MentionKind]bool) []mentionSpan {
+	if !strings.ContainsAny(line, "@#") {
+		return nil
+	}
+	excluded := InlineMathSpans(line)
+	for _, link := range linkTargetRegex.FindAllStringIndex(line, -1) {
+		excluded = append(excluded, [2]int{link[0], link[1]})
+	}
+
+	var spans []mentionSpan
+	for _, text := range textSpans(line) {
+		segment := line[text[0]:text[1]]
+		if kinds[MentionUser] {
+			for _, match := range userMentionRegex.FindAllStringSubmatchIndex(segment, -1) {
+				start := text[0] + match[0]
+				if insideSpans(excluded, start) || !mentionBoundary(line, start, ".-_/:@%+=") {
+					continue
+				}
+				spans = append(spans, mentionSpan{Mention{MentionUser, segment[match[2]:match[3]]}, start, text[0] + match[1]})
+			}
+		}
+		if kinds[MentionTopic] {
+			for _, match := range topicMentionRegex.FindAllStringSubmatchIndex(segment, -1) {
+				start := text[0] + match[0]
+				name := segment[match[2]:match[3]]
+				if insideSpans(excluded, start) || !mentionBoundary(line, start, "&/") || utf8.RuneCountInString(name) > maxTopicLength {
+					continue
+				}
+				spans = append(spans, mentionSpan{Mention{MentionTopic, name}, start, text[0] + match[1]})
+			}
+		}
+	}
+
+	// 两种标记分别查找，按位置排序并去掉重叠的（如 #@张三#）
+	for i := 1; i < len(spans); i++ {
+		for j := i; j > 0 && spans[j].start < spans[j-1].start; j-- {
+			spans[j], spans[j-1] = spans[j-1], spans[j]
+		}
+	}
+	result := spans[:0]
+	for _, span := range spans {
+		if len(result) > 0 && span.start < result[len(result)-1].end {
+			continue
+		}
+		result = append(result, span)
+	}
+	return result
+}
+
+// mentionBoundary 判断 start 处的标记前面是否为行首、空白或标点（forbidden 中的字符除外），
+// 避免把邮箱、网址、C# 等当成标记
+func mentionBoundary(line string, start int, forbidden string) bool {
+	if start == 0 {
+		return true
+	}
+	previous, _ := utf8.DecodeLastRuneInString(line[:start])
+	if unicode.IsLetter(previous) || unicode.IsDigit(previous) {
+		return false
+	}
+	return !strings.ContainsRune(forbidden, previous)
+}
+
+// WithMentionPlaceholders 返回正文中 kinds 类型的社交标记替换为占位符的文章副本，以及按占位符编号排列的标记，
+// 供支持提及/话题的平台填写正文后再逐个触发选择交互。代码块、公式块和 HTML 块中的内容不处理，原文章不受影响
+func (a *Article) WithMentionPlaceholders(kinds ...MentionKind) (*Article, []Mention) {
+	wanted := make(map[MentionKind]bool, len(kinds))
+	for _, kind := range kinds {
+		wanted[kind] = true
+	}
+
+	content := make([]string, len(a.Content))
+	copy(content, a.Content)
+	images := make([]Image, len(a.Images))
+	copy(images, a.Images)
+
+	var mentions []Mention
+	var opening CodeFence
+	inCodeBlock := false
+	skipUntil := -1
+	for i, line := range content {
+		if i < skipUntil {
+			continue
+		}
+		if fence, ok := ParseCodeFence(strings.TrimSpace(line)); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+		if end := MathBlockEnd(content, i); end > 0 {
+			skipUntil = end
+			continue
+		}
+		if end := HTMLBlockEnd(content, i); end > 0 {
+			skipUntil = end
+			continue
+		}
+
+		spans := mentionSpans(line, wanted)
+		if len(spans) == 0 {
+			continue
+		}
+		var builder strings.Builder
+		var shifts []lineShift
+		last := 0
+		for _, span := range spans {
+			placeholder := MentionPlaceholderFor(len(mentions))
+			builder.WriteString(line[last:span.start])
+			builder.WriteString(placeholder)
+			shifts = append(shifts, lineShift{position: span.start, delta: len(placeholder) - (span.end - span.start)})
+			mentions = append(mentions, span.Mention)
+			last = span.end
+		}
+		builder.WriteString(line[last:])
+		content[i] = builder.String()
+		shiftImageColumns(images, i, shifts)
+	}
+
+	converted := *a
+	converted.Content = content
+	converted.Images = images
+	return &converted, mentions
+}
@@ -1,20 +1,33 @@
 package article
 
 import (
-	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Article 文章结构体
 type Article struct {
-	Title   string   `json:"title"`   // 文章标题
-	Content []string `json:"content"` // 文章正文（每行一个元素）
-	Path    string   `json:"path"`    // 文件路径
-	Images  []Image  `json:"images"`  // 文章中的图片信息
+	Title            string      `json:"title"`              // 文章标题
+	Content          []string    `json:"content"`            // 文章正文（每行一个元素）
+	Path             string      `json:"path"`               // 文件路径
+	Images           []Image     `json:"images"`             // 文章中的图片信息
+	Meta             FrontMatter `json:"meta"`               // frontmatter 元数据
+	ContentStartLine int         `json:"content_start_line"` // 正文第一行在文件中的行号（从1开始）
+	Series           *Series     `json:"-"`                  // 所属系列，由 LinkSeries 关联，不属于系列时为 nil
 }
 
 // Image 图片信息结构体
@@ -23,46 +36,234 @@ type Image struct {
 	RelativePath string `json:"relative_path"` // 相对路径（如 ./images/example.png）
 	AbsolutePath string `json:"absolute_path"` // 绝对路径
 	LineIndex   int    `json:"line_index"`   // 在content中的行索引
+	Column      int    `json:"column"`       // 占位符在该行中的字节偏移（同一行多图时用于区分先后）
+	Width       int    `json:"width,omitempty"`  // 指定的显示宽度（像素），![alt](img =300x200) 语法，0 表示未指定
+	Height      int    `json:"height,omitempty"` // 指定的显示高度（像素），0 表示未指定
+}
+
+// SizeAttributes 返回图片指定尺寸对应的 HTML 属性（如 ` width="300" height="200"`），未指定尺寸时返回空字符串
+func (img Image) SizeAttributes() string {
+	attributes := ""
+	if img.Width > 0 {
+		attributes += fmt.Sprintf(` width="%d"`, img.Width)
+	}
+	if img.Height > 0 {
+		attributes += fmt.Sprintf(` height="%d"`, img.Height)
+	}
+	return attributes
+}
+
+// PlaceholderFor 返回第 index 张图片在正文中的占位符
+func PlaceholderFor(index int) string {
+	return fmt.Sprintf("IMAGE_PLACEHOLDER_%d", index)
+}
+
+// maxPlaceholderLabel 带标签占位符中 alt 文本保留的最大字符数
+const maxPlaceholderLabel = 20
+
+// LabeledPlaceholderFor 返回带 alt 文本的方括号占位符，如 [IMAGE_PLACEHOLDER_0_架构图]。
+// alt 中只保留字母、数字和汉字，空格、换行、方括号、下划线等字符会被去掉，
+// 避免占位符在编辑器里被拆断、被解析成 markdown 语法或与查找规则不一致
+func LabeledPlaceholderFor(index int, altText string) string {
+	label := make([]rune, 0, maxPlaceholderLabel)
+	for _, r := range altText {
+		if len(label) >= maxPlaceholderLabel {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			label = append(label, r)
+		}
+	}
+	if len(label) == 0 {
+		return "[" + PlaceholderFor(index) + "]"
+	}
+	return fmt.Sprintf("[%s_%s]", PlaceholderFor(index), string(label))
 }
 
 // Parser 文章解析器
 type Parser struct {
-	articlesDir string
+	articlesDir      string
+	modifiedAfter    time.Time // 只解析修改时间晚于该时间的文件，零值表示全部解析
+	concurrency      int       // ParseAllFiles 同时解析的文件数，不大于 0 时按 CPU 核数
+	titlePunctuation string    // 从标题末尾去掉的标点
+	filter           Filter    // ParseAllFiles 结果的筛选条件，默认跳过草稿
 }
 
 // NewParser 创建文章解析器
 func NewParser(articlesDir string) *Parser {
 	return &Parser{
-		articlesDir: articlesDir,
+		articlesDir:      articlesDir,
+		titlePunctuation: DefaultTitleTrailingPunctuation,
+	}
+}
+
+// SetTitlePunctuation 设置从标题末尾去掉的标点，为空时不去掉标点
+func (p *Parser) SetTitlePunctuation(punctuation string) {
+	p.titlePunctuation = punctuation
+}
+
+// SetModifiedAfter 设置只解析修改时间晚于 t 的文件（ParseAllFiles 生效），零值表示全部解析
+func (p *Parser) SetModifiedAfter(t time.Time) {
+	p.modifiedAfter = t
+}
+
+// SetFilter 设置 ParseAllFiles 结果的筛选条件（草稿、标签）
+func (p *Parser) SetFilter(filter Filter) {
+	p.filter = filter
+}
+
+// SetConcurrency 设置 ParseAllFiles 同时解析的文件数，不大于 0 时按 CPU 核数
+func (p *Parser) SetConcurrency(n int) {
+	p.concurrency = n
+}
+
+// largeFileSize 超过该大小的文件在解析时给出内存占用警告
+const largeFileSize = 8 * 1024 * 1024
+
+// splitLines 将文件内容按行切分（去掉 Windows 换行符残留的 \r）。
+// 整个文件只转换一次字符串，各行都是它的子串、共用同一块内存，
+// 不会像逐行复制那样让正文在内存中存在两份
+func splitLines(data []byte) []string {
+	text := string(data)
+	if text == "" {
+		return []string{}
+	}
+	text = strings.TrimSuffix(text, "\n")
+
+	lines := make([]string, 0, strings.Count(text, "\n")+1)
+	for {
+		end := strings.IndexByte(text, '\n')
+		if end < 0 {
+			return append(lines, strings.TrimSuffix(text, "\r"))
+		}
+		lines = append(lines, strings.TrimSuffix(text[:end], "\r"))
+		text = text[end+1:]
 	}
 }
 
+// normalizeEncoding 去除 UTF-8 BOM，并拒绝非 UTF-8 编码的文件
+func normalizeEncoding(data []byte) ([]byte, error) {
+	if bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}) {
+		data = data[3:]
+	}
+	if bytes.HasPrefix(data, []byte{0xFF, 0xFE}) || bytes.HasPrefix(data, []byte{0xFE, 0xFF}) {
+		return nil, fmt.Errorf("文件是 UTF-16 编码，请转换为 UTF-8 后重试")
+	}
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("文件不是有效的 UTF-8 编码（可能是 GBK 等编码），请转换为 UTF-8 后重试")
+	}
+	return data, nil
+}
+
+// setextLevel 判断是否为 setext 标题下划线：全是 = 为一级，全是 - 为二级，否则返回 0
+func setextLevel(line string) int {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return 0
+	}
+	switch {
+	case strings.Trim(trimmed, "=") == "":
+		return 1
+	case strings.Trim(trimmed, "-") == "":
+		return 2
+	}
+	return 0
+}
+
+// normalizeSetextHeadings 把 setext 风格标题转换为 # 风格，下划线行替换为空行（保持行号不变）。
+// 下划线前一行为空时（如独立的 ---）仍视为分割线；代码块、HTML 块、列表项和已有 # 标题不处理。
+func normalizeSetextHeadings(lines []string) []string {
+	inCodeBlock := false
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock || trimmed == "" || i+1 >= len(lines) {
+			continue
+		}
+		if end := HTMLBlockEnd(lines, i); end > 0 {
+			i = end - 1
+			continue
+		}
+		if end := MathBlockEnd(lines, i); end > 0 {
+			i = end - 1
+			continue
+		}
+
+		level := setextLevel(lines[i+1])
+		if level == 0 || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ">") || isListLine(trimmed) {
+			continue
+		}
+
+		lines[i] = strings.Repeat("#", level) + " " + trimmed
+		lines[i+1] = ""
+		i++
+	}
+	return lines
+}
+
+// isListLine 判断是否为列表项
+func isListLine(trimmed string) bool {
+	if len(trimmed) >= 2 && strings.ContainsRune("-*+", rune(trimmed[0])) && trimmed[1] == ' ' {
+		return true
+	}
+	digits := 0
+	for digits < len(trimmed) && trimmed[digits] >= '0' && trimmed[digits] <= '9' {
+		digits++
+	}
+	return digits > 0 && digits+1 < len(trimmed) && (trimmed[digits] == '.' || trimmed[digits] == ')') && trimmed[digits+1] == ' '
+}
+
 // ParseFile 解析单个 Markdown 文件
 func (p *Parser) ParseFile(filePath string) (*Article, error) {
-	file, err := os.Open(filePath)
+	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("无法打开文件 %s: %v", filePath, err)
 	}
-	defer file.Close()
+	if len(data) > largeFileSize {
+		log.Printf("⚠️ 文件 %s 较大（%.1f MB），解析和发布时会占用较多内存，建议拆分文章或把内嵌的 base64 图片改为图片文件", filePath, float64(len(data))/1024/1024)
+	}
+	return p.ParseContent(data, filePath)
+}
 
-	scanner := bufio.NewScanner(file)
-	lines := make([]string, 0)
-	
-	// 逐行读取文件
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+// ParseContent 解析 Markdown 内容（如剪贴板中的文章），filePath 作为文章路径，
+// 正文中的相对图片路径基于它所在的目录解析，文件不需要真实存在
+func (p *Parser) ParseContent(data []byte, filePath string) (*Article, error) {
+	data, err := normalizeEncoding(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filePath, err)
 	}
+
+	lines := splitLines(data)
 	
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("读取文件时发生错误: %v", err)
+	// 解析文件开头的 frontmatter（可选）
+	meta, bodyStart, err := splitFrontMatter(lines)
+	if err != nil {
+		return nil, err
 	}
+	lines = lines[bodyStart:]
 	
-	if len(lines) == 0 {
+	if len(lines) == 0 && meta.Title == "" {
 		return nil, fmt.Errorf("文件为空")
 	}
 	
-	// 第一行是标题
-	title := strings.TrimSpace(lines[0])
+	// frontmatter 指定了标题时正文从第一行开始，否则第一行是标题（去掉 # 标题标记）
+	title := NormalizeTitle(meta.Title, p.titlePunctuation)
+	contentStart := 0
+	if title == "" {
+		// frontmatter 的标题规范化后为空（如只有空白或标点）且没有正文
+		if len(lines) == 0 {
+			return nil, fmt.Errorf("标题不能为空")
+		}
+		title = NormalizeTitle(titleFromHeading(lines[0]), p.titlePunctuation)
+		contentStart = 1
+		// setext 风格标题：标题下一行是 === 或 ---，下划线不属于正文
+		if len(lines) > 1 && setextLevel(lines[1]) > 0 {
+			contentStart = 2
+		}
+	}
 	if title == "" {
 		return nil, fmt.Errorf("标题不能为空")
 	}
@@ -71,29 +272,41 @@ func (p *Parser) ParseFile(filePath string) (*Article, error) {
 	content := make([]string, 0)
 	images := make([]Image, 0)
 	
-	if len(lines) > 1 {
-		// 从第二行开始是正文
-		content = lines[1:]
+	if len(lines) > contentStart {
+		content = normalizeSetextHeadings(lines[contentStart:])
 		
 		// 解析图片
 		images = p.parseImages(content, filePath)
 	}
 	
 	article := &Article{
-		Title:   title,
-		Content: content,
-		Path:    filePath,
-		Images:  images,
+		Title:            title,
+		Content:          content,
+		Path:             filePath,
+		Images:           images,
+		Meta:             meta,
+		ContentStartLine: bodyStart + contentStart + 1,
 	}
 	
 	return article, nil
 }
 
-// ParseAllFiles 解析 articles 目录下的所有 .md 文件
+// ParseAllFiles 解析 articles 目录下的所有 .md 文件，目录不存在时自动创建并返回空列表；结果按 SetFilter 的条件筛选。
+// 多个文件并行解析，结果按目录遍历顺序返回；有文件解析失败时返回遍历顺序中第一个失败的错误
 func (p *Parser) ParseAllFiles() ([]*Article, error) {
 	articles := make([]*Article, 0)
 	
-	// 遍历 articles 目录
+	if _, err := os.Stat(p.articlesDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(p.articlesDir, 0755); err != nil {
+			return nil, fmt.Errorf("创建文章目录 %s 失败: %v", p.articlesDir, err)
+		}
+		log.Printf("📁 文章目录 %s 不存在，已自动创建", p.articlesDir)
+		return articles, nil
+	}
+	
+	// 遍历 articles 目录，收集需要解析的文件
+	skipped := 0
+	var paths []string
 	err := filepath.Walk(p.articlesDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -101,11 +314,11 @@ func (p *Parser) ParseAllFiles() ([]*Article, error) {
 		
 		// 只处理 .md 文件
 		if !info.IsDir() && strings.HasSuffix(strings.ToLower(info.Name()), ".md") {
-			article, parseErr := p.ParseFile(path)
-			if parseErr != nil {
-				return fmt.Errorf("解析文件 %s 失败: %v", path, parseErr)
+			if !p.modifiedAfter.IsZero() && !info.ModTime().After(p.modifiedAfter) {
+				skipped++
+				return nil
 			}
-			articles = append(articles, article)
+			paths = append(paths, path)
 		}
 		
 		return nil
@@ -114,11 +327,58 @@ func (p *Parser) ParseAllFiles() ([]*Article, error) {
 	if err != nil {
 		return nil, err
 	}
+	if skipped > 0 {
+		log.Printf("⏭️ 跳过 %d 篇自 %s 以来未修改的文章", skipped, p.modifiedAfter.Format("2006-01-02 15:04:05"))
+	}
+
+	parsed, errs := p.parseFiles(paths)
+	for i, parseErr := range errs {
+		if parseErr != nil {
+			return nil, fmt.Errorf("解析文件 %s 失败: %v", paths[i], parseErr)
+		}
+	}
+	articles = append(articles, p.filter.Apply(parsed)...)
+	LinkSeries(articles)
 	
 	return articles, nil
 }
 
-// GetContentAsString 获取文章正文的字符串形式（按行连接）
+// parseFiles 用 worker pool 并行解析文件，第 i 个结果和错误对应 paths[i]
+func (p *Parser) parseFiles(paths []string) ([]*Article, []error) {
+	articles := make([]*Article, len(paths))
+	errs := make([]error, len(paths))
+
+	workers := p.concurrency
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 每个 worker 只写入自己取到的下标，结果切片无需加锁
+			for i := range indexes {
+				articles[i], errs[i] = p.ParseFile(paths[i])
+			}
+		}()
+	}
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return articles, errs
+}
+
+// GetContentAsString 获取文章正文的字符串形式（按行连接）。
+// 正文各行共用解析时的同一块内存，完整字符串只在调用时按需生成，调用方不宜长期持有
 func (a *Article) GetContentAsString() string {
 	return strings.Join(a.Content, "\n")
 }
@@ -132,60 +392,167 @@ func (a *Article) GetContentLineCount() int {
 func (p *Parser) parseImages(content []string, articlePath string) []Image {
 	images := make([]Image, 0)
 	
-	// Markdown图片正则：![alt文本](图片路径)
-	imageRegex := regexp.MustCompile(`!\[([^\]]*)\]\(([^)]+)\)`)
+	// Markdown图片正则：![alt文本](图片路径)，路径后可带尺寸 =300x200（宽高均可省略其一，如 =300x）
+	imageRegex := regexp.MustCompile(`!\[([^\]]*)\]\(([^)]+?)(?:\s+=(\d*)x(\d*))?\s*\)`)
 	
 	// 获取文章所在目录
 	articleDir := filepath.Dir(articlePath)
 	
 	for i, line := range content {
-		matches := imageRegex.FindAllStringSubmatch(line, -1)
+		matches := imageRegex.FindAllStringSubmatchIndex(line, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		// 按出现顺序逐个替换为占位符，同一行的多张图片各自对应独立的序号
+		var newLine strings.Builder
+		last := 0
 		for _, match := range matches {
-			if len(match) >= 3 {
-				altText := match[1]
-				relativePath := match[2]
-				
-				// 计算绝对路径
-				var absolutePath string
-				if strings.HasPrefix(relativePath, "./") {
-					// 相对路径，基于文章目录解析
-					absolutePath = filepath.Join(articleDir, relativePath[2:])
-				} else if strings.HasPrefix(relativePath, "/") {
-					// 绝对路径
-					absolutePath = relativePath
-				} else {
-					// 相对路径，基于文章目录
-					absolutePath = filepath.Join(articleDir, relativePath)
-				}
-				
-				// 转换为绝对路径
-				absPath, err := filepath.Abs(absolutePath)
-				if err != nil {
-					absPath = absolutePath
-				}
-				
-				image := Image{
-					AltText:     altText,
-					RelativePath: relativePath,
-					AbsolutePath: absPath,
-					LineIndex:   i,
-				}
-				
-				images = append(images, image)
-				
-				// 替换当前行中的图片语法为占位符（统一格式）
-				placeholder := fmt.Sprintf("IMAGE_PLACEHOLDER_%d", len(images)-1)
-				content[i] = imageRegex.ReplaceAllString(line, placeholder)
+			altText := line[match[2]:match[3]]
+			relativePath := line[match[4]:match[5]]
+			
+			// 视频（![video](url) 或视频链接）不当作图片，保留原文，发布时按平台转为嵌入代码或链接
+			if _, ok := videoFromSyntax(altText, relativePath); ok && match[6] < 0 {
+				newLine.WriteString(line[last:match[1]])
+				last = match[1]
+				continue
+			}
+			
+			// 计算绝对路径
+			var absolutePath string
+			if strings.HasPrefix(relativePath, "./") {
+				// 相对路径，基于文章目录解析
+				absolutePath = filepath.Join(articleDir, relativePath[2:])
+			} else if strings.HasPrefix(relativePath, "/") {
+				// 绝对路径
+				absolutePath = relativePath
+			} else {
+				// 相对路径，基于文章目录
+				absolutePath = filepath.Join(articleDir, relativePath)
+			}
+			
+			// 转换为绝对路径
+			absPath, err := filepath.Abs(absolutePath)
+			if err != nil {
+				absPath = absolutePath
 			}
+			
+			newLine.WriteString(line[last:match[0]])
+			
+			image := Image{
+				AltText:     altText,
+				RelativePath: relativePath,
+				AbsolutePath: absPath,
+				LineIndex:   i,
+				Column:      newLine.Len(),
+			}
+			if match[6] >= 0 {
+				image.Width, _ = strconv.Atoi(line[match[6]:match[7]])
+				image.Height, _ = strconv.Atoi(line[match[8]:match[9]])
+			}
+			
+			// 替换当前图片语法为占位符（统一格式）
+			newLine.WriteString(PlaceholderFor(len(images)))
+			images = append(images, image)
+			last = match[1]
 		}
+		newLine.WriteString(line[last:])
+		content[i] = newLine.String()
 	}
 	
 	return images
 }
 
+// ImagesOnLine 返回位于指定行的图片序号，按行内出现顺序排列
+func (a *Article) ImagesOnLine(lineIndex int) []int {
+	indexes := make([]int, 0)
+	for i, img := range a.Images {
+		if img.LineIndex == lineIndex {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.SliceStable(indexes, func(x, y int) bool {
+		return a.Images[indexes[x]].Column < a.Images[indexes[y]].Column
+	})
+	return indexes
+}
+
+// ContentWithPlaceholders 按图片在正文中的真实顺序生成正文，
+// render 返回每张图片的替换文本，行内其它文字保持不变
+func (a *Article) ContentWithPlaceholders(render func(index int, img Image) string) []string {
+	result := make([]string, len(a.Content))
+	for i := range a.Content {
+		result[i] = a.RenderLine(i, func(text string) string { return text }, render)
+	}
+	return result
+}
+
+// RenderLine 渲染正文中的一行：图片保持在行内原来的位置，替换为 image 的结果，
+// 图片以外的文字经过 text 处理（如转义 HTML）
+func (a *Article) RenderLine(lineIndex int, text func(string) string, image func(index int, img Image) string) string {
+	line := a.Content[lineIndex]
+	var builder strings.Builder
+	last := 0
+	for _, index := range a.ImagesOnLine(lineIndex) {
+		img := a.Images[index]
+		placeholder := PlaceholderFor(index)
+		if img.Column < last || img.Column+len(placeholder) > len(line) || line[img.Column:img.Column+len(placeholder)] != placeholder {
+			continue
+		}
+		builder.WriteString(text(line[last:img.Column]))
+		builder.WriteString(image(index, img))
+		last = img.Column + len(placeholder)
+	}
+	builder.WriteString(text(line[last:]))
+	return builder.String()
+}
+
+// IsImageOnlyLine 判断该行是否只有图片（去掉图片后没有其它文字），这样的行按独立的图片段落处理；
+// 图片与文字同行时图片是行内图片，需要保持在文字中的位置
+func (a *Article) IsImageOnlyLine(lineIndex int) bool {
+	if len(a.ImagesOnLine(lineIndex)) == 0 {
+		return false
+	}
+	rest := a.RenderLine(lineIndex, func(text string) string { return text }, func(int, Image) string { return "" })
+	return strings.TrimSpace(rest) == ""
+}
+
 // String 文章的字符串表示
 func (a *Article) String() string {
 	imageCount := len(a.Images)
 	return fmt.Sprintf("标题: %s\n正文行数: %d\n图片数量: %d\n文件路径: %s", 
 		a.Title, a.GetContentLineCount(), imageCount, a.Path)
-}
\ No newline at end of file
+}
+
+// ContentHash 计算文章内容哈希（标题+规范化正文+图片路径），用于判断文章是否有改动
+// 规范化会去除行尾空白、折叠连续空行并忽略首尾空行，这些差异不影响内容
+func (a *Article) ContentHash() string {
+	var builder strings.Builder
+	builder.WriteString(strings.TrimSpace(a.Title))
+	builder.WriteString("\n")
+
+	blankPending := false
+	started := false
+	for _, line := range a.Content {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			blankPending = started
+			continue
+		}
+		if blankPending {
+			builder.WriteString("\n")
+			blankPending = false
+		}
+		builder.WriteString(line)
+		builder.WriteString("\n")
+		started = true
+	}
+
+	for _, img := range a.Images {
+		builder.WriteString(img.RelativePath)
+		builder.WriteString("\n")
+	}
+
+	sum := sha256.Sum256([]byte(builder.String()))
+	return hex.EncodeToString(sum[:])
+}
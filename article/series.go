@@ -0,0 +1,112 @@
+package article
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Series 文章系列（连载），同一系列的文章按 frontmatter 中的 order 排列
+type Series struct {
+	Name     string
+	Articles []*Article
+}
+
+// LinkSeries 把 frontmatter 中 series 相同的文章关联到同一个系列。
+// 系列内按 order 从小到大排列，未设置 order 的排在最后，order 相同时按文件路径排序
+func LinkSeries(articles []*Article) {
+	seriesByName := make(map[string]*Series)
+	for _, a := range articles {
+		name := strings.TrimSpace(a.Meta.Series)
+		if name == "" {
+			a.Series = nil
+			continue
+		}
+		series, ok := seriesByName[name]
+		if !ok {
+			series = &Series{Name: name}
+			seriesByName[name] = series
+		}
+		series.Articles = append(series.Articles, a)
+		a.Series = series
+	}
+
+	for _, series := range seriesByName {
+		sort.SliceStable(series.Articles, func(i, j int) bool {
+			a, b := series.Articles[i].Meta.Order, series.Articles[j].Meta.Order
+			if (a > 0) != (b > 0) {
+				return a > 0
+			}
+			if a != b {
+				return a < b
+			}
+			return series.Articles[i].Path < series.Articles[j].Path
+		})
+	}
+}
+
+// Position 返回文章在系列中的位置（从 0 开始），不在系列中时返回 -1
+func (s *Series) Position(a *Article) int {
+	for i, member := range s.Articles {
+		if member.Path == a.Path {
+			return i
+		}
+	}
+	return -1
+}
+
+// WithSeriesNavigation 返回在正文首尾插入系列导航的文章副本：开头说明是系列的第几篇并给出上一篇/下一篇，
+// 结尾列出系列全部篇目。linkFor 返回其它篇目的链接，返回空字符串时只显示标题（如尚未发布）。
+// 文章不属于系列时原样返回
+func (a *Article) WithSeriesNavigation(linkFor func(member *Article) string) *Article {
+	if a.Series == nil || len(a.Series.Articles) < 2 {
+		return a
+	}
+	position := a.Series.Position(a)
+	if position < 0 {
+		return a
+	}
+	members := a.Series.Articles
+	entry := func(member *Article) string {
+		if url := linkFor(member); url != "" {
+			return fmt.Sprintf("[%s](%s)", member.Title, url)
+		}
+		return member.Title
+	}
+
+	intro := fmt.Sprintf("> 本文是「%s」系列的第 %d 篇（共 %d 篇）", a.Series.Name, position+1, len(members))
+	var neighbors []string
+	if position > 0 {
+		neighbors = append(neighbors, "上一篇："+entry(members[position-1]))
+	}
+	if position < len(members)-1 {
+		neighbors = append(neighbors, "下一篇："+entry(members[position+1]))
+	}
+	head := []string{intro, ">", "> " + strings.Join(neighbors, " ｜ "), ""}
+
+	tail := []string{"", fmt.Sprintf("**「%s」系列文章**", a.Series.Name), ""}
+	for i, member := range members {
+		item := entry(member)
+		if i == position {
+			item = member.Title + "（本文）"
+		}
+		tail = append(tail, fmt.Sprintf("%d. %s", i+1, item))
+	}
+
+	content := make([]string, 0, len(head)+len(a.Content)+len(tail))
+	content = append(content, head...)
+	content = append(content, a.Content...)
+	content = append(content, tail...)
+
+	// 正文整体下移，图片的行号随之调整
+	images := make([]Image, len(a.Images))
+	copy(images, a.Images)
+	for i := range images {
+		images[i].LineIndex += len(head)
+	}
+
+	navigated := *a
+	navigated.Content = content
+	navigated.Images = images
+	return &navigated
+}
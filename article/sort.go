@@ -0,0 +1,83 @@
+package article
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SortOrder 文章发布顺序
+type SortOrder string
+
+const (
+	SortByName   SortOrder = "name"   // 按文件路径字典序（默认）
+	SortByPrefix SortOrder = "prefix" // 按文件名数字前缀，如 01-intro.md、02-body.md
+	SortByWeight SortOrder = "weight" // 按 frontmatter 中的 weight 从小到大
+	SortByDate   SortOrder = "date"   // 按 frontmatter 中的 date 从早到晚
+)
+
+// numericPrefix 文件名开头的数字前缀
+var numericPrefix = regexp.MustCompile(`^(\d+)`)
+
+// ParseSortOrder 解析排序方式，空字符串返回默认的按文件名排序
+func ParseSortOrder(value string) (SortOrder, error) {
+	switch order := SortOrder(strings.ToLower(strings.TrimSpace(value))); order {
+	case "":
+		return SortByName, nil
+	case SortByName, SortByPrefix, SortByWeight, SortByDate:
+		return order, nil
+	default:
+		return "", fmt.Errorf("未知的排序方式: %s（可选 name/prefix/weight/date）", value)
+	}
+}
+
+// SortArticles 按指定方式排序文章。缺少排序字段的文章排在最后，
+// 排序字段相同时按文件路径排序，保证顺序稳定
+func SortArticles(articles []*Article, order SortOrder) {
+	key := func(art *Article) (int64, bool) {
+		switch order {
+		case SortByPrefix:
+			match := numericPrefix.FindString(filepath.Base(art.Path))
+			if match == "" {
+				return 0, false
+			}
+			n, err := strconv.ParseInt(match, 10, 64)
+			return n, err == nil
+		case SortByWeight:
+			return int64(art.Meta.Weight), art.Meta.Weight != 0
+		case SortByDate:
+			date, err := parseArticleDate(art.Meta.Date)
+			return date.Unix(), err == nil
+		default:
+			return 0, false
+		}
+	}
+
+	sort.SliceStable(articles, func(i, j int) bool {
+		ki, oki := key(articles[i])
+		kj, okj := key(articles[j])
+		if oki != okj {
+			return oki
+		}
+		if oki && ki != kj {
+			return ki < kj
+		}
+		return articles[i].Path < articles[j].Path
+	})
+}
+
+// parseArticleDate 解析 frontmatter 中的文章日期，支持只有日期或带时间的格式
+func parseArticleDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("未设置日期")
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return ParsePublishTime(value)
+}
@@ -0,0 +1,95 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// externalLinkRegex 指向网络地址的链接：[文本](http...)、<http...>（图片解析时已替换为占位符）
+var externalLinkRegex = regexp.MustCompile(`\[[^\]]*\]\(\s*https?://[^)]*\)|<https?://[^>\s]+>`)
+
+// Stats 文章结构统计，用于发布前评估文章结构
+type Stats struct {
+	Images           int    // 图片数（含网络图片）
+	CodeBlocks       int    // 代码块数
+	ExternalLinks    int    // 外链数
+	Headings         [6]int // 各级标题数量，Headings[0] 为一级标题
+	LongestParagraph int    // 最长段落的字符数
+}
+
+// Stats 统计文章的图片、代码块、外链、标题层级和最长段落。
+// 代码块、公式块和行内代码中的内容不计入外链和段落
+func (a *Article) Stats() Stats {
+	stats := Stats{Images: len(a.Images)}
+	var opening CodeFence
+	inCodeBlock := false
+	mathEnd := -1
+	paragraph := 0
+
+	endParagraph := func() {
+		if paragraph > stats.LongestParagraph {
+			stats.LongestParagraph = paragraph
+		}
+		paragraph = 0
+	}
+
+	for i, line := range a.Content {
+		trimmed := strings.TrimSpace(line)
+		if i < mathEnd {
+			continue
+		}
+		if fence, ok := ParseCodeFence(trimmed); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+				stats.CodeBlocks++
+				endParagraph()
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+		if end := MathBlockEnd(a.Content, i); end > 0 {
+			mathEnd = end
+			endParagraph()
+			continue
+		}
+		if trimmed == "" {
+			endParagraph()
+			continue
+		}
+
+		for _, span := range textSpans(line) {
+			stats.ExternalLinks += len(externalLinkRegex.FindAllStringIndex(line[span[0]:span[1]], -1))
+		}
+
+		if level, _, ok := parseHeading(trimmed); ok {
+			stats.Headings[level-1]++
+			endParagraph()
+			continue
+		}
+		// 图片占位符不算段落文字
+		length := utf8.RuneCountInString(trimmed)
+		for _, index := range a.ImagesOnLine(i) {
+			length -= len(PlaceholderFor(index))
+		}
+		if length > 0 {
+			paragraph += length
+		}
+	}
+	endParagraph()
+	return stats
+}
+
+// HeadingCount 各级标题的总数
+func (s Stats) HeadingCount() int {
+	total := 0
+	for _, count := range s.Headings {
+		total += count
+	}
+	return total
+}
@@ -0,0 +1,107 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultSummaryLength 默认摘要长度（字符数）
+const DefaultSummaryLength = 100
+
+var (
+	// markdownLinkRegex 链接 [文本](地址)，摘要中只保留文本
+	markdownLinkRegex = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	// markdownMarkRegex 加粗、斜体、删除线和行内代码标记
+	markdownMarkRegex = regexp.MustCompile("\\*\\*|__|~~|`|\\*")
+	// listMarkerRegex 列表和引用的行首标记
+	listMarkerRegex = regexp.MustCompile(`^(\s*([-*+]|\d+[.)])\s+|\s*>\s*)`)
+)
+
+// sentenceEnds 句子结束的标点
+const sentenceEnds = "。！？!?；;…"
+
+// Summary 返回文章摘要：frontmatter 中有 description 时直接使用，
+// 否则取正文前 length 个字符（跳过标题、图片、代码块和公式块），尽量在句子边界截断
+func (a *Article) Summary(length int) string {
+	if description := strings.TrimSpace(a.Meta.Description); description != "" {
+		return description
+	}
+	if length <= 0 {
+		length = DefaultSummaryLength
+	}
+
+	var builder strings.Builder
+	inCodeBlock := false
+	codeFence := ""
+	mathEnd := -1
+	for i, line := range a.Content {
+		trimmed := strings.TrimSpace(line)
+		if i < mathEnd {
+			continue
+		}
+
+		if fence := codeFenceOf(trimmed); fence != "" {
+			if !inCodeBlock {
+				inCodeBlock = true
+				codeFence = fence
+			} else if strings.HasPrefix(trimmed, codeFence) && strings.TrimSpace(strings.TrimLeft(trimmed, codeFence[:1])) == "" {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock || trimmed == "" {
+			continue
+		}
+		if end := MathBlockEnd(a.Content, i); end > 0 {
+			mathEnd = end
+			continue
+		}
+		if _, _, ok := parseHeading(trimmed); ok {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, "<") {
+			continue
+		}
+
+		text := placeholderRegex.ReplaceAllString(trimmed, "")
+		text = markdownLinkRegex.ReplaceAllString(text, "$1")
+		text = listMarkerRegex.ReplaceAllString(text, "")
+		text = strings.TrimSpace(markdownMarkRegex.ReplaceAllString(text, ""))
+		if text == "" {
+			continue
+		}
+
+		builder.WriteString(text)
+		if utf8.RuneCountInString(builder.String()) > length {
+			break
+		}
+		// 行与行之间：中文直接相连，英文补一个空格
+		if last, _ := utf8.DecodeLastRuneInString(text); last < 128 {
+			builder.WriteString(" ")
+		}
+	}
+
+	return truncateSummary(strings.TrimSpace(builder.String()), length)
+}
+
+// truncateSummary 将摘要截断到 length 个字符以内，优先在最后一个句子结束处截断，
+// 找不到合适的句子边界时直接截断并加省略号
+func truncateSummary(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+
+	cut := runes[:length]
+	for i := len(cut) - 1; i >= length/2; i-- {
+		if strings.ContainsRune(sentenceEnds, cut[i]) {
+			return string(cut[:i+1])
+		}
+		// 英文句号后跟空格才视为句子结束，避免截断在小数点或域名中
+		if cut[i] == '.' && i+1 < len(runes) && runes[i+1] == ' ' {
+			return string(cut[:i+1])
+		}
+	}
+	return strings.TrimSpace(string(cut[:length-1])) + "…"
+}
@@ -0,0 +1,52 @@
+package article
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultTitleTrailingPunctuation 默认从标题末尾去掉的标点。问号、感叹号等会改变语气，默认保留
+const DefaultTitleTrailingPunctuation = "。，、；：.,;:"
+
+// NormalizeTitle 规范化标题：去掉不可见字符（零宽字符、BOM、控制字符），
+// 把中间连续的空白折叠为一个空格，再去掉首尾空白和末尾的 trailingPunctuation 中的标点
+func NormalizeTitle(title, trailingPunctuation string) string {
+	var builder strings.Builder
+	space := false
+	for _, r := range title {
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case unicode.Is(unicode.Cf, r) || unicode.IsControl(r):
+			continue
+		}
+		if space && builder.Len() > 0 {
+			builder.WriteByte(' ')
+		}
+		space = false
+		builder.WriteRune(r)
+	}
+
+	normalized := builder.String()
+	if trailingPunctuation != "" {
+		normalized = strings.TrimRightFunc(strings.TrimRight(normalized, trailingPunctuation), unicode.IsSpace)
+	}
+	return normalized
+}
+
+// titleFromHeading 去掉第一行作标题时的 ATX 标题标记：开头的 #（不要求后面有空格，如 #标题）
+// 和结尾可选的闭合 #（如 # 标题 #），# 前面的空白和不可见字符一并去掉
+func titleFromHeading(line string) string {
+	trimmed := strings.TrimSpace(strings.TrimLeftFunc(line, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.Is(unicode.Cf, r)
+	}))
+	if !strings.HasPrefix(trimmed, "#") {
+		return trimmed
+	}
+	trimmed = strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+	if closed := strings.TrimRight(trimmed, "#"); closed != trimmed && strings.HasSuffix(closed, " ") {
+		trimmed = strings.TrimSpace(closed)
+	}
+	return trimmed
+}
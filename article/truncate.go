@@ -0,0 +1,169 @@
+package article
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultTruncateSuffix 正文截断后默认追加的引流文字，{url} 替换为 frontmatter 中的原文链接
+const DefaultTruncateSuffix = "> 篇幅所限，这里只摘录了部分内容，阅读全文请访问：{url}"
+
+// Truncated 返回正文截断到 maxLength 个字符以内的文章副本，末尾追加引流文字 suffix
+// （{url} 替换为 frontmatter 中的 original_url，未设置时去掉链接）。截断优先落在句子边界，
+// 代码块、公式块和图片不会被切断，放不下时整块舍弃。正文未超长时返回原文章和 false
+func (a *Article) Truncated(maxLength int, suffix string) (*Article, bool) {
+	if maxLength <= 0 {
+		return a, false
+	}
+
+	count := 0
+	cutLine, cutColumn := -1, -1
+	for i := 0; i < len(a.Content) && cutLine < 0; i++ {
+		// 代码块和公式块整体计算长度，放不下时在块之前截断
+		if end := a.blockEnd(i); end > 0 {
+			length := a.blockLength(i, end)
+			if count+length > maxLength {
+				cutLine = i
+				break
+			}
+			count += length
+			i = end - 1
+			continue
+		}
+
+		length := a.textLength(i)
+		if count+length <= maxLength {
+			count += length
+			continue
+		}
+		cutLine = i
+		cutColumn = a.sentenceCut(i, maxLength-count)
+	}
+	if cutLine < 0 {
+		return a, false
+	}
+
+	content := append([]string(nil), a.Content[:cutLine]...)
+	if cutColumn > 0 {
+		content = append(content, a.Content[cutLine][:cutColumn])
+	}
+	for len(content) > 0 && strings.TrimSpace(content[len(content)-1]) == "" {
+		content = content[:len(content)-1]
+	}
+	content = append(content, "", truncateSuffix(suffix, a.Meta.OriginalURL))
+
+	// 图片按正文顺序编号，保留截断位置之前的图片
+	kept := 0
+	for _, img := range a.Images {
+		if img.LineIndex > cutLine || (img.LineIndex == cutLine && img.Column >= cutColumn) {
+			break
+		}
+		kept++
+	}
+
+	truncated := *a
+	truncated.Content = content
+	truncated.Images = append([]Image(nil), a.Images[:kept]...)
+	return &truncated, true
+}
+
+// Length 返回正文的字符数（按 Unicode 字符计算，一个汉字计 1 个字符），计算方式与 Truncated 相同：
+// 行首空白和图片占位符不计，代码块和公式块按去掉首尾空白后的行计算
+func (a *Article) Length() int {
+	length := 0
+	for i := 0; i < len(a.Content); i++ {
+		if end := a.blockEnd(i); end > 0 {
+			length += a.blockLength(i, end)
+			i = end - 1
+			continue
+		}
+		length += a.textLength(i)
+	}
+	return length
+}
+
+// blockEnd 判断第 start 行是否开始一个代码块或公式块，是则返回块结束后的下一行行号，否则返回 -1。
+// 没有结束标记的代码块延续到正文末尾
+func (a *Article) blockEnd(start int) int {
+	opening, ok := ParseCodeFence(strings.TrimSpace(a.Content[start]))
+	if !ok {
+		return MathBlockEnd(a.Content, start)
+	}
+	for j := start + 1; j < len(a.Content); j++ {
+		if closing, ok := ParseCodeFence(strings.TrimSpace(a.Content[j])); ok && closing.Closes(opening) {
+			return j + 1
+		}
+	}
+	return len(a.Content)
+}
+
+// blockLength 返回第 [start, end) 行组成的代码块或公式块的字符数
+func (a *Article) blockLength(start, end int) int {
+	length := 0
+	for j := start; j < end; j++ {
+		length += utf8.RuneCountInString(strings.TrimSpace(a.Content[j]))
+	}
+	return length
+}
+
+// truncateSuffix 生成截断后追加的引流文字，没有原文链接时去掉链接部分
+func truncateSuffix(suffix, url string) string {
+	if strings.TrimSpace(suffix) == "" {
+		suffix = DefaultTruncateSuffix
+	}
+	if url == "" {
+		return strings.TrimRight(strings.ReplaceAll(suffix, "{url}", ""), " ：:")
+	}
+	return strings.ReplaceAll(suffix, "{url}", url)
+}
+
+// textLength 返回第 lineIndex 行的字符数（行首空白和图片占位符不计）
+func (a *Article) textLength(lineIndex int) int {
+	line := a.Content[lineIndex]
+	spans := a.placeholderSpans(lineIndex)
+	length := 0
+	for position, r := range line {
+		if insideSpans(spans, position) {
+			continue
+		}
+		if length == 0 && (r == ' ' || r == '\t') {
+			continue
+		}
+		length++
+	}
+	return length
+}
+
+// sentenceCut 在第 lineIndex 行中查找不超过 budget 个字符的最后一个句子边界，返回截断处的字节偏移，找不到时返回 -1。
+// 图片占位符不计入长度，也不会被切断
+func (a *Article) sentenceCut(lineIndex, budget int) int {
+	line := a.Content[lineIndex]
+	spans := a.placeholderSpans(lineIndex)
+	count := 0
+	cut := -1
+	for position, r := range line {
+		if insideSpans(spans, position) {
+			continue
+		}
+		count++
+		if count > budget {
+			break
+		}
+		next := position + utf8.RuneLen(r)
+		// 英文句号后跟空格才视为句子结束，避免截断在小数点或域名中
+		if strings.ContainsRune(sentenceEnds, r) || (r == '.' && next < len(line) && line[next] == ' ') {
+			cut = next
+		}
+	}
+	return cut
+}
+
+// placeholderSpans 返回第 lineIndex 行中图片占位符的字节区间
+func (a *Article) placeholderSpans(lineIndex int) [][2]int {
+	var spans [][2]int
+	for _, index := range a.ImagesOnLine(lineIndex) {
+		column := a.Images[index].Column
+		spans = append(spans, [2]int{column, column + len(PlaceholderFor(index))})
+	}
+	return spans
+}
@@ -0,0 +1,251 @@
+package article
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// VideoSource 视频来源
+type VideoSource string
+
+const (
+	VideoYouTube  VideoSource = "youtube"
+	VideoBilibili VideoSource = "bilibili"
+	VideoFile     VideoSource = "file" // 直接指向 .mp4 等视频文件的链接
+)
+
+var (
+	// videoSyntaxRegex 图片语法写法的视频 ![video](url)，与图片正则一致但不带尺寸
+	videoSyntaxRegex = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\s*\)`)
+	// bilibiliIDRegex Bilibili 视频页路径中的 BV 号或 av 号
+	bilibiliIDRegex = regexp.MustCompile(`^/video/(BV[0-9A-Za-z]+|av\d+)`)
+	// youtubeIDRegex YouTube 视频 ID
+	youtubeIDRegex = regexp.MustCompile(`^[0-9A-Za-z_-]{6,}$`)
+)
+
+// videoExtensions 视为视频文件的扩展名
+var videoExtensions = map[string]bool{".mp4": true, ".webm": true, ".mov": true, ".m4v": true, ".ogv": true}
+
+// Video 正文中的一个视频：![video](url) 写法，或单独成行的 YouTube/Bilibili 链接
+type Video struct {
+	Title  string      // 视频标题（![标题](url) 中的文字，video/视频 视为未填写）
+	URL    string      // 视频链接
+	Source VideoSource // 视频来源
+	ID     string      // YouTube 视频 ID 或 Bilibili 的 BV/av 号，无法识别时为空
+	Line   int         // 所在行号（正文中的索引）
+}
+
+// ParseVideoURL 识别 YouTube、Bilibili 视频链接和视频文件链接
+func ParseVideoURL(rawURL string) (Video, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return Video{}, false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	video := Video{URL: rawURL}
+
+	switch {
+	case host == "youtu.be":
+		video.Source, video.ID = VideoYouTube, strings.Trim(u.Path, "/")
+	case host == "youtube.com":
+		switch {
+		case u.Path == "/watch":
+			video.Source, video.ID = VideoYouTube, u.Query().Get("v")
+		case strings.HasPrefix(u.Path, "/embed/"), strings.HasPrefix(u.Path, "/shorts/"), strings.HasPrefix(u.Path, "/live/"):
+			video.Source, video.ID = VideoYouTube, path.Base(u.Path)
+		}
+		if video.Source != "" && !youtubeIDRegex.MatchString(video.ID) {
+			return Video{}, false
+		}
+	case host == "bilibili.com":
+		match := bilibiliIDRegex.FindStringSubmatch(u.Path)
+		if match == nil {
+			return Video{}, false
+		}
+		video.Source, video.ID = VideoBilibili, match[1]
+	case host == "b23.tv":
+		// 短链接无法得知视频号，只能作为链接
+		video.Source = VideoBilibili
+	case u.Scheme != "" && videoExtensions[strings.ToLower(path.Ext(u.Path))]:
+		video.Source = VideoFile
+	}
+	if video.Source == "" || (video.Source == VideoYouTube && video.ID == "") {
+		return Video{}, false
+	}
+	return video, true
+}
+
+// isVideoAlt 判断图片语法的文字是否声明了视频
+func isVideoAlt(alt string) bool {
+	alt = strings.TrimSpace(alt)
+	return strings.EqualFold(alt, "video") || alt == "视频"
+}
+
+// videoFromSyntax 判断 ![alt](url) 是否为视频：链接是可识别的视频链接，或文字为 video/视频
+func videoFromSyntax(alt, rawURL string) (Video, bool) {
+	video, ok := ParseVideoURL(rawURL)
+	if !ok {
+		if !isVideoAlt(alt) {
+			return Video{}, false
+		}
+		video = Video{URL: rawURL}
+	}
+	if !isVideoAlt(alt) {
+		video.Title = strings.TrimSpace(alt)
+	}
+	return video, true
+}
+
+// DisplayTitle 视频的显示标题，未填写时为"视频"
+func (v Video) DisplayTitle() string {
+	if v.Title != "" {
+		return v.Title
+	}
+	return "视频"
+}
+
+// Thumbnail 视频缩略图地址，只有 YouTube 有固定的缩略图地址，其它来源返回空字符串
+func (v Video) Thumbnail() string {
+	if v.Source == VideoYouTube {
+		return fmt.Sprintf("https://img.youtube.com/vi/%s/hqdefault.jpg", v.ID)
+	}
+	return ""
+}
+
+// EmbedURL 视频播放器的嵌入地址，无法嵌入时返回空字符串
+func (v Video) EmbedURL() string {
+	switch {
+	case v.Source == VideoYouTube:
+		return "https://www.youtube.com/embed/" + v.ID
+	case v.Source == VideoBilibili && strings.HasPrefix(v.ID, "BV"):
+		return "https://player.bilibili.com/player.html?bvid=" + v.ID + "&autoplay=0"
+	case v.Source == VideoBilibili && strings.HasPrefix(v.ID, "av"):
+		return "https://player.bilibili.com/player.html?aid=" + strings.TrimPrefix(v.ID, "av") + "&autoplay=0"
+	}
+	return ""
+}
+
+// LinkMarkdown 视频的链接写法，供不支持嵌入视频的平台使用：有缩略图时为点击缩略图跳转的图片链接，否则为带 ▶️ 的文字链接
+func (v Video) LinkMarkdown() string {
+	if thumbnail := v.Thumbnail(); thumbnail != "" {
+		return fmt.Sprintf("[![▶️ %s](%s)](%s)", v.DisplayTitle(), thumbnail, v.URL)
+	}
+	return fmt.Sprintf("▶️ [%s](%s)", v.DisplayTitle(), v.URL)
+}
+
+// videoSpan 一行中一个视频的字节区间
+type videoSpan struct {
+	Video
+	start, end int
+}
+
+// videoSpans 返回一行中的视频：![video](url) 写法，或整行只有一个 YouTube/Bilibili 链接（可带 <>）
+func videoSpans(line string, lineIndex int) []videoSpan {
+	var spans []videoSpan
+	for _, text := range textSpans(line) {
+		segment := line[text[0]:text[1]]
+		for _, match := range videoSyntaxRegex.FindAllStringSubmatchIndex(segment, -1) {
+			video, ok := videoFromSyntax(segment[match[2]:match[3]], segment[match[4]:match[5]])
+			if !ok {
+				continue
+			}
+			video.Line = lineIndex
+			spans = append(spans, videoSpan{video, text[0] + match[0], text[0] + match[1]})
+		}
+	}
+	if len(spans) > 0 {
+		return spans
+	}
+
+	trimmed := strings.TrimSpace(line)
+	bare := strings.TrimSuffix(strings.TrimPrefix(trimmed, "<"), ">")
+	if bare == "" || strings.ContainsAny(bare, " \t") {
+		return nil
+	}
+	if video, ok := ParseVideoURL(bare); ok && video.Source != VideoFile {
+		video.Line = lineIndex
+		start := strings.Index(line, trimmed)
+		return []videoSpan{{video, start, start + len(trimmed)}}
+	}
+	return nil
+}
+
+// forEachVideoLine 对代码块、公式块和 HTML 块之外含有视频的行调用 fn
+func forEachVideoLine(content []string, fn func(i int, spans []videoSpan)) {
+	var opening CodeFence
+	inCodeBlock := false
+	skipUntil := -1
+	for i, line := range content {
+		if i < skipUntil {
+			continue
+		}
+		if fence, ok := ParseCodeFence(strings.TrimSpace(line)); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+		if end := MathBlockEnd(content, i); end > 0 {
+			skipUntil = end
+			continue
+		}
+		if end := HTMLBlockEnd(content, i); end > 0 {
+			skipUntil = end
+			continue
+		}
+		if spans := videoSpans(line, i); len(spans) > 0 {
+			fn(i, spans)
+		}
+	}
+}
+
+// Videos 返回正文中的所有视频，按出现顺序排列
+func (a *Article) Videos() []Video {
+	var videos []Video
+	forEachVideoLine(a.Content, func(_ int, spans []videoSpan) {
+		for _, span := range spans {
+			videos = append(videos, span.Video)
+		}
+	})
+	return videos
+}
+
+// WithVideos 返回正文中的视频替换为 render 结果的文章副本（平台的嵌入代码或链接写法），原文章不受影响
+func (a *Article) WithVideos(render func(Video) string) *Article {
+	content := make([]string, len(a.Content))
+	copy(content, a.Content)
+	images := make([]Image, len(a.Images))
+	copy(images, a.Images)
+
+	forEachVideoLine(a.Content, func(i int, spans []videoSpan) {
+		line := content[i]
+		var builder strings.Builder
+		var shifts []lineShift
+		last := 0
+		for _, span := range spans {
+			replacement := render(span.Video)
+			builder.WriteString(line[last:span.start])
+			builder.WriteString(replacement)
+			shifts = append(shifts, lineShift{position: span.start, delta: len(replacement) - (span.end - span.start)})
+			last = span.end
+		}
+		builder.WriteString(line[last:])
+		content[i] = builder.String()
+		shiftImageColumns(images, i, shifts)
+	})
+
+	converted := *a
+	converted.Content = content
+	converted.Images = images
+	return &converted
+}
@@ -0,0 +1,56 @@
+package baijiahao
+
+import (
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+	"github.com/auto-blog/selectors"
+	"github.com/playwright-community/playwright-go"
+)
+
+// Name 平台名称
+const Name = "百家号"
+
+// ID 平台标识，用于配置段 [platform.baijiahao] 和 frontmatter 中的平台映射
+const ID = "baijiahao"
+
+// Platform 百家号平台（实现 platform.Platform 接口）
+type Platform struct {
+	saveSession SaveSessionFunc
+}
+
+// NewPlatform 创建百家号平台
+func NewPlatform(saveSession SaveSessionFunc) *Platform {
+	return &Platform{
+		saveSession: saveSession,
+	}
+}
+
+// GetName 获取平台名称
+func (p *Platform) GetName() string {
+	return Name
+}
+
+// GetURL 获取平台URL
+func (p *Platform) GetURL() string {
+	return URL()
+}
+
+// CheckAndWaitForLogin 检查并等待登录
+func (p *Platform) CheckAndWaitForLogin(page playwright.Page) {
+	NewLoginChecker(URL(), p.saveSession).CheckAndWaitForLogin(page)
+}
+
+// NewPublisher 创建文章发布器
+func (p *Platform) NewPublisher(page playwright.Page) platform.Publisher {
+	return NewPublisher(page)
+}
+
+// WaitForEditor 等待百家号编辑器
+func (p *Platform) WaitForEditor(page playwright.Page) bool {
+	if err := common.WaitVisible(page.Locator(selectors.Get(Name, selectors.Title)).First(), 5*time.Second); err != nil {
+		return false
+	}
+	return common.WaitVisible(page.Locator(selectors.Get(Name, selectors.Editor)).First(), 5*time.Second) == nil
+}
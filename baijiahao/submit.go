@@ -0,0 +1,33 @@
+package baijiahao
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+)
+
+// Submit 草稿模式下点击"存草稿"，发布模式下点击"发布"
+func (p *Publisher) Submit(mode platform.PublishMode) error {
+	if mode != platform.PublishModePublish {
+		if err := common.ClickButtonByText(p.page, "", "存草稿", "保存草稿"); err != nil {
+			return err
+		}
+		if common.WaitForPageText(p.page, 10*time.Second, "保存成功", "已保存") == "" {
+			return fmt.Errorf("点击存草稿后未看到保存成功提示，可能保存失败")
+		}
+		log.Printf("[百家号] 📝 已保存到草稿箱")
+		return nil
+	}
+
+	if err := common.ClickButtonByText(p.page, "", "发布"); err != nil {
+		return err
+	}
+	if common.WaitForPageText(p.page, 15*time.Second, "发布成功", "提交成功", "审核中") == "" {
+		return fmt.Errorf("点击发布后未看到发布成功提示，可能发布失败")
+	}
+	log.Printf("[百家号] 🎉 文章已提交发布（百家号需审核后才会公开）")
+	return nil
+}
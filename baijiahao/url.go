@@ -0,0 +1,6 @@
+package baijiahao
+
+// URL 百家号图文发布页URL
+func URL() string {
+	return "https://baijiahao.baidu.com/builder/rc/edit?type=news"
+}
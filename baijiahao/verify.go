@@ -0,0 +1,21 @@
+package baijiahao
+
+import (
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+)
+
+// 百家号后台的草稿箱和内容管理列表（包含审核中的文章）
+const (
+	draftsURL   = "https://baijiahao.baidu.com/builder/rc/content?type=draft"
+	articlesURL = "https://baijiahao.baidu.com/builder/rc/content"
+)
+
+// VerifySubmitted 提交后打开草稿箱或内容管理列表，确认文章确实出现且标题匹配
+func (p *Publisher) VerifySubmitted(title string, mode platform.PublishMode) (string, error) {
+	listURL := draftsURL
+	if mode == platform.PublishModePublish {
+		listURL = articlesURL
+	}
+	return common.VerifyArticleListed(p.page, Name, listURL, title)
+}
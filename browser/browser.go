@@ -1,72 +1,122 @@
 package browser
 
 import (
-	"encoding/json"
 	"fmt"
+	"html"
 	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/auto-blog/article"
+	"github.com/auto-blog/baijiahao"
 	"github.com/auto-blog/cnblogs"
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/cover"
+	"github.com/auto-blog/history"
+	"github.com/auto-blog/imagehost"
 	"github.com/auto-blog/juejin"
+	"github.com/auto-blog/notify"
 	"github.com/auto-blog/platform"
+	"github.com/auto-blog/progress"
 	"github.com/auto-blog/segmentfault"
+	"github.com/auto-blog/status"
+	"github.com/auto-blog/tasklog"
+	"github.com/auto-blog/tempfiles"
+	"github.com/auto-blog/toutiao"
+	"github.com/auto-blog/watermark"
 	"github.com/auto-blog/zhihu"
-	"github.com/jonfriesen/playwright-go-stealth"
 	"github.com/playwright-community/playwright-go"
 )
 
 // Manager 浏览器管理器
 type Manager struct {
 	pw              *playwright.Playwright
-	context         playwright.BrowserContext
 	browser         playwright.Browser
+	contextOptions  playwright.BrowserNewContextOptions
+	contexts        map[string]playwright.BrowserContext // 每个平台独立的上下文，避免 cookie 串味
+	pages           map[string]playwright.Page           // 页面池，同一平台的多篇文章复用页面
+	programPages    map[playwright.Page]bool             // 程序正在关闭或重新打开的页面，这些页面的关闭不算作用户关闭
+	contextMutex    sync.Mutex
 	userDataDir     string
-	closing         bool
+	closing         atomic.Bool
 	lastSave        time.Time
 	saveMutex       sync.Mutex
 	platformManager *platform.Manager
 	articles        []*article.Article
+	history         *history.History
+	platformURLs    map[string]string // 平台编辑器地址，用于发布下一篇文章时重新打开
+	platformPages   map[string]playwright.Page
+	publishMutex    sync.Mutex
+	closeOnce       sync.Once
+	imageStrategies map[string]platform.ImageStrategy
+	visibilities    map[string]platform.Visibility
+	imageHost       *imagehost.Uploader
+	cover           *cover.Generator
+	summaryLength   int
+	watermark       *watermark.Watermarker
+	watermarkOn     map[string]bool
+	report          *notify.PublishReport
+	progress        *progress.Bar
+	onPublished     func(article *article.Article, platforms []string)
+	maxRestarts     int             // 浏览器崩溃后自动重启的次数上限
+	restarts        int             // 已自动重启的次数
+	crashed         atomic.Bool     // 浏览器已崩溃、尚未恢复
+	userClosedAt    atomic.Int64    // 用户最近一次关闭平台页面的时间（UnixNano），用于区分用户关闭浏览器与崩溃
+	nextArticle     int             // 下一篇要发布的文章下标，崩溃恢复后从这里继续
+	retryRound      int             // 当前的失败重试轮数，崩溃恢复后从这一轮继续
+	interrupted     bool            // 发布流程被浏览器崩溃中断，恢复后需要继续
+	published       map[string]bool // 本次运行中已完成的「文章|平台|内容哈希」，崩溃恢复后跳过
+	throttle        *throttle       // 按平台的发布限流器
+	publishMode     platform.PublishMode
+	seriesNav       bool   // 是否在系列文章的正文首尾插入系列导航
+	retryFailed     int    // 所有文章发布完后重试失败平台的轮数
+	uploadLimit     int    // 上传控件方式同时上传的图片数量，剪贴板方式始终逐张粘贴
+	executablePath  string // 本机浏览器路径，崩溃重启时沿用
+	maxLengths      map[string]int
+	lengthStrategy  map[string]platform.LengthStrategy
+	truncateSuffix  map[string]string
+	languageRoutes  map[string]map[string]bool
+	configPlatforms map[string]bool              // 配置中启用的平台，未指定 platforms 的文章只发布到这些平台，为 nil 时不限制
+	updateTargets   map[string]map[string]string // 平台名称 -> 文章文件名 -> 要修改的已发布文章链接
+	status          *status.Writer // 实时进度状态文件，为 nil 时不写入
 }
 
 // NewManager 创建浏览器管理器
-func NewManager(userDataDir string, articles []*article.Article) (*Manager, error) {
+func NewManager(userDataDir string, articles []*article.Article, options Options) (manager *Manager, err error) {
+	// 任何一步失败都按逆序释放已创建的资源，避免遗留浏览器进程
+	var cleanup cleanupStack
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("创建浏览器管理器时发生 panic: %v", r)
+		}
+		if err != nil {
+			cleanup.run()
+			manager = nil
+		}
+	}()
+
 	pw, err := playwright.Run()
 	if err != nil {
 		return nil, err
 	}
+	cleanup.push(func() { pw.Stop() })
 
-	browser, err := pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
-		Headless: playwright.Bool(false), // 显示浏览器窗口
-		Args: []string{
-			"--disable-web-security",
-			"--disable-features=VizDisplayCompositor",
-			// 反检测参数
-			"--disable-blink-features=AutomationControlled",
-			"--disable-dev-shm-usage",
-			"--no-first-run",
-			"--no-default-browser-check",
-			"--disable-extensions-file-access-check",
-			"--disable-extensions",
-			"--disable-plugins",
-		},
-	})
+	browser, err := launchBrowser(pw, options.ExecutablePath)
 	if err != nil {
-		pw.Stop()
 		return nil, err
 	}
+	cleanup.push(func() { browser.Close() })
 
-	// 创建持久化的浏览器上下文
-	stateFile := filepath.Join(userDataDir, "state.json")
+	// 浏览器上下文配置（各平台的上下文按需创建，并加载各自的会话状态）
 	contextOptions := playwright.BrowserNewContextOptions{
-		// 使用真实的User-Agent，模拟最新版本Chrome
-		UserAgent: playwright.String("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.234 Safari/537.36"),
+		// 使用真实的User-Agent（可在配置文件 [browser] 中修改）
+		UserAgent: playwright.String(options.UserAgent),
 		// 设置适中的viewport
 		Viewport: &playwright.Size{
 			Width:  1366,
@@ -77,69 +127,127 @@ func NewManager(userDataDir string, articles []*article.Article) (*Manager, erro
 		IsMobile:          playwright.Bool(false),
 		HasTouch:          playwright.Bool(false),
 		// 设置语言和时区
-		Locale:     playwright.String("zh-CN"),
-		TimezoneId: playwright.String("Asia/Shanghai"),
+		Locale:     playwright.String(options.Locale),
+		TimezoneId: playwright.String(options.TimezoneID),
 		// 启用JavaScript
 		JavaScriptEnabled: playwright.Bool(true),
 		// 设置权限，包括剪贴板权限
 		Permissions: []string{"geolocation", "notifications", "clipboard-read", "clipboard-write"},
 	}
 
-	// 如果存在会话状态文件，则加载它
-	if _, err := os.Stat(stateFile); err == nil {
-		contextOptions.StorageStatePath = playwright.String(stateFile)
-		log.Println("加载已保存的会话状态")
-	} else {
-		log.Println("首次运行，创建新会话")
-	}
-
-	context, err := browser.NewContext(contextOptions)
-	if err != nil {
-		browser.Close()
-		pw.Stop()
-		return nil, err
-	}
-
-	manager := &Manager{
+	manager = &Manager{
 		pw:              pw,
-		context:         context,
 		browser:         browser,
+		contextOptions:  contextOptions,
+		contexts:        make(map[string]playwright.BrowserContext),
+		pages:           make(map[string]playwright.Page),
+		programPages:    make(map[playwright.Page]bool),
 		userDataDir:     userDataDir,
 		lastSave:        time.Now(),
 		platformManager: platform.NewManager(),
 		articles:        articles,
+		imageStrategies: options.ImageStrategies,
+		visibilities:    options.Visibilities,
+		imageHost:       options.ImageHost,
+		cover:           options.Cover,
+		summaryLength:   options.SummaryLength,
+		watermark:       options.Watermark,
+		watermarkOn:     options.WatermarkPlatforms,
+		report:          options.Report,
+		progress:        options.Progress,
+		maxRestarts:     options.MaxRestarts,
+		published:       make(map[string]bool),
+		throttle:        newThrottle(options),
+		publishMode:     options.PublishMode,
+		seriesNav:       options.SeriesNavigation,
+		retryFailed:     options.RetryFailed,
+		uploadLimit:     options.UploadConcurrency,
+		executablePath:  options.ExecutablePath,
+		maxLengths:      options.MaxLengths,
+		lengthStrategy:  options.LengthStrategies,
+		truncateSuffix:  options.TruncateSuffixes,
+		languageRoutes:  options.LanguageRoutes,
+		configPlatforms: options.DefaultPlatforms,
+		updateTargets:   options.UpdateTargets,
+		status:          options.Status,
 	}
 
-	// 监听浏览器断开连接事件
-	browser.On("disconnected", func() {
-		// 只有在非正常关闭时才保存（即用户直接关闭浏览器）
-		if !manager.closing {
-			log.Println("🔴 检测到浏览器已关闭，保存会话状态")
-			if err := manager.SaveSession(); err != nil {
-				log.Printf("🚫 浏览器关闭时保存会话状态失败: %v", err)
-			} else {
-				log.Println("💾 浏览器关闭时会话状态已保存")
-			}
-		}
-	})
+	// 各平台的元素等待统一按配置的倍率放宽超时
+	common.SetTimeoutMultiplier(options.TimeoutMultiplier)
+	common.SetActionDelay(options.ActionDelay)
+
+	// 注册支持的平台
+	manager.platformManager.Register(juejin.NewPlatform(manager.SaveSession, articles, options.Juejin))
+	manager.platformManager.Register(cnblogs.NewPlatform(manager.SaveSession))
+	manager.platformManager.Register(zhihu.NewPlatform(manager.SaveSession, articles, options.Zhihu))
+	manager.platformManager.Register(segmentfault.NewPlatform(manager.SaveSession, articles))
+	manager.platformManager.Register(toutiao.NewPlatform(manager.SaveSession))
+	manager.platformManager.Register(baijiahao.NewPlatform(manager.SaveSession))
+
+	// 监听浏览器断开连接事件，非正常关闭时自动重启
+	manager.watchDisconnect(browser)
 
 	return manager, nil
 }
 
+// launchBrowser 启动 Chromium（有界面模式），executablePath 不为空时使用本机已安装的浏览器
+func launchBrowser(pw *playwright.Playwright, executablePath string) (playwright.Browser, error) {
+	var executable *string
+	if executablePath != "" {
+		executable = playwright.String(executablePath)
+	}
+	return pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
+		ExecutablePath: executable,
+		Headless: playwright.Bool(false), // 显示浏览器窗口
+		Args: []string{
+			"--disable-web-security",
+			"--disable-features=VizDisplayCompositor",
+			// 反检测参数
+			"--disable-blink-features=AutomationControlled",
+			"--disable-dev-shm-usage",
+			"--no-first-run",
+			"--no-default-browser-check",
+			"--disable-extensions-file-access-check",
+			"--disable-extensions",
+			"--disable-plugins",
+		},
+	})
+}
+
+// SetHistory 设置发布历史，发布成功后会记录文章内容哈希
+func (m *Manager) SetHistory(h *history.History) {
+	m.history = h
+}
+
 // OpenPlatforms 并行打开平台，然后统一发布内容
 func (m *Manager) OpenPlatforms(platforms map[string]string) {
 	log.Printf("开始并行打开 %d 个平台", len(platforms))
+	m.platformURLs = platforms
+	
+	platformPages := m.openAll(platforms)
+	log.Printf("所有 %d 个平台已打开", len(platformPages))
+	
+	m.platformPages = platformPages
 	
-	// 存储平台页面信息
+	// 统一发布流程
+	if len(m.articles) > 0 {
+		m.publishMutex.Lock()
+		m.unifiedPublishFlow(platformPages)
+		m.publishMutex.Unlock()
+	}
+}
+
+// openAll 并行打开所有平台，返回成功打开的平台页面
+func (m *Manager) openAll(platforms map[string]string) map[string]playwright.Page {
 	platformPages := make(map[string]playwright.Page)
 	var wg sync.WaitGroup
 	var mutex sync.Mutex
 	
-	// 并行打开所有平台
 	for platform, url := range platforms {
 		wg.Add(1)
 		go func(platformName, platformURL string) {
 			defer wg.Done()
+			defer m.recoverPanic(fmt.Sprintf("打开 %s ", platformName))
 			page := m.openPlatform(platformName, platformURL)
 			if page != nil {
 				mutex.Lock()
@@ -150,74 +258,47 @@ func (m *Manager) OpenPlatforms(platforms map[string]string) {
 	}
 	
 	wg.Wait()
-	log.Printf("所有 %d 个平台已打开", len(platformPages))
-	
-	// 统一发布流程
-	if len(m.articles) > 0 {
-		m.unifiedPublishFlow(platformPages)
-	}
+	return platformPages
 }
 
-// openAndPublishToPlatform 同步打开平台并发布文章
-func (m *Manager) openAndPublishToPlatform(platformName, url string) {
-	page, err := m.context.NewPage()
-	if err != nil {
-		log.Printf("无法为 %s 创建新页面: %v", platformName, err)
-		return
-	}
+// SetPublishedHandler 设置文章发布完成后的回调，platforms 为发布成功的平台
+func (m *Manager) SetPublishedHandler(handler func(article *article.Article, platforms []string)) {
+	m.onPublished = handler
+}
 
-	// 注入stealth脚本，防止被检测为自动化浏览器
-	if err := stealth.Inject(page); err != nil {
-		log.Printf("注入stealth脚本失败 %s: %v", platformName, err)
-	} else {
-		log.Printf("已为 %s 启用反检测模式", platformName)
+// CheckLogins 检查已打开平台的登录状态，未登录时等待用户在浏览器中完成登录并保存会话
+func (m *Manager) CheckLogins() {
+	for platformName, page := range m.platformPages {
+		m.platformManager.CheckAndWaitForLogin(platformName, page)
 	}
+}
 
-	// 打开页面
-	_, err = page.Goto(url)
-	if err != nil {
-		log.Printf("无法打开 %s (%s): %v", platformName, url, err)
+// PublishArticles 在已打开的平台页面上发布新的文章（监听模式使用）
+func (m *Manager) PublishArticles(articles []*article.Article) {
+	m.publishMutex.Lock()
+	defer m.publishMutex.Unlock()
+	
+	if len(m.platformPages) == 0 {
+		log.Println("没有已打开的平台页面，无法发布")
 		return
 	}
-
-	log.Printf("已打开 %s: %s", platformName, url)
-
-	// 等待页面加载完成
-	page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
-		State: playwright.LoadStateNetworkidle,
-	})
-
-	// 同步尝试发布文章（如果已登录）
-	publishSuccess := m.tryPublishArticleSync(platformName, page, url)
 	
-	// 如果发布成功，等待一段时间让用户查看结果
-	if publishSuccess {
-		log.Printf("文章已成功发布到 %s，等待5秒后继续...", platformName)
-		time.Sleep(5 * time.Second)
-	} else {
-		// 如果没有成功发布，可能需要登录
-		log.Printf("%s 可能需要登录或手动操作", platformName)
-		// 这里可以添加等待用户手动登录的逻辑
-	}
+	// 重新打开空白编辑器，避免覆盖之前发布的内容
+	m.reopenPlatformPages(m.platformPages)
+	m.articles = articles
+	m.unifiedPublishFlow(m.platformPages)
 }
 
-// openPlatform 在新页面中打开指定平台并返回页面对象
+// openPlatform 在平台页面中打开编辑器并返回页面对象
 func (m *Manager) openPlatform(platformName, url string) playwright.Page {
-	page, err := m.context.NewPage()
+	page, err := m.pageFor(platformName)
 	if err != nil {
-		log.Printf("无法为 %s 创建新页面: %v", platformName, err)
+		log.Printf("%v", err)
 		return nil
 	}
 
-	// 注入stealth脚本，防止被检测为自动化浏览器
-	if err := stealth.Inject(page); err != nil {
-		log.Printf("注入stealth脚本失败 %s: %v", platformName, err)
-	} else {
-		log.Printf("已为 %s 启用反检测模式", platformName)
-	}
-
 	// 打开页面
-	_, err = page.Goto(url)
+	_, err = common.HumanGoto(page, url)
 	if err != nil {
 		log.Printf("无法打开 %s (%s): %v", platformName, url, err)
 		return nil
@@ -256,572 +337,609 @@ func (m *Manager) GetArticleCount() int {
 	return len(m.articles)
 }
 
-// tryPublishArticleSync 同步尝试发布文章，返回是否成功
-func (m *Manager) tryPublishArticleSync(platformName string, page playwright.Page, url string) bool {
-	if len(m.articles) == 0 {
-		log.Printf("没有文章要发布")
-		return false
+// SaveSession 保存会话状态（带日志输出，用于程序启动和退出）
+func (m *Manager) SaveSession() error {
+	m.contextMutex.Lock()
+	contexts := make(map[string]playwright.BrowserContext, len(m.contexts))
+	for platformName, context := range m.contexts {
+		contexts[platformName] = context
 	}
-	
-	log.Printf("尝试发布文章到 %s", platformName)
-	
-	// 根据不同平台尝试发布
-	switch platformName {
-	case "掘金":
-		return m.tryPublishToJuejinSync(page)
-	case "博客园":
-		return m.tryPublishToCnblogsSync(page)
-	case "知乎":
-		return m.tryPublishToZhihuSync(page)
-	case "SegmentFault":
-		return m.tryPublishToSegmentFaultSync(page)
-	default:
-		log.Printf("平台 %s 暂不支持直接发布", platformName)
-		return false
+	m.contextMutex.Unlock()
+
+	var lastErr error
+	for platformName, context := range contexts {
+		cookieCount, size, err := m.saveContextState(platformName, context)
+		if err != nil {
+			log.Printf("⚠️ 保存 %s 会话状态失败: %v", platformName, err)
+			lastErr = err
+			continue
+		}
+		log.Printf("📊 %s 会话数据: %d个cookies, 文件大小: %d bytes", platformName, cookieCount, size)
 	}
+	return lastErr
 }
 
-// tryPublishArticle 尝试直接发布文章（如果页面已经是编辑器状态）
-func (m *Manager) tryPublishArticle(platformName string, page playwright.Page, url string) {
+// unifiedPublishFlow 统一发布流程：逐篇发布文章，每篇文章内部为混合模式（并行平台打开 + 串行图片替换）
+func (m *Manager) unifiedPublishFlow(platformPages map[string]playwright.Page) {
+	defer m.recoverPanic("发布流程")
+	
 	if len(m.articles) == 0 {
-		return // 没有文章要发布
+		log.Println("没有文章需要发布")
+		return
 	}
 	
-	log.Printf("尝试直接发布文章到 %s", platformName)
-	
-	// 根据不同平台尝试发布
-	switch platformName {
-	case "掘金":
-		m.tryPublishToJuejin(page)
-	case "博客园":
-		m.tryPublishToCnblogs(page)
-	case "知乎":
-		m.tryPublishToZhihu(page)
-	case "SegmentFault":
-		m.tryPublishToSegmentFault(page)
-	default:
-		log.Printf("平台 %s 暂不支持直接发布", platformName)
+	// 总步骤数 = 平台数 × (标题 + 正文 + 图片数 + 提交)
+	total := 0
+	for _, article := range m.articles {
+		total += len(platformPages) * stepsPerPlatform(article)
 	}
+	m.progress.Start(total)
+	m.status.Start(len(m.articles))
+	m.nextArticle = 0
+	m.retryRound = 1
+	m.publishRemaining(platformPages)
 }
 
-// tryPublishToJuejin 尝试发布文章到掘金
-func (m *Manager) tryPublishToJuejin(page playwright.Page) {
-	// 检查是否已经在编辑器页面
-	currentURL := page.URL()
-	if !strings.Contains(currentURL, "editor/drafts") {
-		log.Printf("当前页面不是掘金编辑器，跳过直接发布")
-		return
-	}
-	
-	// 快速检查编辑器元素是否存在
-	titleLocator := page.Locator("input.title-input")
-	editorLocator := page.Locator("div.CodeMirror-scroll")
-	
-	// 等待编辑器元素，但使用较短的超时时间
-	titleVisible := make(chan bool, 1)
-	editorVisible := make(chan bool, 1)
-	
-	go func() {
-		err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-			Timeout: playwright.Float(3000), // 3秒超时
-			State:   playwright.WaitForSelectorStateVisible,
-		})
-		titleVisible <- (err == nil)
-	}()
-	
-	go func() {
-		err := editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-			Timeout: playwright.Float(3000), // 3秒超时
-			State:   playwright.WaitForSelectorStateVisible,
-		})
-		editorVisible <- (err == nil)
+// publishRemaining 从 nextArticle 开始逐篇发布剩余的文章，全部发布完后重试失败的平台。
+// 浏览器崩溃时停在当前文章和重试轮次，崩溃恢复后再次调用即可继续，已完成的平台由 pendingPlatforms 跳过
+func (m *Manager) publishRemaining(platformPages map[string]playwright.Page) {
+	defer func() {
+		m.interrupted = m.crashed.Load()
+		if !m.interrupted {
+			m.progress.Finish()
+		}
 	}()
-	
-	// 等待两个元素都检查完成
-	titleReady := <-titleVisible
-	editorReady := <-editorVisible
-	
-	if titleReady && editorReady {
-		log.Println("✅ 检测到掘金编辑器已就绪，开始发布文章")
-		
-		// 创建发布器并发布第一篇文章
-		publisher := juejin.NewPublisher(page)
-		article := m.articles[0]
-		
-		if err := publisher.PublishArticle(article); err != nil {
-			log.Printf("❌ 直接发布失败: %v", err)
-		} else {
-			log.Printf("🎉 文章《%s》已成功发布到掘金", article.Title)
+
+	pagesUsed := false
+	for ; m.nextArticle < len(m.articles); m.nextArticle++ {
+		article := m.articles[m.nextArticle]
+		if m.crashed.Load() {
+			log.Println("⏸️ 浏览器已崩溃，暂停发布，等待自动恢复")
+			return
 		}
-	} else {
-		log.Println("编辑器尚未就绪，将等待登录检测")
+		// 上一篇文章占用了编辑器，重新打开空白编辑器页面
+		if pagesUsed {
+			m.reopenPlatformPages(platformPages)
+		}
+		log.Printf("📚 [%d/%d] 准备发布文章: %s", m.nextArticle+1, len(m.articles), article.Title)
+		m.status.StartArticle(article.Path, article.Title)
+		pagesUsed = m.publishArticle(article, platformPages)
+		// 浏览器崩溃后这篇文章和剩余文章交给崩溃恢复流程继续发布
+		if m.crashed.Load() {
+			return
+		}
+		m.status.FinishArticle(article.Path)
 	}
+	m.retryFailedPlatforms(platformPages)
 }
 
-// tryPublishToCnblogs 尝试发布文章到博客园
-func (m *Manager) tryPublishToCnblogs(page playwright.Page) {
-	// 检查是否已经在编辑器页面
-	currentURL := page.URL()
-	if !strings.Contains(currentURL, "i.cnblogs.com/posts") {
-		log.Printf("当前页面不是博客园编辑器，跳过直接发布")
-		return
-	}
-	
-	// 快速检查编辑器元素是否存在
-	titleLocator := page.Locator("#post-title")
-	editorLocator := page.Locator("#md-editor")
-	
-	// 等待编辑器元素，但使用较短的超时时间
-	titleVisible := make(chan bool, 1)
-	editorVisible := make(chan bool, 1)
-	
-	go func() {
-		err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-			Timeout: playwright.Float(2000),
-			State:   playwright.WaitForSelectorStateVisible,
-		})
-		titleVisible <- (err == nil)
-	}()
-	
-	go func() {
-		err := editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-			Timeout: playwright.Float(2000),
-			State:   playwright.WaitForSelectorStateVisible,
-		})
-		editorVisible <- (err == nil)
-	}()
-	
-	// 等待两个检查完成
-	titleReady := <-titleVisible
-	editorReady := <-editorVisible
-	
-	if titleReady && editorReady {
-		log.Println("✅ 检测到博客园编辑器已就绪，开始发布文章")
-		
-		// 创建发布器并发布第一篇文章
-		publisher := cnblogs.NewPublisher(page)
-		article := m.articles[0]
-		
-		if err := publisher.PublishArticle(article); err != nil {
-			log.Printf("❌ 直接发布失败: %v", err)
-		} else {
-			log.Printf("🎉 文章《%s》已成功发布到博客园", article.Title)
-		}
-	} else {
-		log.Println("编辑器尚未就绪，将等待登录检测")
-	}
+// stepsPerPlatform 单篇文章在单个平台上的进度步骤数：标题、正文、每张图片、提交
+func stepsPerPlatform(article *article.Article) int {
+	return 2 + len(article.Images) + 1
 }
 
-// tryPublishToJuejinSync 同步尝试发布文章到掘金
-func (m *Manager) tryPublishToJuejinSync(page playwright.Page) bool {
-	// 检查是否已经在编辑器页面
-	currentURL := page.URL()
-	if !strings.Contains(currentURL, "editor/drafts") {
-		log.Printf("当前页面不是掘金编辑器，跳过直接发布")
-		return false
-	}
-	
-	// 快速检查编辑器元素是否存在
-	titleLocator := page.Locator("input.title-input")
-	editorLocator := page.Locator("div.CodeMirror-scroll")
-	
-	// 同步等待编辑器元素
-	err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(3000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		log.Printf("掘金标题输入框未就绪: %v", err)
-		return false
-	}
-	
-	err = editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(3000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		log.Printf("掘金编辑器未就绪: %v", err)
-		return false
-	}
-	
-	log.Println("✅ 检测到掘金编辑器已就绪，开始发布文章")
-	
-	// 创建发布器并发布第一篇文章
-	publisher := juejin.NewPublisher(page)
-	article := m.articles[0]
-	
-	if err := publisher.PublishArticle(article); err != nil {
-		log.Printf("❌ 发布失败: %v", err)
-		return false
+// reopenPlatformPages 将各平台页面重新导航到编辑器地址
+func (m *Manager) reopenPlatformPages(platformPages map[string]playwright.Page) {
+	for platformName, page := range platformPages {
+		url, ok := m.platformURLs[platformName]
+		if !ok || page == nil {
+			continue
+		}
+		m.markProgramPage(page)
+		_, err := common.HumanGoto(page, url)
+		m.unmarkProgramPage(page)
+		if err != nil {
+			log.Printf("⚠️ 重新打开 %s 失败: %v", platformName, err)
+			continue
+		}
+		page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+			State: playwright.LoadStateNetworkidle,
+		})
 	}
-	
-	log.Printf("🎉 文章《%s》已成功发布到掘金", article.Title)
-	return true
 }
 
-// tryPublishToCnblogsSync 同步尝试发布文章到博客园
-func (m *Manager) tryPublishToCnblogsSync(page playwright.Page) bool {
-	// 检查是否已经在编辑器页面
-	currentURL := page.URL()
-	if !strings.Contains(currentURL, "i.cnblogs.com/posts") {
-		log.Printf("当前页面不是博客园编辑器，跳过直接发布")
-		return false
-	}
-	
-	// 快速检查编辑器元素是否存在
-	titleLocator := page.Locator("#post-title")
-	editorLocator := page.Locator("#md-editor")
-	
-	// 同步等待编辑器元素
-	err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(3000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		log.Printf("博客园标题输入框未就绪: %v", err)
-		return false
-	}
-	
-	err = editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(3000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		log.Printf("博客园编辑器未就绪: %v", err)
-		return false
-	}
-	
-	log.Println("✅ 检测到博客园编辑器已就绪，开始发布文章")
-	
-	// 创建发布器并发布第一篇文章
-	publisher := cnblogs.NewPublisher(page)
-	article := m.articles[0]
-	
-	if err := publisher.PublishArticle(article); err != nil {
-		log.Printf("❌ 发布失败: %v", err)
-		return false
+// pendingPlatforms 根据发布历史过滤出文章尚未完成发布的平台，未提交的平台从头重新发布，
+// 上次已提交但未记入历史的平台直接记入历史，避免重复提交
+func (m *Manager) pendingPlatforms(article *article.Article, platformPages map[string]playwright.Page) map[string]playwright.Page {
+	contentHash := article.ContentHash()
+	pending := make(map[string]playwright.Page)
+	for platformName, page := range platformPages {
+		if m.published[publishedKey(article, platformName, contentHash)] {
+			log.Printf("⏭️ 《%s》本次运行已发布到 %s，跳过", article.Title, platformName)
+			continue
+		}
+		if m.updateURL(platformName, article) != "" {
+			// 指定了更新目标时修改已发布的文章，不按发布历史跳过
+			pending[platformName] = page
+			continue
+		}
+		if m.history == nil {
+			pending[platformName] = page
+			continue
+		}
+		if m.history.IsCompleted(article.Path, platformName, contentHash) {
+			log.Printf("⏭️ 《%s》已发布到 %s，跳过", article.Title, platformName)
+			continue
+		}
+		progress := m.history.GetProgress(article.Path, platformName, contentHash)
+		if progress != nil && progress.Step >= history.StepSubmitted {
+			log.Printf("⏭️ 《%s》上次已提交到 %s，跳过重复提交", article.Title, platformName)
+			m.published[publishedKey(article, platformName, contentHash)] = true
+			m.recordHistory(article, []string{platformName}, nil)
+			continue
+		}
+		if progress != nil {
+			// 新打开的编辑器是空白的，未提交的平台需要从头填写
+			log.Printf("🔁 《%s》在 %s 上次中断于「%s」步骤（已替换 %d 张图片），重新发布", article.Title, platformName, progress.Step, progress.ImagesDone)
+		}
+		pending[platformName] = page
 	}
-	
-	log.Printf("🎉 文章《%s》已成功发布到博客园", article.Title)
-	return true
+	return pending
 }
 
-// tryPublishToZhihuSync 同步尝试发布文章到知乎
-func (m *Manager) tryPublishToZhihuSync(page playwright.Page) bool {
-	// 检查是否已经在编辑器页面
-	currentURL := page.URL()
-	if !strings.Contains(currentURL, "zhuanlan.zhihu.com/write") {
-		log.Printf("当前页面不是知乎编辑器，跳过直接发布")
-		return false
-	}
-	
-	// 快速检查编辑器元素是否存在
-	titleLocator := page.Locator("textarea.Input")
-	editorLocator := page.Locator("div.Editable-content")
-	
-	// 同步等待编辑器元素
-	err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(3000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		log.Printf("知乎标题输入框未就绪: %v", err)
-		return false
-	}
-	
-	err = editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(3000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		log.Printf("知乎编辑器未就绪: %v", err)
-		return false
+// updateProgress 记录发布进度
+func (m *Manager) updateProgress(article *article.Article, platformName string, step history.Step, imagesDone int) {
+	if m.history == nil {
+		return
 	}
-	
-	log.Println("✅ 检测到知乎编辑器已就绪，开始发布文章")
-	
-	// 创建发布器并发布第一篇文章
-	publisher := zhihu.NewPublisher(page)
-	article := m.articles[0]
-	
-	if err := publisher.PublishArticle(article); err != nil {
-		log.Printf("❌ 发布失败: %v", err)
-		return false
+	if err := m.history.UpdateProgress(article.Path, platformName, article.ContentHash(), step, imagesDone); err != nil {
+		log.Printf("⚠️ 保存发布进度失败: %v", err)
 	}
-	
-	log.Printf("🎉 文章《%s》已成功发布到知乎", article.Title)
-	return true
 }
 
-// tryPublishToZhihu 尝试发布文章到知乎
-func (m *Manager) tryPublishToZhihu(page playwright.Page) {
-	// 检查是否已经在编辑器页面
-	currentURL := page.URL()
-	if !strings.Contains(currentURL, "zhuanlan.zhihu.com/write") {
-		log.Printf("当前页面不是知乎编辑器，跳过直接发布")
+// recordImageProgress 记录图片替换进度（只有之前的图片都已替换成功才推进）
+func (m *Manager) recordImageProgress(article *article.Article, platformName string, imageIndex int) {
+	m.status.SetImages(article.Path, platformName, imageIndex+1, len(article.Images))
+	if m.history == nil {
 		return
 	}
-	
-	// 快速检查编辑器元素是否存在
-	titleLocator := page.Locator("textarea.Input")
-	editorLocator := page.Locator("div.Editable-content")
-	
-	// 等待编辑器元素，但使用较短的超时时间
-	titleVisible := make(chan bool, 1)
-	editorVisible := make(chan bool, 1)
-	
-	go func() {
-		err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-			Timeout: playwright.Float(2000), // 2秒超时
-			State:   playwright.WaitForSelectorStateVisible,
-		})
-		titleVisible <- (err == nil)
-	}()
-	
-	go func() {
-		err := editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-			Timeout: playwright.Float(2000), // 2秒超时
-			State:   playwright.WaitForSelectorStateVisible,
-		})
-		editorVisible <- (err == nil)
-	}()
-	
-	// 等待两个检查完成
-	titleReady := <-titleVisible
-	editorReady := <-editorVisible
-	
-	if titleReady && editorReady {
-		log.Println("✅ 检测到知乎编辑器已就绪，开始发布文章")
-		
-		// 创建发布器并发布第一篇文章
-		publisher := zhihu.NewPublisher(page)
-		article := m.articles[0]
-		
-		if err := publisher.PublishArticle(article); err != nil {
-			log.Printf("❌ 直接发布失败: %v", err)
-		} else {
-			log.Printf("🎉 文章《%s》已成功发布到知乎", article.Title)
-		}
-	} else {
-		log.Println("编辑器尚未就绪，将等待登录检测")
+	progress := m.history.GetProgress(article.Path, platformName, article.ContentHash())
+	if progress == nil || progress.ImagesDone != imageIndex {
+		return
 	}
-}
-
-// SaveSession 保存会话状态（带日志输出，用于程序启动和退出）
-func (m *Manager) SaveSession() error {
-	if m.context != nil {
-		stateFile := filepath.Join(m.userDataDir, "state.json")
-		state, err := m.context.StorageState()
-		if err == nil {
-			// 将状态序列化为JSON并保存
-			data, err := json.Marshal(state)
-			if err == nil {
-				err = os.WriteFile(stateFile, data, 0644)
-				if err == nil {
-					// 统计cookies数量
-					cookieCount := 0
-					if state != nil && state.Cookies != nil {
-						cookieCount = len(state.Cookies)
-					}
-					log.Printf("📊 会话数据: %d个cookies, 文件大小: %d bytes", cookieCount, len(data))
-				}
-			}
-		}
-		return err
+	step := history.StepContent
+	if imageIndex+1 == len(article.Images) {
+		step = history.StepImages
 	}
-	return nil
+	m.updateProgress(article, platformName, step, imageIndex+1)
 }
 
-// unifiedPublishFlow 统一发布流程：混合模式（并行平台打开 + 串行图片替换）
-func (m *Manager) unifiedPublishFlow(platformPages map[string]playwright.Page) {
-	if len(m.articles) == 0 {
-		log.Println("没有文章需要发布")
-		return
+// publishArticle 发布单篇文章到所有未完成的平台，返回是否使用了编辑器页面
+func (m *Manager) publishArticle(article *article.Article, platformPages map[string]playwright.Page) bool {
+	log.Printf("开始统一发布文章: %s", article.Title)
+	
+	pending := m.pendingPlatforms(article, m.targetPlatforms(article, platformPages))
+	m.progress.Advance((len(platformPages) - len(pending)) * stepsPerPlatform(article))
+	platformPages = pending
+	if len(platformPages) == 0 {
+		log.Printf("✅ 《%s》在所有平台均已发布，跳过", article.Title)
+		return false
 	}
 	
-	article := m.articles[0]
-	log.Printf("开始统一发布文章: %s", article.Title)
+	// 各平台的失败原因，用于发布报告
+	failures := make(map[string]string)
 	
 	// 1. 等待所有平台编辑器就绪
 	validPages := make(map[string]playwright.Page)
 	for platformName, page := range platformPages {
+		if m.lengthBlocked(platformName, article) {
+			// 正文超过字数上限且策略为阻止，不再等待编辑器
+			failures[platformName] = "正文超过字数上限"
+			continue
+		}
+		if page != nil && m.updateURL(platformName, article) != "" {
+			m.status.SetStep(article.Path, platformName, "打开编辑页")
+			if err := m.openForUpdate(platformName, page, article); err != nil {
+				log.Printf("❌ [%s] %v", platformName, err)
+				failures[platformName] = err.Error()
+				continue
+			}
+		}
 		if page != nil {
+			m.status.SetStep(article.Path, platformName, "等待编辑器")
 			if m.waitForPlatformEditor(platformName, page) {
 				validPages[platformName] = page
 				log.Printf("✅ %s 编辑器就绪", platformName)
 			} else {
 				log.Printf("⚠️ %s 编辑器未就绪，跳过", platformName)
+				failures[platformName] = "编辑器未就绪"
 			}
 		}
 	}
 	
 	if len(validPages) == 0 {
 		log.Println("没有有效的平台页面")
-		return
+		m.progress.Advance(len(platformPages) * stepsPerPlatform(article))
+		m.reportResults(article, platformPages, nil, nil, failures)
+		return false
 	}
 	
 	// 2. 创建平台发布器
-	publishers := make(map[string]interface{})
+	publishers := make(map[string]platform.Publisher)
 	for platformName, page := range validPages {
-		switch platformName {
-		case "掘金":
-			publishers[platformName] = juejin.NewPublisher(page)
-		case "博客园":
-			publishers[platformName] = cnblogs.NewPublisher(page)
-		case "知乎":
-			publishers[platformName] = zhihu.NewPublisher(page)
-		case "SegmentFault":
-			publishers[platformName] = segmentfault.NewPublisher(page)
-		default:
+		publisher, ok := m.platformManager.NewPublisher(platformName, page)
+		if !ok {
 			log.Printf("暂不支持的平台: %s", platformName)
+			failures[platformName] = "暂不支持的平台"
+			continue
 		}
+		publishers[platformName] = publisher
 	}
 	
+	// 未能创建发布器的平台不再有后续步骤
+	m.progress.Advance((len(platformPages) - len(publishers)) * stepsPerPlatform(article))
+	
 	// 3. 并行填写标题和内容（不包含图片替换）
 	var wg sync.WaitGroup
+	var resultMutex sync.Mutex
+	succeeded := make([]string, 0, len(publishers))
 	for platformName, publisher := range publishers {
 		wg.Add(1)
-		go func(name string, pub interface{}) {
+		go func(name string, pub platform.Publisher) {
 			defer wg.Done()
-			m.fillPlatformContent(name, pub, article)
+			defer m.recoverPanic(fmt.Sprintf("%s 内容填写", name))
+			logger := m.taskLogger(name, article)
+			m.throttle.wait(name)
+			m.status.SetStep(article.Path, name, "填写内容")
+			m.updateProgress(article, name, history.StepTitle, 0)
+			err := m.runWithStrategy(name, "内容填写", validPages[name], logger, func() error {
+				return m.fillPlatformContent(name, pub, article, logger)
+			})
+			m.progress.Advance(2)
+			if err != nil {
+				log.Printf("❌ %v", err)
+				resultMutex.Lock()
+				failures[name] = err.Error()
+				resultMutex.Unlock()
+				return
+			}
+			logger.Printf("✅ 内容填写完成")
+			m.updateProgress(article, name, history.StepContent, 0)
+			resultMutex.Lock()
+			succeeded = append(succeeded, name)
+			resultMutex.Unlock()
 		}(platformName, publisher)
 	}
 	wg.Wait()
 	
-	// 4. 如果有图片，按图片顺序进行并行替换（每张图片所有平台并行，但图片间串行）
+	// 4. 如果有图片，按图片顺序逐个替换（每张图片在各平台依次替换，避免焦点和剪贴板竞争）；
+	// 配置了上传并行度时，通过上传控件插入图片的平台不占用剪贴板，在后台各自上传
 	if len(article.Images) > 0 {
 		log.Printf("开始按顺序替换 %d 张图片", len(article.Images))
-		for imageIndex := 0; imageIndex < len(article.Images); imageIndex++ {
-			log.Printf("🖼️ 开始并行替换第 %d 张图片到所有平台", imageIndex+1)
-			m.replaceImageInAllPlatforms(publishers, article, imageIndex)
+		serial, uploading := m.splitUploadPlatforms(publishers)
+		var uploadWg sync.WaitGroup
+		m.uploadImagesInParallel(uploading, validPages, article, &uploadWg)
+		for imageIndex := 0; len(serial) > 0 && imageIndex < len(article.Images); imageIndex++ {
+			log.Printf("🖼️ 开始替换第 %d 张图片到所有平台", imageIndex+1)
+			m.replaceImageInAllPlatforms(serial, validPages, article, imageIndex)
 			// 等待一段时间再处理下一张图片，确保剪贴板操作不冲突
 			time.Sleep(2 * time.Second)
 		}
+		uploadWg.Wait()
+	}
+	
+	// 浏览器中途崩溃时各平台的编辑器内容已丢失，不记录发布结果，恢复后重新发布
+	if m.crashed.Load() {
+		log.Printf("⚠️ 《%s》发布过程中浏览器崩溃，恢复后将重新发布", article.Title)
+		return true
+	}
+	
+	// 5. 设置封面（文章指定的封面优先，未指定时自动生成）
+	if coverPath := m.coverFor(article); coverPath != "" {
+		for _, name := range succeeded {
+			m.applyCover(name, publishers[name], coverPath)
+		}
+	}
+	
+	// 6. 填写摘要（frontmatter 中的 description 优先，未指定时从正文提取）
+	if summary := article.Summary(m.summaryLength); summary != "" {
+		for _, name := range succeeded {
+			m.applySummary(name, publishers[name], summary)
+		}
+	}
+	
+	// 7. 内容和图片都处理完后再设置可见范围和定时发布，避免发布面板遮挡编辑器；修改已发布的文章时不定时
+	for _, name := range succeeded {
+		m.applyVisibility(name, publishers[name], article)
+		if m.updateURL(name, article) == "" {
+			m.applySchedule(name, publishers[name], article)
+		}
 	}
 	
+	// 8. 按配置的提交方式保存草稿或发布，提交失败的平台不记录历史；
+	// 自动发布成功后页面跳转到文章页，记下链接供系列导航引用
+	submitted := make([]string, 0, len(succeeded))
+	urls := make(map[string]string)
+	unverified := make(map[string]string)
+	for _, name := range succeeded {
+		if m.updateURL(name, article) != "" {
+			// 修改已发布的文章不区分草稿和发布，直接保存更新
+			m.status.SetStep(article.Path, name, "保存更新")
+			url, err := m.submitUpdate(name, publishers[name], article)
+			if err != nil {
+				log.Printf("❌ [%s] 保存更新失败: %v", name, err)
+				failures[name] = fmt.Sprintf("保存更新失败: %v", err)
+				continue
+			}
+			urls[name] = url
+		} else if submitter, ok := publishers[name].(platform.Submitter); ok {
+			m.status.SetStep(article.Path, name, "提交")
+			if err := submitter.Submit(m.publishMode); err != nil {
+				log.Printf("❌ [%s] 提交失败: %v", name, err)
+				failures[name] = fmt.Sprintf("提交失败: %v", err)
+				continue
+			}
+			if m.publishMode == platform.PublishModePublish {
+				urls[name] = platformPages[name].URL()
+			}
+			// 提交成功不代表文章一定存在（审核拦截、静默失败），到文章页或文章列表中确认
+			if reason, ok := m.verifySubmitted(name, publishers[name], article, urls); !ok {
+				unverified[name] = reason
+			}
+		} else if m.publishMode == platform.PublishModePublish {
+			log.Printf("⚠️ [%s] 暂不支持自动发布，请在浏览器中手动发布", name)
+		}
+		m.recordSubmitted(article, name)
+		submitted = append(submitted, name)
+	}
+	succeeded = submitted
+	
+	// 记录各平台的发布时间，下一篇文章按平台限流
+	for name := range publishers {
+		m.throttle.done(name, failures[name] == "")
+	}
+	
+	contentHash := article.ContentHash()
+	for _, name := range succeeded {
+		m.published[publishedKey(article, name, contentHash)] = true
+	}
+	m.recordHistory(article, succeeded, urls)
+	m.reportResults(article, platformPages, succeeded, unverified, failures)
+	if m.onPublished != nil {
+		m.onPublished(article, succeeded)
+	}
+	m.progress.Advance(len(publishers))
 	log.Printf("🎉 文章《%s》统一发布完成", article.Title)
+	return true
+}
+
+// recordSubmitted 记录平台已提交，记入发布历史前崩溃时下次运行不再重复提交；
+// 图片未全部替换的平台不记录，下次仍重新发布
+func (m *Manager) recordSubmitted(article *article.Article, platformName string) {
+	if m.history == nil {
+		return
+	}
+	progress := m.history.GetProgress(article.Path, platformName, article.ContentHash())
+	imagesDone := 0
+	if progress != nil {
+		imagesDone = progress.ImagesDone
+	}
+	if len(article.Images) > 0 && (progress == nil || progress.Step < history.StepImages) {
+		return
+	}
+	m.updateProgress(article, platformName, history.StepSubmitted, imagesDone)
+}
+
+// recordHistory 记录文章在各平台的发布历史，urls 为已知的文章链接
+func (m *Manager) recordHistory(article *article.Article, platforms []string, urls map[string]string) {
+	if m.history == nil || len(platforms) == 0 {
+		return
+	}
+
+	contentHash := article.ContentHash()
+	for _, platformName := range platforms {
+		// 图片未全部替换成功的平台保留进度，下次重新发布
+		if len(article.Images) > 0 {
+			progress := m.history.GetProgress(article.Path, platformName, contentHash)
+			if progress == nil || progress.Step < history.StepImages {
+				log.Printf("⚠️ 《%s》在 %s 的图片未全部替换，下次运行将重新发布", article.Title, platformName)
+				continue
+			}
+		}
+		m.history.Add(history.Record{
+			ArticlePath: article.Path,
+			Title:       article.Title,
+			Platform:    platformName,
+			ContentHash: contentHash,
+			URL:         urls[platformName],
+		})
+		m.history.ClearProgress(article.Path, platformName)
+	}
+
+	if err := m.history.Save(); err != nil {
+		log.Printf("⚠️ 保存发布历史失败: %v", err)
+	}
+}
+
+// reportResults 将文章在各平台的发布结果记入发布报告，unverified 为已提交但未能确认文章存在的平台及原因
+func (m *Manager) reportResults(article *article.Article, platformPages map[string]playwright.Page, succeeded []string, unverified, failures map[string]string) {
+	done := make(map[string]bool, len(succeeded))
+	for _, name := range succeeded {
+		done[name] = true
+	}
+	for platformName := range platformPages {
+		reason, notConfirmed := unverified[platformName]
+		switch {
+		case done[platformName] && notConfirmed:
+			m.status.SetResult(article.Path, platformName, status.ResultUnverified, reason)
+		case done[platformName]:
+			m.status.SetResult(article.Path, platformName, status.ResultSucceeded, "")
+		default:
+			m.status.SetResult(article.Path, platformName, status.ResultFailed, failures[platformName])
+		}
+		if m.report == nil {
+			continue
+		}
+		result := notify.Result{
+			Title:      article.Title,
+			Path:       article.Path,
+			Platform:   platformName,
+			Success:    done[platformName] && !notConfirmed,
+			Unverified: done[platformName] && notConfirmed,
+		}
+		if result.Unverified {
+			result.Error = reason
+		} else if !result.Success {
+			result.Error = failures[platformName]
+		}
+		m.report.Add(result)
+	}
 }
 
 // waitForPlatformEditor 等待平台编辑器就绪
 func (m *Manager) waitForPlatformEditor(platformName string, page playwright.Page) bool {
-	switch platformName {
-	case "掘金":
-		return m.waitForJuejinEditor(page)
-	case "博客园":
-		return m.waitForCnblogsEditor(page)
-	case "知乎":
-		return m.waitForZhihuEditor(page)
-	case "SegmentFault":
-		return m.waitForSegmentFaultEditor(page)
-	default:
-		return false
+	return m.platformManager.WaitForEditor(platformName, page)
+}
+
+// platformIDs 平台名称对应的平台标识，用于查找 frontmatter 中按平台配置的标题
+var platformIDs = map[string]string{
+	juejin.Name:       juejin.ID,
+	cnblogs.Name:      cnblogs.ID,
+	zhihu.Name:        zhihu.ID,
+	segmentfault.Name: segmentfault.ID,
+	toutiao.Name:      toutiao.ID,
+	baijiahao.Name:    baijiahao.ID,
+}
+
+// taskLogger 返回平台上当前文章的日志记录器，日志带 [平台][文章N] 前缀，N 为文章在本轮发布中的序号
+func (m *Manager) taskLogger(platformName string, art *article.Article) *tasklog.Logger {
+	for i, candidate := range m.articles {
+		if candidate == art {
+			return tasklog.New(platformName, i+1)
+		}
 	}
+	return tasklog.New(platformName, 0)
 }
 
-// waitForJuejinEditor 等待掘金编辑器
-func (m *Manager) waitForJuejinEditor(page playwright.Page) bool {
-	titleLocator := page.Locator("input.title-input")
-	editorLocator := page.Locator("div.CodeMirror-scroll")
-	
-	err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(5000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		return false
+// fillPlatformContent 给平台填写内容（根据平台特性处理图片），frontmatter 为该平台指定了标题时使用平台标题
+func (m *Manager) fillPlatformContent(platformName string, publisher platform.Publisher, article *article.Article, logger *tasklog.Logger) error {
+	logger.Printf("开始填写内容: %s", article.Title)
+	if title := article.TitleFor(platformIDs[platformName], platformName); title != article.Title {
+		logger.Printf("使用平台标题: %s", title)
+		article = article.WithTitle(title)
 	}
-	
-	err = editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(5000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	return err == nil
+	article = m.truncateFor(platformName, article, logger)
+	article = m.withSeriesNavigation(platformName, article, logger)
+	article = m.withResolvedLinks(platformName, article, logger)
+	article = m.withVideos(publisher, article, logger)
+	return publisher.PublishArticle(article)
 }
 
-// waitForCnblogsEditor 等待博客园编辑器
-func (m *Manager) waitForCnblogsEditor(page playwright.Page) bool {
-	titleLocator := page.Locator("#post-title")
-	editorLocator := page.Locator("#md-editor")
-	
-	err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(5000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
+// verifySubmitted 提交后确认文章在平台上确实存在，找到文章链接时更新 urls。
+// 平台不支持验证时视为已确认；验证失败时返回原因和 false
+func (m *Manager) verifySubmitted(platformName string, publisher platform.Publisher, article *article.Article, urls map[string]string) (string, bool) {
+	verifier, ok := publisher.(platform.Verifier)
+	if !ok {
+		return "", true
+	}
+	logger := m.taskLogger(platformName, article)
+	url, err := verifier.VerifySubmitted(article.TitleFor(platformIDs[platformName], platformName), m.publishMode)
 	if err != nil {
-		return false
+		logger.Printf("⚠️ 已提交但未确认成功: %v", err)
+		return err.Error(), false
 	}
-	
-	err = editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(5000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	return err == nil
+	if url != "" && m.publishMode == platform.PublishModePublish {
+		urls[platformName] = url
+	}
+	return "", true
 }
 
-// waitForZhihuEditor 等待知乎编辑器
-func (m *Manager) waitForZhihuEditor(page playwright.Page) bool {
-	titleLocator := page.Locator("textarea.Input")
-	editorLocator := page.Locator("div.Editable-content")
-	
-	err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(5000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		return false
+// applySchedule 文章指定了发布时间时在平台上设置定时发布，
+// 时间已过、平台不支持或设置失败时降级为立即发布
+func (m *Manager) applySchedule(platformName string, publisher platform.Publisher, article *article.Article) {
+	publishAt, ok := article.ScheduledTime()
+	if !ok {
+		return
 	}
-	
-	err = editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(5000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	return err == nil
+	logger := m.taskLogger(platformName, article)
+	if !publishAt.After(time.Now()) {
+		logger.Printf("⏰ 《%s》的发布时间 %s 已过，改为立即发布", article.Title, publishAt.Format("2006-01-02 15:04"))
+		return
+	}
+
+	scheduler, ok := publisher.(platform.SchedulePublisher)
+	if !ok {
+		logger.Printf("⏰ 平台不支持定时发布，改为立即发布")
+		return
+	}
+	if err := scheduler.SchedulePublish(publishAt); err != nil {
+		logger.Printf("⚠️ 设置定时发布失败，改为立即发布: %v", err)
+		return
+	}
+	logger.Printf("⏰ 已设置定时发布: %s", publishAt.Format("2006-01-02 15:04"))
 }
 
-// fillPlatformContent 给平台填写内容（根据平台特性处理图片）
-func (m *Manager) fillPlatformContent(platformName string, publisher interface{}, article *article.Article) {
-	log.Printf("开始为 %s 填写内容", platformName)
-	
-	switch platformName {
-	case "掘金":
-		if pub, ok := publisher.(*juejin.Publisher); ok {
-			if err := pub.PublishArticle(article); err != nil {
-				log.Printf("❌ %s 内容填写失败: %v", platformName, err)
-			} else {
-				log.Printf("✅ %s 内容填写完成", platformName)
-			}
-		}
-	case "博客园":
-		if pub, ok := publisher.(*cnblogs.Publisher); ok {
-			if err := pub.PublishArticle(article); err != nil {
-				log.Printf("❌ %s 内容填写失败: %v", platformName, err)
-			} else {
-				log.Printf("✅ %s 内容填写完成", platformName)
-			}
-		}
-	case "知乎":
-		if pub, ok := publisher.(*zhihu.Publisher); ok {
-			// 知乎也直接调用PublishArticle，但知乎内部会使用占位符方式
-			if err := pub.PublishArticle(article); err != nil {
-				log.Printf("❌ %s 内容填写失败: %v", platformName, err)
-			} else {
-				log.Printf("✅ %s 内容填写完成（待图片替换）", platformName)
-			}
-		}
-	case "SegmentFault":
-		if pub, ok := publisher.(*segmentfault.Publisher); ok {
-			if err := pub.PublishArticle(article); err != nil {
-				log.Printf("❌ %s 内容填写失败: %v", platformName, err)
-			} else {
-				log.Printf("✅ %s 内容填写完成", platformName)
-			}
+// applyVisibility 在平台的发布设置中选择配置的可见范围，
+// 平台不支持该可见范围或设置失败时保持公开并警告
+func (m *Manager) applyVisibility(platformName string, publisher platform.Publisher, article *article.Article) {
+	visibility, ok := m.visibilities[platformName]
+	if !ok || visibility == platform.VisibilityPublic {
+		return
+	}
+	logger := m.taskLogger(platformName, article)
+
+	setter, ok := publisher.(platform.VisibilityPublisher)
+	if !ok || !setter.SupportsVisibility(visibility) {
+		logger.Printf("⚠️ 平台不支持可见范围 %s，将公开发布", visibility)
+		return
+	}
+	if err := setter.SetVisibility(visibility); err != nil {
+		logger.Printf("⚠️ 设置可见范围失败，将公开发布: %v", err)
+		return
+	}
+	logger.Printf("🔒 已设置可见范围: %s", visibility)
+}
+
+// coverFor 返回文章的封面图路径：优先使用 frontmatter 中的 cover，未指定时按配置自动生成
+func (m *Manager) coverFor(article *article.Article) string {
+	if coverPath := article.CoverPath(); coverPath != "" {
+		if strings.HasPrefix(coverPath, "http://") || strings.HasPrefix(coverPath, "https://") {
+			log.Printf("⚠️ 《%s》的封面为网络图片，暂不支持上传: %s", article.Title, coverPath)
+			return ""
 		}
+		return coverPath
+	}
+	if m.cover == nil {
+		return ""
 	}
+
+	coverPath, err := m.cover.Generate(article.Title)
+	if err != nil {
+		log.Printf("⚠️ 《%s》生成封面失败: %v", article.Title, err)
+		return ""
+	}
+	log.Printf("🎨 已为《%s》生成封面: %s", article.Title, coverPath)
+	return coverPath
 }
 
+// applyCover 在支持封面的平台上传封面图，失败时只记录警告
+func (m *Manager) applyCover(platformName string, publisher platform.Publisher, coverPath string) {
+	uploader, ok := publisher.(platform.CoverUploader)
+	if !ok {
+		return
+	}
+	if err := uploader.UploadCover(coverPath); err != nil {
+		log.Printf("[%s] ⚠️ 设置封面失败: %v", platformName, err)
+		return
+	}
+	log.Printf("[%s] 🖼️ 已设置封面", platformName)
+}
+
+// applySummary 在支持摘要的平台填写摘要，失败时只记录警告
+func (m *Manager) applySummary(platformName string, publisher platform.Publisher, summary string) {
+	summarizer, ok := publisher.(platform.SummaryPublisher)
+	if !ok {
+		return
+	}
+	if err := summarizer.SetSummary(summary); err != nil {
+		log.Printf("[%s] ⚠️ %v", platformName, err)
+		return
+	}
+	log.Printf("[%s] 📝 已填写摘要", platformName)
+}
 
-// replaceImageInAllPlatforms 在所有平台并行替换指定索引的图片
-func (m *Manager) replaceImageInAllPlatforms(publishers map[string]interface{}, article *article.Article, imageIndex int) {
+// replaceImageInAllPlatforms 在所有平台依次替换指定索引的图片
+func (m *Manager) replaceImageInAllPlatforms(publishers map[string]platform.Publisher, pages map[string]playwright.Page, article *article.Article, imageIndex int) {
 	if imageIndex >= len(article.Images) {
 		return
 	}
@@ -829,286 +947,289 @@ func (m *Manager) replaceImageInAllPlatforms(publishers map[string]interface{},
 	image := article.Images[imageIndex]
 	placeholder := fmt.Sprintf("IMAGE_PLACEHOLDER_%d", imageIndex)
 	
-	var wg sync.WaitGroup
-	
-	// 为每个平台启动一个goroutine进行图片替换
+	// 按平台串行替换：剪贴板和页面焦点是全局共享的，并行切换标签页容易把图片粘到其它平台
+	for platformName, publisher := range publishers {
+		if imageIndex >= m.imageCountFor(platformName, article) {
+			// 正文截断后该平台不包含这张图片
+			m.progress.Advance(1)
+			m.recordImageProgress(article, platformName, imageIndex)
+			continue
+		}
+		m.replaceImageOnPlatform(platformName, publisher, pages[platformName], article, imageIndex, placeholder, image)
+	}
+	log.Printf("✅ 第 %d 张图片已在所有平台替换完成", imageIndex+1)
+}
+
+// splitUploadPlatforms 按图片插入方式拆分平台：配置了上传并行度时，使用上传控件的平台单独返回，
+// 其余平台（剪贴板粘贴、图床、跳过图片）仍按图片顺序逐个平台替换
+func (m *Manager) splitUploadPlatforms(publishers map[string]platform.Publisher) (serial, uploading map[string]platform.Publisher) {
+	serial = make(map[string]platform.Publisher, len(publishers))
+	uploading = make(map[string]platform.Publisher)
+	for platformName, publisher := range publishers {
+		if _, ok := publisher.(platform.ImageUploader); ok && m.uploadLimit > 1 && m.imageStrategyFor(platformName) == platform.ImageStrategyUpload {
+			uploading[platformName] = publisher
+			continue
+		}
+		serial[platformName] = publisher
+	}
+	return serial, uploading
+}
+
+// uploadImagesInParallel 使用上传控件的平台各自在后台替换全部图片，所有平台合计最多同时上传 uploadLimit 张。
+// 同一平台的页面内仍按占位符顺序逐张上传，选中占位符和插入图片不会在同一个编辑器中交错，图片与占位符一一对应
+func (m *Manager) uploadImagesInParallel(publishers map[string]platform.Publisher, pages map[string]playwright.Page, article *article.Article, wg *sync.WaitGroup) {
+	if len(publishers) == 0 {
+		return
+	}
+	log.Printf("📤 %d 个平台通过上传控件并行上传图片（同时最多 %d 张）", len(publishers), m.uploadLimit)
+	slots := make(chan struct{}, m.uploadLimit)
 	for platformName, publisher := range publishers {
 		wg.Add(1)
-		go func(name string, pub interface{}) {
+		go func(name string, pub platform.Publisher) {
 			defer wg.Done()
-			m.replaceImageByIndex(name, pub, placeholder, image)
+			for imageIndex, image := range article.Images {
+				if imageIndex >= m.imageCountFor(name, article) {
+					// 正文截断后该平台不包含这张图片
+					m.progress.Advance(1)
+					m.recordImageProgress(article, name, imageIndex)
+					continue
+				}
+				slots <- struct{}{}
+				m.replaceImageOnPlatform(name, pub, pages[name], article, imageIndex, fmt.Sprintf("IMAGE_PLACEHOLDER_%d", imageIndex), image)
+				<-slots
+			}
 		}(platformName, publisher)
 	}
-	
-	// 等待所有平台完成当前图片的替换
-	wg.Wait()
-	log.Printf("✅ 第 %d 张图片已在所有平台替换完成", imageIndex+1)
 }
 
-// replaceImageByIndex 在指定平台替换占位符为图片
-func (m *Manager) replaceImageByIndex(platformName string, publisher interface{}, placeholder string, image article.Image) {
-	log.Printf("[%s] 🔍 开始替换占位符: %s", platformName, placeholder)
-	
-	switch platformName {
-	case "掘金":
-		if pub, ok := publisher.(*juejin.Publisher); ok {
-			if err := pub.ReplaceTextWithImage(placeholder, image); err != nil {
-				log.Printf("❌ [%s] 图片替换失败: %v", platformName, err)
-			} else {
-				log.Printf("✅ [%s] 图片替换完成", platformName)
-			}
+// replaceImageOnPlatform 在单个平台替换指定索引的图片
+func (m *Manager) replaceImageOnPlatform(platformName string, publisher platform.Publisher, page playwright.Page, article *article.Article, imageIndex int, placeholder string, image article.Image) {
+	defer m.recoverPanic(fmt.Sprintf("%s 图片替换", platformName))
+	logger := m.taskLogger(platformName, article)
+	err := m.runWithStrategy(platformName, fmt.Sprintf("第%d张图片替换", imageIndex+1), page, logger, func() error {
+		return m.replaceImageByIndex(platformName, publisher, page, placeholder, image, logger)
+	})
+	m.progress.Advance(1)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		return
+	}
+	logger.Printf("✅ 第 %d 张图片替换完成", imageIndex+1)
+	m.recordImageProgress(article, platformName, imageIndex)
+}
+
+// replaceImageByIndex 在指定平台按配置的图片策略替换占位符
+func (m *Manager) replaceImageByIndex(platformName string, publisher platform.Publisher, page playwright.Page, placeholder string, image article.Image, logger *tasklog.Logger) error {
+	strategy := m.imageStrategyFor(platformName)
+	logger.Printf("🔍 开始替换占位符: %s（图片策略: %s）", placeholder, strategy)
+	image = m.watermarkFor(platformName, image)
+
+	switch strategy {
+	case platform.ImageStrategySkip:
+		replacer, ok := publisher.(platform.TextReplacer)
+		if !ok {
+			return fmt.Errorf("[%s] 不支持跳过图片策略", platformName)
 		}
-	case "博客园":
-		if pub, ok := publisher.(*cnblogs.Publisher); ok {
-			if err := pub.ReplaceTextWithImage(placeholder, image); err != nil {
-				log.Printf("❌ [%s] 图片替换失败: %v", platformName, err)
-			} else {
-				log.Printf("✅ [%s] 图片替换完成", platformName)
-			}
+		return replacer.ReplaceTextWithText(placeholder, imageAltPlaceholder(image))
+
+	case platform.ImageStrategyImageHost:
+		replacer, ok := publisher.(platform.TextReplacer)
+		if !ok {
+			return fmt.Errorf("[%s] 不支持图床策略", platformName)
 		}
-	case "知乎":
-		if pub, ok := publisher.(*zhihu.Publisher); ok {
-			if err := pub.ReplaceTextWithImage(placeholder, image); err != nil {
-				log.Printf("❌ [%s] 图片替换失败: %v", platformName, err)
-			} else {
-				log.Printf("✅ [%s] 图片替换完成", platformName)
-			}
+		if m.imageHost == nil {
+			return fmt.Errorf("未配置图床，无法使用图床策略")
 		}
-	case "SegmentFault":
-		if pub, ok := publisher.(*segmentfault.Publisher); ok {
-			if err := pub.ReplaceTextWithImage(placeholder, image); err != nil {
-				log.Printf("❌ [%s] 图片替换失败: %v", platformName, err)
-			} else {
-				log.Printf("✅ [%s] 图片替换完成", platformName)
-			}
+		url, err := m.imageHost.Upload(image.AbsolutePath)
+		if err != nil {
+			return err
+		}
+		return replacer.ReplaceTextWithText(placeholder, imageMarkup(image, url))
+
+	case platform.ImageStrategyUpload:
+		if uploader, ok := publisher.(platform.ImageUploader); ok {
+			return uploader.UploadImage(placeholder, image)
 		}
-	default:
-		log.Printf("⚠️ [%s] 暂不支持图片替换", platformName)
+		logger.Printf("⚠️ 平台不支持上传控件，改用剪贴板粘贴")
 	}
-}
 
-// tryPublishToSegmentFault 尝试发布文章到SegmentFault
-func (m *Manager) tryPublishToSegmentFault(page playwright.Page) {
-	currentURL := page.URL()
-	
-	// 检查是否在登录页面
-	if strings.Contains(currentURL, "segmentfault.com/user/login") {
-		log.Println("🔐 检测到SegmentFault未登录，请在浏览器中完成登录")
-		
-		// 等待用户登录
-		ticker := time.NewTicker(1 * time.Second)
-		defer ticker.Stop()
-		
-		for {
-			select {
-			case <-ticker.C:
-				currentURL = page.URL()
-				
-				// 检查是否已经离开登录页面
-				if !strings.Contains(currentURL, "segmentfault.com/user/login") {
-					log.Println("✅ SegmentFault登录成功")
-					
-					// 保存会话状态
-					if err := m.SaveSession(); err != nil {
-						log.Printf("⚠️ 登录成功后保存会话失败: %v", err)
-					} else {
-						log.Println("💾 登录成功，会话状态已保存")
-					}
-					
-					// 跳转到写作页面
-					if _, err := page.Goto(segmentfault.URL()); err != nil {
-						log.Printf("⚠️ 跳转到写作页面失败: %v", err)
-						return
-					}
-					
-					// 登录成功后发布文章
-					if len(m.articles) > 0 {
-						publisher := segmentfault.NewPublisher(page)
-						if err := publisher.WaitForEditor(); err != nil {
-							log.Printf("❌ 等待编辑器失败: %v", err)
-							return
-						}
-						
-						article := m.articles[0]
-						if err := publisher.PublishArticle(article); err != nil {
-							log.Printf("❌ 发布失败: %v", err)
-						} else {
-							log.Printf("🎉 文章《%s》已发布到SegmentFault", article.Title)
-						}
-					}
-					return
-				}
-			}
+	// 超大图片不经过 base64 剪贴板，平台支持上传控件时直接上传原图，否则复制前先压缩
+	if common.ImageTooLargeForClipboard(image.AbsolutePath) {
+		if uploader, ok := publisher.(platform.ImageUploader); ok {
+			logger.Printf("⚠️ 图片超过剪贴板大小上限，改用上传控件插入图片")
+			return uploader.UploadImage(placeholder, image)
 		}
 	}
-}
 
-// tryPublishToSegmentFaultSync 同步尝试发布文章到SegmentFault
-func (m *Manager) tryPublishToSegmentFaultSync(page playwright.Page) bool {
-	currentURL := page.URL()
-	
-	// 检查是否在登录页面
-	if strings.Contains(currentURL, "segmentfault.com/user/login") {
-		log.Println("🔐 检测到SegmentFault未登录，请在浏览器中完成登录")
-		
-		// 等待用户登录
-		ticker := time.NewTicker(1 * time.Second)
-		defer ticker.Stop()
-		
-		for {
-			select {
-			case <-ticker.C:
-				currentURL = page.URL()
-				
-				// 检查是否已经离开登录页面
-				if !strings.Contains(currentURL, "segmentfault.com/user/login") {
-					log.Println("✅ SegmentFault登录成功")
-					
-					// 保存会话状态
-					if err := m.SaveSession(); err != nil {
-						log.Printf("⚠️ 登录成功后保存会话失败: %v", err)
-					} else {
-						log.Println("💾 登录成功，会话状态已保存")
-					}
-					
-					// 跳转到写作页面
-					if _, err := page.Goto(segmentfault.URL()); err != nil {
-						log.Printf("⚠️ 跳转到写作页面失败: %v", err)
-						return false
-					}
-					
-					// 登录成功后发布文章
-					if len(m.articles) > 0 {
-						publisher := segmentfault.NewPublisher(page)
-						if err := publisher.WaitForEditor(); err != nil {
-							log.Printf("❌ 等待编辑器失败: %v", err)
-							return false
-						}
-						
-						article := m.articles[0]
-						if err := publisher.PublishArticle(article); err != nil {
-							log.Printf("❌ 发布失败: %v", err)
-							return false
-						}
-						
-						log.Printf("🎉 文章《%s》已发布到SegmentFault", article.Title)
-					}
-					return true
-				}
-			}
+	// 剪贴板 API 不可用（无头模式、权限被拒等）时优先改用上传控件，
+	// 平台不支持上传控件时由复制图片时回退到系统剪贴板
+	if err := common.ProbeClipboard(page); err != nil {
+		if uploader, ok := publisher.(platform.ImageUploader); ok {
+			logger.Printf("⚠️ %v，改用上传控件插入图片", err)
+			return uploader.UploadImage(placeholder, image)
 		}
+		logger.Printf("⚠️ %v，平台不支持上传控件，将通过系统剪贴板粘贴图片", err)
+	} else {
+		logger.Printf("📎 通过剪贴板 API 粘贴图片")
 	}
-	
-	return false
+	return publisher.ReplaceTextWithImage(placeholder, image)
 }
 
-// waitForSegmentFaultEditor 等待SegmentFault编辑器
-func (m *Manager) waitForSegmentFaultEditor(page playwright.Page) bool {
-	currentURL := page.URL()
-	log.Printf("[SegmentFault] 当前页面URL: %s", currentURL)
-	
-	// 检查是否在登录页面，如果是则等待用户登录
-	if strings.Contains(currentURL, "segmentfault.com/user/login") {
-		log.Println("[SegmentFault] 🔐 检测到SegmentFault未登录，请在浏览器中完成登录")
-		
-		// 循环等待用户登录
-		ticker := time.NewTicker(1 * time.Second)
-		defer ticker.Stop()
-		
-		for {
-			select {
-			case <-ticker.C:
-				// 实时获取当前URL
-				currentURL = page.URL()
-				log.Printf("[SegmentFault] 检测URL变化: %s", currentURL)
-				
-				// 检查是否已经跳转离开登录页面
-				if !strings.Contains(currentURL, "segmentfault.com/user/login") {
-					log.Println("[SegmentFault] ✅ 检测到已离开登录页面")
-					
-					// 保存会话状态
-					if err := m.SaveSession(); err != nil {
-						log.Printf("[SegmentFault] ⚠️ 保存会话失败: %v", err)
-					} else {
-						log.Println("[SegmentFault] 💾 会话状态已保存")
-					}
-					
-					// 跳出循环，继续执行编辑器检测
-					goto continueEditorCheck
-				}
-			}
-		}
+// watermarkFor 平台开启水印时返回加了水印的图片，处理失败时使用原图
+func (m *Manager) watermarkFor(platformName string, image article.Image) article.Image {
+	if m.watermark == nil {
+		return image
 	}
-	
-continueEditorCheck:
-	// 重新获取当前URL（可能在登录后有变化）
-	currentURL = page.URL()
-	log.Printf("[SegmentFault] 继续检测编辑器，当前URL: %s", currentURL)
-	
-	// 检查是否在写作页面，如果不是则跳转
-	if !strings.Contains(currentURL, "segmentfault.com/write") {
-		log.Printf("[SegmentFault] 当前不在写作页面，跳转到: %s", segmentfault.URL())
+	if enabled, ok := m.watermarkOn[platformName]; ok && !enabled {
+		return image
+	}
+
+	watermarked, err := m.watermark.Apply(image.AbsolutePath)
+	if err != nil {
+		log.Printf("[%s] ⚠️ 图片加水印失败，使用原图: %v", platformName, err)
+		return image
+	}
+	image.AbsolutePath = watermarked
+	return image
+}
+
+// imageStrategyFor 获取平台的图片策略，未配置时使用剪贴板粘贴
+func (m *Manager) imageStrategyFor(platformName string) platform.ImageStrategy {
+	if strategy, ok := m.imageStrategies[platformName]; ok {
+		return strategy
+	}
+	return platform.ImageStrategyClipboard
+}
+
+// imageMarkup 图床链接插入编辑器的写法：指定了尺寸（![alt](img =300x200)）时使用带宽高的 <img> 标签，
+// 标准 Markdown 图片语法无法表达尺寸
+func imageMarkup(image article.Image, url string) string {
+	if image.Width == 0 && image.Height == 0 {
+		return fmt.Sprintf("![%s](%s)", image.AltText, url)
+	}
+	return fmt.Sprintf(`<img src="%s" alt="%s"%s />`, url, html.EscapeString(image.AltText), image.SizeAttributes())
+}
+
+// imageAltPlaceholder 跳过图片时使用的占位文本，没有 alt 文本时使用文件名
+func imageAltPlaceholder(image article.Image) string {
+	alt := image.AltText
+	if alt == "" {
+		alt = filepath.Base(image.RelativePath)
+	}
+	return fmt.Sprintf("[图片: %s]", alt)
+}
+
+// 发布失败的重试策略参数
+const (
+	maxPublishAttempts = 3                // 每个步骤最多尝试次数
+	networkRetryDelay  = 3 * time.Second  // 网络错误重试间隔
+	rateLimitBaseDelay = 30 * time.Second // 限流退避的初始等待时间（之后翻倍）
+	reloginTimeout     = 5 * time.Minute  // 等待用户重新登录的最长时间
+)
+
+// runWithStrategy 执行发布步骤，失败时按错误类别采取不同策略：
+// 网络错误重试，选择器失效立即报告，登录失效等待重新登录，平台限流退避等待
+func (m *Manager) runWithStrategy(platformName, step string, page playwright.Page, logger *tasklog.Logger, action func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := action()
+		if err == nil {
+			return nil
+		}
 		
-		if _, err := page.Goto(segmentfault.URL()); err != nil {
-			log.Printf("[SegmentFault] ❌ 跳转到写作页面失败: %v", err)
-			return false
+		publishErr := classifyError(platformName, step, err)
+		if publishErr.Kind == ErrorRateLimit {
+			m.throttle.backoff(platformName)
+		}
+		if attempt >= maxPublishAttempts {
+			return publishErr
 		}
 		
-		// 等待页面加载
-		time.Sleep(2 * time.Second)
-		currentURL = page.URL()
-		log.Printf("[SegmentFault] 跳转后URL: %s", currentURL)
+		switch publishErr.Kind {
+		case ErrorNetwork:
+			logger.Printf("🌐 %s遇到网络错误，%v 后重试（第 %d 次）: %v", step, networkRetryDelay, attempt, err)
+			time.Sleep(networkRetryDelay)
+		case ErrorRateLimit:
+			delay := rateLimitBaseDelay * time.Duration(1<<(attempt-1))
+			logger.Printf("🐢 触发平台限流，等待 %v 后重试: %v", delay, err)
+			time.Sleep(delay)
+		case ErrorLogin:
+			logger.Printf("🔐 登录已失效，请在浏览器中重新登录")
+			if !m.waitForRelogin(platformName, page) {
+				return publishErr
+			}
+		default:
+			// 选择器失效等错误重试无意义，立即报告
+			return publishErr
+		}
 	}
-	
-	log.Println("[SegmentFault] 开始等待编辑器元素...")
-	
-	titleLocator := page.Locator("input[placeholder*='标题']")
-	editorLocator := page.Locator(".CodeMirror")
-	
-	log.Println("[SegmentFault] 等待标题输入框...")
-	err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		log.Printf("[SegmentFault] ❌ 等待标题输入框失败: %v", err)
+}
+
+// waitForRelogin 重新打开平台编辑器并等待用户完成登录
+func (m *Manager) waitForRelogin(platformName string, page playwright.Page) bool {
+	url, ok := m.platformURLs[platformName]
+	if !ok || page == nil {
 		return false
 	}
-	log.Println("[SegmentFault] ✅ 标题输入框已就绪")
 	
-	log.Println("[SegmentFault] 等待编辑器...")
-	err = editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000),
-		State:   playwright.WaitForSelectorStateVisible,
-	})
-	if err != nil {
-		log.Printf("[SegmentFault] ❌ 等待编辑器失败: %v", err)
-		return false
+	deadline := time.Now().Add(reloginTimeout)
+	for time.Now().Before(deadline) {
+		if _, err := common.HumanGoto(page, url); err != nil {
+			log.Printf("⚠️ [%s] 重新打开编辑器失败: %v", platformName, err)
+		}
+		// 每轮等待约30秒，登录后平台可能跳转到首页，下一轮会重新打开编辑器
+		for i := 0; i < 6; i++ {
+			if m.waitForPlatformEditor(platformName, page) {
+				log.Printf("✅ [%s] 已重新登录", platformName)
+				return true
+			}
+		}
 	}
 	
-	log.Println("[SegmentFault] ✅ 编辑器已就绪")
-	return true
+	log.Printf("❌ [%s] 等待重新登录超时", platformName)
+	return false
 }
 
 // Close 关闭浏览器和Playwright
 func (m *Manager) Close() {
-	// 标记正在关闭，避免重复保存
-	m.closing = true
+	m.closeOnce.Do(func() {
+		// 标记正在关闭，避免重复保存
+		m.closing.Store(true)
 
-	// 最后保存一次会话状态
-	if err := m.SaveSession(); err != nil {
-		log.Printf("🚫 程序退出时保存会话状态失败: %v", err)
-	} else {
-		log.Println("💾 程序退出时会话状态已保存")
-	}
+		// 最后保存一次会话状态（浏览器已断开时上下文不可用，跳过）
+		runSafely(func() {
+			if m.browser != nil && !m.browser.IsConnected() {
+				return
+			}
+			if err := m.SaveSession(); err != nil {
+				log.Printf("🚫 程序退出时保存会话状态失败: %v", err)
+			} else {
+				log.Println("💾 程序退出时会话状态已保存")
+			}
+		})
 
-	if m.context != nil {
-		m.context.Close()
-	}
-	if m.browser != nil {
-		m.browser.Close()
-	}
-	if m.pw != nil {
-		m.pw.Stop()
-	}
+		// 按创建的逆序释放：上下文（含其中的页面）→ 浏览器 → Playwright
+		var cleanup cleanupStack
+		if m.pw != nil {
+			cleanup.push(func() { m.pw.Stop() })
+		}
+		if m.browser != nil {
+			cleanup.push(func() { m.browser.Close() })
+		}
+		m.contextMutex.Lock()
+		for _, page := range m.pages {
+			m.programPages[page] = true
+		}
+		for _, context := range m.contexts {
+			context := context
+			cleanup.push(func() { context.Close() })
+		}
+		m.contextMutex.Unlock()
+		cleanup.run()
+
+		// 异常退出（Ctrl+C、崩溃）时也把状态文件标记为结束
+		m.status.Close()
+
+		// 浏览器关闭后不再使用临时文件（生成的封面、加水印或压缩后的图片等）
+		tempfiles.Cleanup()
+	})
 }
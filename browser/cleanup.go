@@ -0,0 +1,43 @@
+package browser
+
+import (
+	"log"
+	"os"
+	"runtime/debug"
+)
+
+// cleanupStack 按创建顺序的逆序释放资源，用于构造过程中出错时回滚
+type cleanupStack []func()
+
+// push 登记一个释放函数
+func (s *cleanupStack) push(release func()) {
+	*s = append(*s, release)
+}
+
+// run 逆序执行所有释放函数，单个释放函数 panic 不影响其它资源的释放
+func (s *cleanupStack) run() {
+	for i := len(*s) - 1; i >= 0; i-- {
+		runSafely((*s)[i])
+	}
+	*s = nil
+}
+
+// runSafely 执行释放函数并吞掉其中的 panic
+func runSafely(release func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("⚠️ 释放资源时发生 panic: %v", r)
+		}
+	}()
+	release()
+}
+
+// recoverPanic 捕获 panic，清理浏览器资源后退出，避免遗留浏览器进程。
+// 需要在可能 panic 的 goroutine 开头 defer 调用
+func (m *Manager) recoverPanic(where string) {
+	if r := recover(); r != nil {
+		log.Printf("💥 %s发生 panic: %v\n%s", where, r, debug.Stack())
+		m.Close()
+		os.Exit(1)
+	}
+}
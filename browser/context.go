@@ -0,0 +1,130 @@
+package browser
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/auto-blog/utils"
+	"github.com/jonfriesen/playwright-go-stealth"
+	"github.com/playwright-community/playwright-go"
+)
+
+// legacyStateFile 旧版本所有平台共用的会话状态文件
+const legacyStateFile = "state.json"
+
+// stateFileFor 返回平台独立的会话状态文件路径
+func (m *Manager) stateFileFor(platformName string) string {
+	return filepath.Join(m.userDataDir, fmt.Sprintf("state_%s.json", platformName))
+}
+
+// contextFor 获取平台专属的浏览器上下文，不存在时创建（各平台 cookie 互相隔离）
+func (m *Manager) contextFor(platformName string) (playwright.BrowserContext, error) {
+	m.contextMutex.Lock()
+	defer m.contextMutex.Unlock()
+
+	if context, ok := m.contexts[platformName]; ok {
+		return context, nil
+	}
+
+	options := m.contextOptions
+	stateFile := m.stateFileFor(platformName)
+	if usableStateFile(stateFile) {
+		options.StorageStatePath = playwright.String(stateFile)
+		log.Printf("加载 %s 已保存的会话状态", platformName)
+	} else if legacy := filepath.Join(m.userDataDir, legacyStateFile); usableStateFile(legacy) {
+		// 兼容旧版本的共享会话文件，保存时会拆分为平台独立文件
+		options.StorageStatePath = playwright.String(legacy)
+		log.Printf("加载 %s 的旧版共享会话状态", platformName)
+	} else {
+		log.Printf("%s 首次运行，创建新会话", platformName)
+	}
+
+	context, err := m.browser.NewContext(options)
+	if err != nil {
+		return nil, fmt.Errorf("创建 %s 浏览器上下文失败: %v", platformName, err)
+	}
+	m.contexts[platformName] = context
+	return context, nil
+}
+
+// pageFor 从页面池获取平台页面，已有可用页面时直接复用
+func (m *Manager) pageFor(platformName string) (playwright.Page, error) {
+	m.contextMutex.Lock()
+	page, ok := m.pages[platformName]
+	m.contextMutex.Unlock()
+	if ok && !page.IsClosed() {
+		return page, nil
+	}
+
+	context, err := m.contextFor(platformName)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err = context.NewPage()
+	if err != nil {
+		return nil, fmt.Errorf("无法为 %s 创建新页面: %v", platformName, err)
+	}
+
+	// 注入stealth脚本，防止被检测为自动化浏览器
+	if err := stealth.Inject(page); err != nil {
+		log.Printf("注入stealth脚本失败 %s: %v", platformName, err)
+	} else {
+		log.Printf("已为 %s 启用反检测模式", platformName)
+	}
+
+	m.watchPageClose(platformName, context, page)
+
+	m.contextMutex.Lock()
+	m.pages[platformName] = page
+	m.contextMutex.Unlock()
+	return page, nil
+}
+
+// saveContextState 保存单个平台上下文的会话状态，返回 cookie 数量和文件大小
+func (m *Manager) saveContextState(platformName string, context playwright.BrowserContext) (int, int, error) {
+	state, err := context.StorageState()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// 将状态序列化为JSON并保存
+	data, err := json.Marshal(state)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := utils.WriteFileAtomic(m.stateFileFor(platformName), data); err != nil {
+		return 0, 0, err
+	}
+
+	cookieCount := 0
+	if state != nil && state.Cookies != nil {
+		cookieCount = len(state.Cookies)
+	}
+	return cookieCount, len(data), nil
+}
+
+// usableStateFile 判断会话状态文件是否存在且为合法 JSON。
+// 文件损坏（如上次崩溃时只写了一半）时备份后返回 false，以全新会话启动，避免浏览器上下文无法创建
+func usableStateFile(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	if json.Valid(data) {
+		return true
+	}
+
+	backup := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+	if err := os.Rename(path, backup); err != nil {
+		log.Printf("⚠️ 会话文件 %s 已损坏且无法备份: %v", path, err)
+	} else {
+		log.Printf("⚠️ 会话文件 %s 已损坏，已备份为 %s", path, backup)
+	}
+	log.Println("🔐 将以全新会话启动，请在浏览器中重新登录")
+	return false
+}
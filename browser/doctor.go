@@ -0,0 +1,54 @@
+package browser
+
+import (
+	"sort"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+)
+
+// selectorCheckTimeout 单个选择器的等待时间，页面已加载完成，找不到即视为缺失
+const selectorCheckTimeout = 3 * time.Second
+
+// doctorKeys 自检的关键选择器，平台没有配置的键跳过
+var doctorKeys = []string{selectors.Title, selectors.Editor, selectors.ImageButton, selectors.PublishButton}
+
+// SelectorCheck 平台关键选择器的自检结果
+type SelectorCheck struct {
+	Platform    string
+	Key         string
+	Selector    string
+	Found       bool
+	EditorReady bool // 编辑器是否就绪，未就绪时通常是未登录或页面未加载完成
+}
+
+// CheckSelectors 在已打开的各平台编辑页上逐一检查关键选择器是否存在，结果按平台名排序
+func (m *Manager) CheckSelectors() []SelectorCheck {
+	names := make([]string, 0, len(m.platformPages))
+	for name := range m.platformPages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var checks []SelectorCheck
+	for _, name := range names {
+		page := m.platformPages[name]
+		ready := m.waitForPlatformEditor(name, page)
+		for _, key := range doctorKeys {
+			selector := selectors.Get(name, key)
+			if selector == "" {
+				continue
+			}
+			err := common.WaitAttached(page.Locator(selector).First(), selectorCheckTimeout)
+			checks = append(checks, SelectorCheck{
+				Platform:    name,
+				Key:         key,
+				Selector:    selector,
+				Found:       err == nil,
+				EditorReady: ready,
+			})
+		}
+	}
+	return checks
+}
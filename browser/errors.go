@@ -0,0 +1,116 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ErrorKind 发布错误类别
+type ErrorKind int
+
+const (
+	ErrorUnknown   ErrorKind = iota // 未知错误
+	ErrorNetwork                    // 网络错误：重试
+	ErrorSelector                   // 元素未找到（选择器失效）：立即报告，不重试
+	ErrorLogin                      // 登录失效：进入重新登录流程
+	ErrorRateLimit                  // 平台限流：退避等待后重试
+)
+
+// String 错误类别的中文描述
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorNetwork:
+		return "网络错误"
+	case ErrorSelector:
+		return "元素未找到"
+	case ErrorLogin:
+		return "登录失效"
+	case ErrorRateLimit:
+		return "平台限流"
+	default:
+		return "未知错误"
+	}
+}
+
+// PublishError 带分类的发布错误
+type PublishError struct {
+	Kind     ErrorKind // 错误类别
+	Platform string    // 平台名称
+	Step     string    // 出错的步骤
+	Err      error     // 原始错误
+}
+
+// Error 实现 error 接口
+func (e *PublishError) Error() string {
+	return fmt.Sprintf("[%s] %s失败（%s）: %v", e.Platform, e.Step, e.Kind, e.Err)
+}
+
+// Unwrap 返回原始错误
+func (e *PublishError) Unwrap() error {
+	return e.Err
+}
+
+// 各类错误的关键字（发布器内部错误多以 %v 包装，只能按文本识别）
+var (
+	rateLimitKeywords = []string{"too many requests", "频繁", "限流", "稍后再试", "rate limit"}
+	loginKeywords     = []string{"未登录", "登录失效", "请登录", "重新登录", "login", "unauthorized"}
+	networkKeywords   = []string{"net::", "network", "connection", "econnreset", "econnrefused", "dns", "网络", "navigating to", "navigation"}
+	selectorKeywords  = []string{"找不到", "未找到", "not found", "waiting for locator", "waiting for selector"}
+	timeoutKeywords   = []string{"timeout", "超时"}
+)
+
+// HTTP 状态码按整词匹配，避免把 "4290 字"、"id=14011" 之类的数字误判为限流或登录失效
+var (
+	rateLimitStatus = regexp.MustCompile(`\b429\b`)
+	loginStatus     = regexp.MustCompile(`\b401\b`)
+)
+
+// classifyError 根据错误内容判断错误类别
+func classifyError(platformName, step string, err error) *PublishError {
+	if err == nil {
+		return nil
+	}
+
+	var publishErr *PublishError
+	if errors.As(err, &publishErr) {
+		return publishErr
+	}
+
+	message := strings.ToLower(err.Error())
+	kind := ErrorUnknown
+	switch {
+	case rateLimitStatus.MatchString(message), containsAny(message, rateLimitKeywords):
+		kind = ErrorRateLimit
+	case loginStatus.MatchString(message), containsAny(message, loginKeywords):
+		kind = ErrorLogin
+	case containsAny(message, networkKeywords):
+		kind = ErrorNetwork
+	case containsAny(message, selectorKeywords):
+		// 等待元素超时通常意味着选择器失效
+		kind = ErrorSelector
+	case errors.Is(err, playwright.ErrTimeout), containsAny(message, timeoutKeywords):
+		// 页面加载、等待响应等其它超时多为网络慢，按网络错误重试
+		kind = ErrorNetwork
+	}
+
+	return &PublishError{
+		Kind:     kind,
+		Platform: platformName,
+		Step:     step,
+		Err:      err,
+	}
+}
+
+// containsAny 判断字符串是否包含任意关键字
+func containsAny(s string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(s, keyword) {
+			return true
+		}
+	}
+	return false
+}
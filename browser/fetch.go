@@ -0,0 +1,137 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/playwright-community/playwright-go"
+)
+
+// fetchTimeout 抓取网页时等待页面加载的超时
+const fetchTimeout = 30 * time.Second
+
+// extractMarkdownJs 提取网页正文（优先 article、main，其次文字最多的区块）并转换为 Markdown，
+// 返回标题（第一个 h1，没有时为页面标题）和正文，作为标题的 h1 不重复出现在正文中
+const extractMarkdownJs = `
+	() => {
+		const skipTags = new Set(['SCRIPT', 'STYLE', 'NOSCRIPT', 'NAV', 'FOOTER', 'ASIDE', 'HEADER', 'FORM', 'BUTTON', 'IFRAME', 'SVG']);
+		const textLength = (el) => (el.innerText || '').trim().length;
+
+		let root = document.querySelector('article') || document.querySelector('main');
+		if (!root) {
+			root = Array.from(document.querySelectorAll('div, section'))
+				.sort((a, b) => textLength(b) - textLength(a))[0] || document.body;
+		}
+
+		const titleHeading = root.querySelector('h1') || document.querySelector('h1');
+		const title = ((titleHeading && titleHeading.innerText) || document.title || '').trim();
+
+		const inline = (node) => Array.from(node.childNodes).map(convertInline).join('');
+		const convertInline = (node) => {
+			if (node.nodeType === Node.TEXT_NODE) return node.textContent.replace(/\s+/g, ' ');
+			if (node.nodeType !== Node.ELEMENT_NODE || skipTags.has(node.tagName)) return '';
+			switch (node.tagName) {
+				case 'STRONG': case 'B': return '**' + inline(node).trim() + '**';
+				case 'EM': case 'I': return '*' + inline(node).trim() + '*';
+				case 'CODE': return '` + "`" + `' + node.textContent + '` + "`" + `';
+				case 'BR': return '\n';
+				case 'A': {
+					const text = inline(node).trim();
+					return node.href && !node.href.startsWith('javascript:') ? '[' + text + '](' + node.href + ')' : text;
+				}
+				case 'IMG': {
+					const src = node.currentSrc || node.src || node.dataset.src || '';
+					return src ? '![' + (node.alt || '') + '](' + src + ')' : '';
+				}
+				default: return inline(node);
+			}
+		};
+
+		const blocks = [];
+		const convertBlock = (node, listPrefix) => {
+			if (node.nodeType === Node.TEXT_NODE) {
+				const text = node.textContent.trim();
+				if (text) blocks.push(text);
+				return;
+			}
+			if (node.nodeType !== Node.ELEMENT_NODE || skipTags.has(node.tagName) || node === titleHeading) return;
+			const tag = node.tagName;
+			if (/^H[1-6]$/.test(tag)) {
+				blocks.push('#'.repeat(Number(tag[1])) + ' ' + inline(node).trim());
+			} else if (tag === 'P') {
+				const text = inline(node).trim();
+				if (text) blocks.push(text);
+			} else if (tag === 'PRE') {
+				const code = node.querySelector('code');
+				const match = ((code && code.className) || '').match(/language-([\w+-]+)/);
+				blocks.push('` + "```" + `' + (match ? match[1] : '') + '\n' + node.textContent.replace(/\n$/, '') + '\n` + "```" + `');
+			} else if (tag === 'UL' || tag === 'OL') {
+				const items = Array.from(node.children).filter(el => el.tagName === 'LI');
+				blocks.push(items.map((li, i) => (tag === 'OL' ? (i + 1) + '. ' : '- ') + inline(li).trim()).join('\n'));
+			} else if (tag === 'BLOCKQUOTE') {
+				blocks.push(inline(node).trim().split('\n').map(line => '> ' + line.trim()).join('\n'));
+			} else if (tag === 'IMG' || tag === 'FIGURE') {
+				const text = inline(node).trim();
+				if (text) blocks.push(text);
+			} else if (tag === 'HR') {
+				blocks.push('---');
+			} else {
+				Array.from(node.childNodes).forEach(child => convertBlock(child));
+			}
+		};
+		Array.from(root.childNodes).forEach(child => convertBlock(child));
+
+		return { title: title, markdown: blocks.join('\n\n') };
+	}
+`
+
+// FetchMarkdown 用无头浏览器打开网页，提取正文并转换为 Markdown，返回标题和正文。
+// executablePath 为本机浏览器路径，为空时使用 Playwright 自带的 Chromium
+func FetchMarkdown(url, executablePath string) (string, string, error) {
+	pw, err := playwright.Run()
+	if err != nil {
+		return "", "", fmt.Errorf("启动 Playwright 失败: %v", err)
+	}
+	defer pw.Stop()
+
+	var executable *string
+	if executablePath != "" {
+		executable = playwright.String(executablePath)
+	}
+	browser, err := pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
+		ExecutablePath: executable,
+		Headless:       playwright.Bool(true),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("启动浏览器失败: %v", err)
+	}
+	defer browser.Close()
+
+	page, err := browser.NewPage()
+	if err != nil {
+		return "", "", fmt.Errorf("创建页面失败: %v", err)
+	}
+	if _, err := page.Goto(url, playwright.PageGotoOptions{
+		WaitUntil: playwright.WaitUntilStateNetworkidle,
+		Timeout:   common.TimeoutMs(fetchTimeout),
+	}); err != nil {
+		return "", "", fmt.Errorf("打开网页失败: %v", err)
+	}
+
+	result, err := page.Evaluate(extractMarkdownJs)
+	if err != nil {
+		return "", "", fmt.Errorf("提取正文失败: %v", err)
+	}
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return "", "", fmt.Errorf("提取正文返回了无法识别的结果: %v", result)
+	}
+	title, _ := resultMap["title"].(string)
+	markdown, _ := resultMap["markdown"].(string)
+	if strings.TrimSpace(markdown) == "" {
+		return "", "", fmt.Errorf("网页中没有提取到正文")
+	}
+	return strings.TrimSpace(title), markdown, nil
+}
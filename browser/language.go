@@ -0,0 +1,30 @@
+package browser
+
+import (
+	"log"
+
+	"github.com/auto-blog/article"
+	"github.com/playwright-community/playwright-go"
+)
+
+// routedPlatforms 按语言路由规则过滤出文章应发布的平台，文章语言没有配置路由时发布到所有平台
+func (m *Manager) routedPlatforms(art *article.Article, platformPages map[string]playwright.Page) map[string]playwright.Page {
+	if len(m.languageRoutes) == 0 {
+		return platformPages
+	}
+	lang := art.Language()
+	allowed, ok := m.languageRoutes[lang]
+	if !ok {
+		return platformPages
+	}
+
+	routed := make(map[string]playwright.Page)
+	for platformName, page := range platformPages {
+		if !allowed[platformName] {
+			log.Printf("⏭️ 《%s》语言为 %s，按语言路由不发布到 %s", art.Title, lang, platformName)
+			continue
+		}
+		routed[platformName] = page
+	}
+	return routed
+}
@@ -0,0 +1,27 @@
+package browser
+
+import (
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/tasklog"
+)
+
+// withResolvedLinks 将正文中指向其他本地文章的相对链接替换为该文章在平台上的已发布链接，
+// 发布历史中查不到链接时只保留链接文字，避免发布后出现死链
+func (m *Manager) withResolvedLinks(platformName string, art *article.Article, logger *tasklog.Logger) *article.Article {
+	resolvedArticle, resolved, unresolved := art.WithResolvedLinks(func(path string) string {
+		if m.history == nil {
+			return ""
+		}
+		if record := m.history.Find(path, platformName); record != nil {
+			return record.URL
+		}
+		return ""
+	})
+	if resolved > 0 {
+		logger.Printf("🔗 已将 %d 个文章内链接替换为平台链接", resolved)
+	}
+	if unresolved > 0 {
+		logger.Printf("🔗 %d 个文章内链接指向的文章尚未发布到该平台，已改为纯文本", unresolved)
+	}
+	return resolvedArticle
+}
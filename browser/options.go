@@ -0,0 +1,90 @@
+package browser
+
+import (
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/cover"
+	"github.com/auto-blog/imagehost"
+	"github.com/auto-blog/juejin"
+	"github.com/auto-blog/notify"
+	"github.com/auto-blog/platform"
+	"github.com/auto-blog/progress"
+	"github.com/auto-blog/status"
+	"github.com/auto-blog/watermark"
+	"github.com/auto-blog/zhihu"
+)
+
+// Options 浏览器上下文配置
+type Options struct {
+	UserAgent  string // User-Agent
+	Locale     string // 语言区域，如 zh-CN
+	TimezoneID string // 时区，如 Asia/Shanghai
+
+	ExecutablePath string // 本机已安装的 Chrome/Chromium 可执行文件路径，为空时使用 Playwright 下载的浏览器
+
+	Zhihu  zhihu.Options  // 知乎发布设置
+	Juejin juejin.Options // 掘金发布设置
+
+	ImageStrategies map[string]platform.ImageStrategy // 各平台的图片处理策略，未配置的平台使用剪贴板粘贴
+	Visibilities    map[string]platform.Visibility    // 各平台的文章可见范围，未配置的平台保持公开
+	ImageHost       *imagehost.Uploader               // 图床上传器（imagehost 策略使用）
+
+	Cover         *cover.Generator // 封面生成器，为 nil 时不自动生成封面（文章指定的封面仍会上传）
+	SummaryLength int              // 自动提取摘要的长度（字符数），0 使用默认值
+
+	Watermark          *watermark.Watermarker // 图片水印处理器，为 nil 时不加水印
+	WatermarkPlatforms map[string]bool        // 各平台是否加水印，未列出的平台默认加水印
+
+	Progress *progress.Bar         // 发布进度条，为 nil 时不显示
+	Report   *notify.PublishReport // 发布报告，记录各平台的发布结果，为 nil 时不记录
+	Status   *status.Writer        // 实时进度状态文件（供 auto-blog status 查看），为 nil 时不写入
+
+	PublishMode platform.PublishMode // 内容填写完成后保存草稿还是直接发布
+
+	SeriesNavigation bool // 在系列文章的正文首尾插入系列导航（上一篇/下一篇）
+
+	MaxLengths       map[string]int                     // 各平台的最大正文长度（字符数），未列出的平台不限制
+	LengthStrategies map[string]platform.LengthStrategy // 各平台正文超长时的处理策略，未列出的平台截断
+	TruncateSuffixes map[string]string                  // 各平台截断正文后追加的引流文字，{url} 替换为原文链接，未列出时使用默认文字
+
+	LanguageRoutes map[string]map[string]bool // 语言 -> 该语言文章发布的平台名称，未列出的语言发布到所有平台
+
+	// DefaultPlatforms 配置中启用的平台名称，frontmatter 未指定 platforms 的文章只发布到这些平台，
+	// 不会发布到因其它文章的 platforms 而额外打开的平台；为 nil 时不限制
+	DefaultPlatforms map[string]bool
+
+	UpdateTargets map[string]map[string]string // 平台名称 -> 文章文件名 -> 已发布文章链接，列出的文章修改原文而不是新建
+
+	MaxRestarts int // 浏览器崩溃后自动重启的次数上限，0 表示不自动恢复
+	RetryFailed int // 所有文章发布完后重试失败平台的轮数，0 表示不重试
+
+	TimeoutMultiplier float64 // 页面元素等待超时的全局倍率，慢机器或慢网络调大
+	UploadConcurrency int     // 上传控件方式同时上传的图片数量，剪贴板方式始终逐张粘贴
+
+	ActionDelay time.Duration // 点击、输入、打开页面等操作前的平均随机停顿（拟人化节流），0 表示关闭
+
+	PublishInterval  time.Duration            // 同一平台两次发布之间的最小间隔，0 表示不限制
+	PublishIntervals map[string]time.Duration // 各平台单独配置的最小发布间隔，未列出的平台使用 PublishInterval
+	PublishJitter    time.Duration            // 发布间隔的随机抖动上限，让发布节奏更接近人工操作
+}
+
+// DefaultOptions 返回默认的浏览器配置
+func DefaultOptions() Options {
+	return Options{
+		UserAgent:  "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.234 Safari/537.36",
+		Locale:     "zh-CN",
+		TimezoneID: "Asia/Shanghai",
+
+		PublishMode: platform.PublishModeDraft,
+		MaxRestarts: 3,
+
+		TimeoutMultiplier: 1,
+		UploadConcurrency: 1,
+
+		ActionDelay: common.DefaultActionDelay,
+
+		PublishInterval: 30 * time.Second,
+		PublishJitter:   10 * time.Second,
+	}
+}
@@ -0,0 +1,164 @@
+package browser
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/playwright-community/playwright-go"
+)
+
+// restartDelay 重启浏览器失败后再次尝试前的等待时间
+const restartDelay = 5 * time.Second
+
+// userCloseWindow 用户关闭页面后这段时间内浏览器断开连接，视为用户关闭了浏览器而不是崩溃
+const userCloseWindow = 5 * time.Second
+
+// publishedKey 本次运行已发布记录的键
+func publishedKey(article *article.Article, platformName, contentHash string) string {
+	return article.Path + "|" + platformName + "|" + contentHash
+}
+
+// watchDisconnect 监听浏览器断开连接事件：程序主动关闭时忽略，用户关闭浏览器时退出程序，
+// 否则视为浏览器崩溃，在次数上限内自动重启
+func (m *Manager) watchDisconnect(browser playwright.Browser) {
+	browser.On("disconnected", func() {
+		m.contextMutex.Lock()
+		current := m.browser == browser
+		m.contextMutex.Unlock()
+		// 重启后旧浏览器的事件不再处理
+		if m.closing.Load() || !current {
+			return
+		}
+
+		if m.closedByUser() {
+			// 会话状态已在页面关闭时保存，浏览器断开后无法再读取
+			log.Println("👋 浏览器已被关闭，程序退出")
+			m.Close()
+			os.Exit(0)
+		}
+
+		log.Println("🔴 检测到浏览器意外断开连接")
+		if m.maxRestarts <= 0 {
+			log.Println("❌ 未开启浏览器自动恢复（[browser] max_restarts），程序退出")
+			m.Close()
+			os.Exit(1)
+		}
+
+		m.crashed.Store(true)
+		// 事件回调中不能阻塞 Playwright 的消息处理，在新的 goroutine 中恢复
+		go m.recoverFromCrash()
+	})
+}
+
+// recoverFromCrash 重启浏览器、加载已保存的会话、重新打开崩溃前的平台页面，
+// 然后从崩溃时的文章继续发布，只发布尚未完成的平台。重启次数超过上限时报错退出
+func (m *Manager) recoverFromCrash() {
+	defer m.recoverPanic("浏览器崩溃恢复")
+
+	// 等待崩溃前的发布流程退出，避免与其同时操作页面
+	m.publishMutex.Lock()
+	defer m.publishMutex.Unlock()
+
+	for {
+		if m.closing.Load() {
+			return
+		}
+		if m.restarts >= m.maxRestarts {
+			log.Printf("❌ 浏览器已自动重启 %d 次，仍然崩溃，程序退出", m.restarts)
+			m.Close()
+			os.Exit(1)
+		}
+		m.restarts++
+
+		log.Printf("🔄 正在重启浏览器（第 %d/%d 次）", m.restarts, m.maxRestarts)
+		if err := m.restartBrowser(); err != nil {
+			log.Printf("⚠️ %v，%v 后重试", err, restartDelay)
+			time.Sleep(restartDelay)
+			continue
+		}
+		break
+	}
+	m.crashed.Store(false)
+
+	platformPages := m.openAll(m.platformURLs)
+	m.platformPages = platformPages
+	log.Printf("✅ 浏览器已恢复，重新打开了 %d 个平台", len(platformPages))
+
+	if m.interrupted {
+		log.Println("▶️ 继续发布未完成的文章")
+		m.publishRemaining(platformPages)
+	}
+}
+
+// watchPageClose 监听平台页面被关闭：不是程序关闭或重新打开的页面、且浏览器仍然连接时，说明是用户关闭了窗口，
+// 记录关闭时间供断开连接时区分用户关闭与崩溃，并趁上下文还可用时保存该平台的会话状态
+func (m *Manager) watchPageClose(platformName string, context playwright.BrowserContext, page playwright.Page) {
+	page.On("close", func() {
+		if m.closing.Load() || m.isProgramPage(page) || !context.Browser().IsConnected() {
+			return
+		}
+		m.userClosedAt.Store(time.Now().UnixNano())
+		// 事件回调中不能阻塞 Playwright 的消息处理
+		go func() {
+			if _, _, err := m.saveContextState(platformName, context); err != nil {
+				log.Printf("⚠️ 页面关闭时保存 %s 会话状态失败: %v", platformName, err)
+			}
+		}()
+	})
+}
+
+// markProgramPage 标记程序即将关闭或重新打开的页面，期间触发的关闭事件不算作用户关闭
+func (m *Manager) markProgramPage(page playwright.Page) {
+	m.contextMutex.Lock()
+	m.programPages[page] = true
+	m.contextMutex.Unlock()
+}
+
+// unmarkProgramPage 程序重新打开页面后取消标记，之后页面再被关闭时视为用户关闭
+func (m *Manager) unmarkProgramPage(page playwright.Page) {
+	m.contextMutex.Lock()
+	delete(m.programPages, page)
+	m.contextMutex.Unlock()
+}
+
+// isProgramPage 页面是否正在被程序关闭或重新打开
+func (m *Manager) isProgramPage(page playwright.Page) bool {
+	m.contextMutex.Lock()
+	defer m.contextMutex.Unlock()
+	return m.programPages[page]
+}
+
+// closedByUser 浏览器断开前不久有页面被用户关闭时，认为是用户关闭了浏览器
+func (m *Manager) closedByUser() bool {
+	closedAt := m.userClosedAt.Load()
+	return closedAt != 0 && time.Since(time.Unix(0, closedAt)) < userCloseWindow
+}
+
+// restartBrowser 启动新的浏览器并清空上下文和页面池，
+// 各平台的上下文在重新打开页面时按需创建并加载已保存的会话状态
+func (m *Manager) restartBrowser() error {
+	browser, err := launchBrowser(m.pw, m.executablePath)
+	if err != nil {
+		return fmt.Errorf("重启浏览器失败: %v", err)
+	}
+
+	m.contextMutex.Lock()
+	previous := m.browser
+	m.browser = browser
+	m.contexts = make(map[string]playwright.BrowserContext)
+	// 旧浏览器的页面随之由程序关闭，只保留它们的标记
+	m.programPages = make(map[playwright.Page]bool, len(m.pages))
+	for _, page := range m.pages {
+		m.programPages[page] = true
+	}
+	m.pages = make(map[string]playwright.Page)
+	m.contextMutex.Unlock()
+
+	m.watchDisconnect(browser)
+	// 释放崩溃的浏览器残留的连接
+	runSafely(func() { previous.Close() })
+	return nil
+}
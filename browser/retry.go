@@ -0,0 +1,49 @@
+package browser
+
+import (
+	"log"
+
+	"github.com/auto-blog/article"
+	"github.com/playwright-community/playwright-go"
+)
+
+// retryFailedPlatforms 所有文章发布完后，按 retry_failed 配置的轮数重新发布失败的平台：
+// 重新打开编辑器、等待编辑器就绪、从头填写内容。每轮的结果覆盖发布报告中之前的失败记录。
+// 轮次记录在 retryRound 中，浏览器崩溃恢复后从中断的那一轮继续
+func (m *Manager) retryFailedPlatforms(platformPages map[string]playwright.Page) {
+	for ; m.retryRound <= m.retryFailed; m.retryRound++ {
+		retried := false
+		for _, art := range m.articles {
+			if m.crashed.Load() {
+				return
+			}
+			failed := m.failedPlatforms(art, platformPages)
+			if len(failed) == 0 {
+				continue
+			}
+			retried = true
+			log.Printf("🔁 [重试 %d/%d] 《%s》在 %d 个平台发布失败，重新发布", m.retryRound, m.retryFailed, art.Title, len(failed))
+			m.reopenPlatformPages(failed)
+			m.publishArticle(art, failed)
+		}
+		if !retried {
+			return
+		}
+	}
+}
+
+// failedPlatforms 返回文章本次运行中未发布成功、也没有历史发布记录的平台（页面未能打开的平台、文章不发布的平台除外）
+func (m *Manager) failedPlatforms(art *article.Article, platformPages map[string]playwright.Page) map[string]playwright.Page {
+	contentHash := art.ContentHash()
+	failed := make(map[string]playwright.Page)
+	for platformName, page := range m.targetPlatforms(art, platformPages) {
+		if page == nil || m.published[publishedKey(art, platformName, contentHash)] {
+			continue
+		}
+		if m.history != nil && m.history.IsCompleted(art.Path, platformName, contentHash) {
+			continue
+		}
+		failed[platformName] = page
+	}
+	return failed
+}
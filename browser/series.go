@@ -0,0 +1,26 @@
+package browser
+
+import (
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/tasklog"
+)
+
+// withSeriesNavigation 开启系列导航时为系列文章插入导航，其它篇目在该平台的发布历史中有链接时附上链接
+func (m *Manager) withSeriesNavigation(platformName string, art *article.Article, logger *tasklog.Logger) *article.Article {
+	if !m.seriesNav || art.Series == nil {
+		return art
+	}
+	navigated := art.WithSeriesNavigation(func(member *article.Article) string {
+		if m.history == nil {
+			return ""
+		}
+		if record := m.history.Find(member.Path, platformName); record != nil {
+			return record.URL
+		}
+		return ""
+	})
+	if navigated != art {
+		logger.Printf("📚 已插入「%s」系列导航", art.Series.Name)
+	}
+	return navigated
+}
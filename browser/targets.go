@@ -0,0 +1,40 @@
+package browser
+
+import (
+	"log"
+
+	"github.com/auto-blog/article"
+	"github.com/playwright-community/playwright-go"
+)
+
+// targetPlatforms 过滤出文章要发布的平台：frontmatter 指定了 platforms 时只发布到这些平台（优先于语言路由），
+// 否则只发布到配置中启用的平台，并按语言路由规则过滤
+func (m *Manager) targetPlatforms(art *article.Article, platformPages map[string]playwright.Page) map[string]playwright.Page {
+	if len(art.Meta.Platforms) == 0 {
+		return m.routedPlatforms(art, m.defaultTargets(platformPages))
+	}
+
+	targets := make(map[string]playwright.Page)
+	for platformName, page := range platformPages {
+		if !art.PublishesTo(platformIDs[platformName], platformName) {
+			log.Printf("⏭️ 《%s》的 platforms 未包含 %s，跳过", art.Title, platformName)
+			continue
+		}
+		targets[platformName] = page
+	}
+	return targets
+}
+
+// defaultTargets 未指定 platforms 的文章发布的平台：排除因其它文章的 platforms 而额外打开的平台
+func (m *Manager) defaultTargets(platformPages map[string]playwright.Page) map[string]playwright.Page {
+	if m.configPlatforms == nil {
+		return platformPages
+	}
+	targets := make(map[string]playwright.Page, len(platformPages))
+	for platformName, page := range platformPages {
+		if m.configPlatforms[platformName] {
+			targets[platformName] = page
+		}
+	}
+	return targets
+}
@@ -0,0 +1,83 @@
+package browser
+
+import (
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// maxThrottleLevel 连续触发平台限流时，发布间隔最多放大到 2^maxThrottleLevel 倍
+const maxThrottleLevel = 4
+
+// throttle 按平台限制发布频率：同一平台两次发布之间至少间隔 interval，并加上随机抖动；
+// 触发平台限流后间隔翻倍，之后每次成功发布减半，直到恢复为配置的间隔
+type throttle struct {
+	interval  time.Duration            // 默认最小发布间隔
+	intervals map[string]time.Duration // 各平台单独配置的最小发布间隔
+	jitter    time.Duration            // 随机抖动的上限
+	mutex     sync.Mutex
+	last      map[string]time.Time // 各平台上次发布完成的时间
+	level     map[string]int       // 各平台的限流退避级别
+}
+
+// newThrottle 创建发布限流器
+func newThrottle(options Options) *throttle {
+	return &throttle{
+		interval:  options.PublishInterval,
+		intervals: options.PublishIntervals,
+		jitter:    options.PublishJitter,
+		last:      make(map[string]time.Time),
+		level:     make(map[string]int),
+	}
+}
+
+// gap 平台当前的最小发布间隔（调用方持有锁）
+func (t *throttle) gap(platformName string) time.Duration {
+	interval, ok := t.intervals[platformName]
+	if !ok {
+		interval = t.interval
+	}
+	return interval * time.Duration(1<<t.level[platformName])
+}
+
+// wait 等待到平台允许再次发布的时间，各平台互不影响
+func (t *throttle) wait(platformName string) {
+	t.mutex.Lock()
+	last, ok := t.last[platformName]
+	gap := t.gap(platformName)
+	t.mutex.Unlock()
+
+	if !ok || gap <= 0 {
+		return
+	}
+	delay := time.Until(last.Add(gap))
+	if delay <= 0 {
+		return
+	}
+	if t.jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(t.jitter)))
+	}
+	log.Printf("⏳ [%s] 距上次发布不足 %v，等待 %v 后继续", platformName, gap, delay.Round(time.Second))
+	time.Sleep(delay)
+}
+
+// done 记录平台完成一次发布，成功时逐步恢复被限流放大的间隔
+func (t *throttle) done(platformName string, success bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.last[platformName] = time.Now()
+	if success && t.level[platformName] > 0 {
+		t.level[platformName]--
+	}
+}
+
+// backoff 平台提示发布过于频繁，放大之后的发布间隔
+func (t *throttle) backoff(platformName string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.level[platformName] < maxThrottleLevel {
+		t.level[platformName]++
+	}
+	log.Printf("🐢 [%s] 触发平台限流，之后的发布间隔延长为 %v", platformName, t.gap(platformName))
+}
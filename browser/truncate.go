@@ -0,0 +1,61 @@
+package browser
+
+import (
+	"log"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/platform"
+	"github.com/auto-blog/tasklog"
+)
+
+// overLength 平台配置了最大正文长度且正文超长时返回正文字数、上限和处理策略
+func (m *Manager) overLength(platformName string, art *article.Article) (int, int, platform.LengthStrategy, bool) {
+	maxLength := m.maxLengths[platformName]
+	if maxLength <= 0 {
+		return 0, 0, "", false
+	}
+	length := art.Length()
+	if length <= maxLength {
+		return 0, 0, "", false
+	}
+	strategy, ok := m.lengthStrategy[platformName]
+	if !ok {
+		strategy = platform.LengthStrategyTruncate
+	}
+	return length, maxLength, strategy, true
+}
+
+// lengthBlocked 判断正文是否超过平台字数上限且策略为阻止发布
+func (m *Manager) lengthBlocked(platformName string, art *article.Article) bool {
+	length, maxLength, strategy, over := m.overLength(platformName, art)
+	if !over || strategy != platform.LengthStrategyBlock {
+		return false
+	}
+	log.Printf("⛔ [%s] 《%s》正文 %d 字，超过平台上限 %d 字，已阻止发布", platformName, art.Title, length, maxLength)
+	return true
+}
+
+// truncateFor 平台配置了最大正文长度且正文超长时按策略处理：截断策略在句子边界截断正文并追加引流文字，
+// 警告策略只给出警告
+func (m *Manager) truncateFor(platformName string, art *article.Article, logger *tasklog.Logger) *article.Article {
+	length, maxLength, strategy, over := m.overLength(platformName, art)
+	if !over {
+		return art
+	}
+	if strategy == platform.LengthStrategyWarn {
+		logger.Printf("⚠️ 正文 %d 字，超过平台上限 %d 字，提交可能失败", length, maxLength)
+		return art
+	}
+	truncated, _ := art.Truncated(maxLength, m.truncateSuffix[platformName])
+	logger.Printf("✂️ 正文 %d 字，超过 %d 字，已截断并追加引流文字（保留 %d/%d 张图片）", length, maxLength, len(truncated.Images), len(art.Images))
+	return truncated
+}
+
+// imageCountFor 平台正文中的图片数量，正文被截断时只包含截断位置之前的图片
+func (m *Manager) imageCountFor(platformName string, art *article.Article) int {
+	if _, _, strategy, over := m.overLength(platformName, art); !over || strategy != platform.LengthStrategyTruncate {
+		return len(art.Images)
+	}
+	truncated, _ := art.Truncated(m.maxLengths[platformName], m.truncateSuffix[platformName])
+	return len(truncated.Images)
+}
@@ -0,0 +1,50 @@
+package browser
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/platform"
+	"github.com/playwright-community/playwright-go"
+)
+
+// updateURL 返回文章在平台上要修改的已发布文章链接，没有指定更新目标时返回空字符串（照常新建文章）
+func (m *Manager) updateURL(platformName string, art *article.Article) string {
+	return m.updateTargets[platformName][filepath.Base(art.Path)]
+}
+
+// openForUpdate 在平台页面中打开已发布文章的编辑页并清空原内容，之后按正常流程填写新内容
+func (m *Manager) openForUpdate(platformName string, page playwright.Page, art *article.Article) error {
+	publisher, ok := m.platformManager.NewPublisher(platformName, page)
+	if !ok {
+		return fmt.Errorf("暂不支持的平台")
+	}
+	updater, ok := publisher.(platform.Updater)
+	if !ok {
+		return fmt.Errorf("暂不支持修改已发布文章")
+	}
+	url := m.updateURL(platformName, art)
+	log.Printf("✏️ [%s] 修改已发布的文章《%s》: %s", platformName, art.Title, url)
+	if err := updater.OpenForUpdate(url); err != nil {
+		return fmt.Errorf("打开文章编辑页失败: %v", err)
+	}
+	return nil
+}
+
+// submitUpdate 保存对已发布文章的修改，返回文章链接（平台未返回时沿用更新目标的链接）
+func (m *Manager) submitUpdate(platformName string, publisher platform.Publisher, art *article.Article) (string, error) {
+	updater, ok := publisher.(platform.Updater)
+	if !ok {
+		return "", fmt.Errorf("暂不支持修改已发布文章")
+	}
+	url, err := updater.SubmitUpdate()
+	if err != nil {
+		return "", err
+	}
+	if url == "" {
+		url = m.updateURL(platformName, art)
+	}
+	return url, nil
+}
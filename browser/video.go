@@ -0,0 +1,29 @@
+package browser
+
+import (
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/platform"
+	"github.com/auto-blog/tasklog"
+)
+
+// withVideos 把正文中的视频转为平台的嵌入代码，平台不支持嵌入该视频时转为带缩略图的链接
+func (m *Manager) withVideos(publisher platform.Publisher, art *article.Article, logger *tasklog.Logger) *article.Article {
+	videos := art.Videos()
+	if len(videos) == 0 {
+		return art
+	}
+
+	embedder, _ := publisher.(platform.VideoEmbedder)
+	embedded := 0
+	converted := art.WithVideos(func(video article.Video) string {
+		if embedder != nil {
+			if code := embedder.VideoEmbedCode(video); code != "" {
+				embedded++
+				return code
+			}
+		}
+		return video.LinkMarkdown()
+	})
+	logger.Printf("🎬 正文中有 %d 个视频：%d 个嵌入播放器，%d 个转为链接", len(videos), embedded, len(videos)-embedded)
+	return converted
+}
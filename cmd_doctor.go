@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/auto-blog/browser"
+	"github.com/auto-blog/installer"
+	"github.com/auto-blog/selectors"
+	"github.com/auto-blog/session"
+)
+
+// selectorLabels 自检报告中选择器键名的中文说明
+var selectorLabels = map[string]string{
+	selectors.Title:         "标题框",
+	selectors.Editor:        "编辑器",
+	selectors.ImageButton:   "图片按钮",
+	selectors.PublishButton: "发布按钮",
+}
+
+// runDoctor 打开启用的平台编辑页，检查关键选择器是否仍然有效，在真正发布前发现平台改版导致的选择器失效
+func runDoctor(args []string) {
+	flags, configPath := newFlagSet("doctor")
+	flags.Parse(args)
+
+	cfg := mustLoadConfig(*configPath)
+
+	enabledPlatforms := cfg.GetEnabledPlatforms()
+	if len(enabledPlatforms) == 0 {
+		log.Println("没有启用任何平台")
+		return
+	}
+	loadSelectorOverrides(cfg)
+
+	if err := installer.EnsurePlaywrightInstalled(cfg.GetInstallerOptions()); err != nil {
+		log.Fatalf("安装 Playwright 失败: %v", err)
+	}
+
+	sessionManager, err := session.NewManager()
+	if err != nil {
+		log.Fatalf("无法创建会话管理器: %v", err)
+	}
+
+	browserManager, err := browser.NewManager(sessionManager.GetUserDataDir(), nil, cfg.GetBrowserOptions())
+	if err != nil {
+		log.Fatalf("无法创建浏览器管理器: %v", err)
+	}
+
+	browserManager.OpenPlatforms(enabledPlatforms)
+	browserManager.CheckLogins()
+	log.Println("🩺 正在检查各平台的关键选择器...")
+	checks := browserManager.CheckSelectors()
+	browserManager.Close()
+
+	if missing := printDoctorReport(enabledPlatforms, checks); missing > 0 {
+		os.Exit(1)
+	}
+}
+
+// printDoctorReport 按平台输出每个选择器找到/缺失的报告，返回缺失的数量（无法打开的平台计为缺失）
+func printDoctorReport(enabledPlatforms map[string]string, checks []browser.SelectorCheck) int {
+	byPlatform := make(map[string][]browser.SelectorCheck)
+	for _, check := range checks {
+		byPlatform[check.Platform] = append(byPlatform[check.Platform], check)
+	}
+	names := make([]string, 0, len(enabledPlatforms))
+	for name := range enabledPlatforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	missing := 0
+	for _, name := range names {
+		fmt.Println(name)
+		platformChecks, ok := byPlatform[name]
+		if !ok {
+			fmt.Println("  ❌ 无法打开编辑页")
+			missing++
+			continue
+		}
+		for _, check := range platformChecks {
+			label := selectorLabels[check.Key]
+			padding := strings.Repeat(" ", 8-displayWidth(label))
+			if check.Found {
+				fmt.Printf("  ✅ %s%s找到    %s\n", label, padding, check.Selector)
+			} else {
+				fmt.Printf("  ❌ %s%s缺失    %s\n", label, padding, check.Selector)
+				missing++
+			}
+		}
+		if !platformChecks[0].EditorReady {
+			fmt.Println("  ⚠️ 编辑器未就绪，可能尚未登录，请先运行 auto-blog login 后重新检查")
+		}
+	}
+
+	fmt.Println()
+	if missing == 0 {
+		fmt.Printf("✅ 共检查 %d 个选择器，全部找到\n", len(checks))
+		return 0
+	}
+	fmt.Printf("❌ 共检查 %d 个选择器，%d 项缺失；平台改版后可在 [selectors] file 指定的 JSON 文件中覆盖失效的选择器\n", len(checks), missing)
+	return missing
+}
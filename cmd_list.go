@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/staticsite"
+)
+
+// runList 列出解析到的文章及各平台的发布状态
+func runList(args []string) {
+	flags, configPath := newFlagSet("list")
+	asJSON := flags.Bool("json", false, "以 JSON 格式输出解析后的全部文章（含正文和图片的路径、行索引），便于其他工具使用或检查解析结果")
+	filter := addFilterFlags(flags)
+	flags.Parse(args)
+
+	cfg := mustLoadConfig(*configPath)
+
+	parser := newParser(cfg)
+	parser.SetFilter(filter())
+	articles, err := loadArticles(cfg, parser)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *asJSON {
+		printArticlesJSON(articles)
+		return
+	}
+	if len(articles) == 0 {
+		fmt.Println(emptyArticlesHint)
+		return
+	}
+
+	platformNames := make([]string, 0)
+	for name := range cfg.GetEnabledPlatforms() {
+		platformNames = append(platformNames, name)
+	}
+	if _, enabled := cfg.GetStaticSiteOptions(); enabled {
+		platformNames = append(platformNames, staticsite.Name)
+	}
+	sort.Strings(platformNames)
+	publishHistory := loadHistory()
+
+	for i, art := range articles {
+		fmt.Printf("%d. %s\n", i+1, art.Title)
+		fmt.Printf("   文件: %s (%d行, %d张图片)\n", art.Path, art.GetContentLineCount(), len(art.Images))
+		if publishAt := art.Meta.PublishAt; publishAt != "" {
+			fmt.Printf("   定时发布: %s\n", publishAt)
+		}
+		if len(art.Meta.Platforms) > 0 {
+			fmt.Printf("   指定平台: %s\n", strings.Join(art.Meta.Platforms, ", "))
+		}
+		if len(art.Meta.Tags) > 0 {
+			fmt.Printf("   标签: %s\n", strings.Join(art.Meta.Tags, ", "))
+		}
+		if publishHistory == nil || len(platformNames) == 0 {
+			continue
+		}
+
+		states := make([]string, 0, len(platformNames))
+		for _, name := range articlePlatformNames(art, platformNames, platformNames) {
+			record := publishHistory.Find(art.Path, name)
+			switch {
+			case record == nil:
+				states = append(states, name+": 未发布")
+			case record.ContentHash != art.ContentHash():
+				states = append(states, name+": 已修改")
+			default:
+				states = append(states, name+": 已发布")
+			}
+		}
+		fmt.Printf("   状态: %s\n", strings.Join(states, ", "))
+	}
+
+	fmt.Println()
+	printStatsTable(articles)
+}
+
+// printStatsTable 以表格形式输出各篇文章的结构统计，帮助发布前评估文章结构
+func printStatsTable(articles []*article.Article) {
+	rows := [][]string{{"#", "图片", "代码块", "外链", "最长段落", "标题层级", "标题"}}
+	for i, art := range articles {
+		stats := art.Stats()
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			strconv.Itoa(stats.Images),
+			strconv.Itoa(stats.CodeBlocks),
+			strconv.Itoa(stats.ExternalLinks),
+			strconv.Itoa(stats.LongestParagraph),
+			headingLevels(stats.Headings),
+			art.Title,
+		})
+	}
+
+	// 标题放在最后一列不补齐，其余列按显示宽度（中文占两格）对齐
+	widths := make([]int, len(rows[0])-1)
+	for _, row := range rows {
+		for j := range widths {
+			if width := displayWidth(row[j]); width > widths[j] {
+				widths[j] = width
+			}
+		}
+	}
+	for _, row := range rows {
+		var line strings.Builder
+		for j, cell := range row {
+			line.WriteString(cell)
+			if j < len(widths) {
+				line.WriteString(strings.Repeat(" ", widths[j]-displayWidth(cell)+2))
+			}
+		}
+		fmt.Println(line.String())
+	}
+}
+
+// headingLevels 标题层级分布，如 "h2:3 h3:5"，没有标题时为 "-"
+func headingLevels(headings [6]int) string {
+	parts := make([]string, 0, len(headings))
+	for level, count := range headings {
+		if count > 0 {
+			parts = append(parts, fmt.Sprintf("h%d:%d", level+1, count))
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+// displayWidth 字符串在终端中的显示宽度，中日韩文字和全角符号按两格计算
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if r >= 0x2E80 {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// printArticlesJSON 将解析后的文章以 JSON 数组输出到标准输出（日志输出到标准错误，不影响管道处理）
+func printArticlesJSON(articles []*article.Article) {
+	if articles == nil {
+		articles = []*article.Article{}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(articles); err != nil {
+		log.Fatalf("导出 JSON 失败: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"log"
+
+	"github.com/auto-blog/browser"
+	"github.com/auto-blog/installer"
+	"github.com/auto-blog/session"
+)
+
+// runLogin 打开启用的平台但不发布文章，用于首次登录或会话过期后重新登录
+func runLogin(args []string) {
+	flags, configPath := newFlagSet("login")
+	flags.Parse(args)
+
+	cfg := mustLoadConfig(*configPath)
+
+	enabledPlatforms := cfg.GetEnabledPlatforms()
+	if len(enabledPlatforms) == 0 {
+		log.Println("没有启用任何平台")
+		return
+	}
+
+	if err := installer.EnsurePlaywrightInstalled(cfg.GetInstallerOptions()); err != nil {
+		log.Fatalf("安装 Playwright 失败: %v", err)
+	}
+
+	sessionManager, err := session.NewManager()
+	if err != nil {
+		log.Fatalf("无法创建会话管理器: %v", err)
+	}
+
+	// 不传入文章，登录成功后只保存会话
+	browserManager, err := browser.NewManager(sessionManager.GetUserDataDir(), nil, cfg.GetBrowserOptions())
+	if err != nil {
+		log.Fatalf("无法创建浏览器管理器: %v", err)
+	}
+	defer browserManager.Close()
+
+	browserManager.OpenPlatforms(enabledPlatforms)
+	browserManager.CheckLogins()
+	log.Println("🔐 请在浏览器中完成各平台登录，登录成功后会话会自动保存")
+
+	browserManager.WaitForExit()
+}
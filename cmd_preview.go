@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/utils"
+)
+
+// previewPage 预览页面模板，%s 依次为标题、标题和正文 HTML
+const previewPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>%s - 预览</title>
+<style>
+	body { max-width: 760px; margin: 40px auto; padding: 0 20px; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif; font-size: 16px; line-height: 1.75; color: #222; }
+	pre { background: #f6f8fa; padding: 12px 16px; overflow-x: auto; border-radius: 4px; }
+	code { font-family: Menlo, Consolas, monospace; font-size: 14px; }
+	blockquote { margin: 16px 0; padding: 4px 16px; border-left: 4px solid #ddd; color: #555; }
+	img { max-width: 100%%; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+%s
+</body>
+</html>
+`
+
+// previewArticles 把文章渲染为 HTML（图片内嵌）写入临时目录，并在系统默认浏览器中打开
+func previewArticles(articles []*article.Article) {
+	if len(articles) == 0 {
+		log.Println("没有需要预览的文章")
+		return
+	}
+
+	dir := filepath.Join(os.TempDir(), "auto-blog-preview")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatalf("创建预览目录失败: %v", err)
+	}
+
+	for _, art := range articles {
+		path, err := writePreview(dir, art)
+		if err != nil {
+			log.Printf("❌ 《%s》生成预览失败: %v", art.Title, err)
+			continue
+		}
+		log.Printf("👀 《%s》预览已生成: %s", art.Title, path)
+		if err := utils.OpenInBrowser(path); err != nil {
+			log.Printf("⚠️ %v，请手动打开 %s", err, path)
+		}
+	}
+}
+
+// writePreview 渲染单篇文章的预览页面，文件名使用文章文件名
+func writePreview(dir string, art *article.Article) (string, error) {
+	// 预览中的视频显示为链接写法（与不支持嵌入视频的平台一致）
+	body, err := common.RenderHTML(art.WithVideos(article.Video.LinkMarkdown))
+	if err != nil {
+		return "", err
+	}
+
+	base := filepath.Base(art.Path)
+	path := filepath.Join(dir, strings.TrimSuffix(base, filepath.Ext(base))+".html")
+	title := html.EscapeString(art.Title)
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(previewPage, title, title, body)), 0644); err != nil {
+		return "", fmt.Errorf("写入预览文件失败: %v", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,753 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/browser"
+	"github.com/auto-blog/config"
+	"github.com/auto-blog/cover"
+	"github.com/auto-blog/history"
+	"github.com/auto-blog/hooks"
+	"github.com/auto-blog/imagehost"
+	"github.com/auto-blog/installer"
+	"github.com/auto-blog/notify"
+	"github.com/auto-blog/progress"
+	"github.com/auto-blog/sensitive"
+	"github.com/auto-blog/session"
+	"github.com/auto-blog/staticsite"
+	"github.com/auto-blog/status"
+	"github.com/auto-blog/tasklog"
+	"github.com/auto-blog/tempfiles"
+	"github.com/auto-blog/utils"
+	"github.com/auto-blog/watcher"
+	"github.com/auto-blog/watermark"
+)
+
+// publishOptions 发布流程的选项
+type publishOptions struct {
+	configPath   string
+	watch        bool
+	noProgress   bool
+	sinceLastRun bool
+	preview      bool
+	// articles 直接发布的文章（quick 命令即时构造），为 nil 时解析 articles 目录
+	articles []*article.Article
+	// taskPath 任务文件（--task），设置后只发布任务文件中列出的文章，并按其中的设置覆盖 frontmatter
+	taskPath string
+	// filter 按草稿状态和标签筛选 articles 目录或任务文件中的文章（--filter-tag、--exclude-tag），默认跳过草稿
+	filter article.Filter
+	// updateTargets 要修改的已发布文章（update 命令指定）：平台名称 -> 文章文件名 -> 链接，
+	// 不为 nil 时只在这些平台上更新文章，不新建、不发布静态博客
+	updateTargets map[string]map[string]string
+}
+
+// coversArticlesDir 本次是否发布了 articles 目录中的全部文章（草稿除外），只有这时才记录增量发布的时间
+func (o publishOptions) coversArticlesDir() bool {
+	return o.articles == nil && o.taskPath == "" && len(o.filter.IncludeTags) == 0 && len(o.filter.ExcludeTags) == 0
+}
+
+// runPublish 发布文章，--watch 时持续监听 articles 目录
+func runPublish(args []string) {
+	flags, configPath := newFlagSet("publish")
+	watch := flags.Bool("watch", false, "监听 articles 目录，文章新增或修改后自动发布")
+	noProgress := flags.Bool("no-progress", false, "不显示发布进度条")
+	sinceLastRun := flags.Bool("since-last-run", false, "只发布上次运行以来新增或修改过的文章（按文件修改时间，首次运行发布全部）")
+	preview := flags.Bool("preview", false, "把文章渲染为 HTML（图片内嵌）并在默认浏览器中打开预览，不启动自动化流程也不发布")
+	filter := addFilterFlags(flags)
+	taskPath := flags.String("task", "", "任务文件（JSON 或 YAML），只发布其中列出的文章，每篇可单独指定平台、标题、标签和发布时间")
+	flags.Parse(args)
+
+	if *taskPath != "" && (*watch || *sinceLastRun) {
+		log.Fatalf("--task 不能与 --watch、--since-last-run 同时使用")
+	}
+
+	publish(publishOptions{
+		configPath:   *configPath,
+		watch:        *watch,
+		noProgress:   *noProgress,
+		sinceLastRun: *sinceLastRun,
+		preview:      *preview,
+		taskPath:     *taskPath,
+		filter:       filter(),
+	})
+}
+
+// publish 按配置预处理文章并发布到启用的平台
+func publish(options publishOptions) {
+	cfg := mustLoadConfig(options.configPath)
+	var err error
+
+	// 本次运行生成的临时文件在退出时统一清理（浏览器关闭时也会清理，覆盖 Ctrl+C 等异常退出）
+	tempfiles.SetKeep(cfg.KeepTempFiles())
+	defer tempfiles.Cleanup()
+
+	// 获取启用的平台
+	enabledPlatforms := cfg.GetEnabledPlatforms()
+	if options.updateTargets != nil {
+		enabledPlatforms = updatePlatforms(enabledPlatforms, options.updateTargets)
+	}
+
+	// 静态博客发布器（不需要浏览器）
+	var staticPublisher *staticsite.Publisher
+	if staticOptions, enabled := cfg.GetStaticSiteOptions(); enabled && options.updateTargets == nil {
+		staticPublisher, err = staticsite.NewPublisher(staticOptions)
+		if err != nil {
+			log.Fatalf("无法创建静态博客发布器: %v", err)
+		}
+	}
+
+	if len(enabledPlatforms) == 0 && staticPublisher == nil && !options.preview {
+		log.Println("没有启用任何平台")
+		return
+	}
+
+	log.Printf("启用的平台: %d个", len(enabledPlatforms))
+
+	// 按平台分文件输出日志，方便单独查看并行发布中某个平台的过程
+	var logRouter *tasklog.Router
+	if logDir := cfg.GetPlatformLogDir(); logDir != "" {
+		names := make([]string, 0, len(enabledPlatforms)+1)
+		for name := range enabledPlatforms {
+			names = append(names, name)
+		}
+		if staticPublisher != nil {
+			names = append(names, staticsite.Name)
+		}
+		if logRouter, err = tasklog.NewRouter(logDir, names); err != nil {
+			log.Fatalf("[log] platform_dir 配置错误: %v", err)
+		}
+		defer logRouter.Close()
+		log.SetOutput(logRouter.Writer(os.Stderr))
+		log.Printf("各平台日志同时输出到 %s 目录", logDir)
+	}
+
+	// 解析articles目录下的所有文章（quick 命令直接使用即时构造的文章）
+	parser := newParser(cfg)
+	parser.SetFilter(options.filter)
+	runStartedAt := time.Now()
+	articles := options.articles
+	if articles == nil && options.taskPath != "" {
+		log.Printf("正在解析任务文件 %s 中的文章...", options.taskPath)
+		if articles, err = loadTaskArticles(cfg, parser, options.taskPath, options.filter); err != nil {
+			log.Fatalf("%v", err)
+		}
+	} else if articles == nil {
+		log.Println("正在解析articles目录下的文章...")
+		if options.sinceLastRun {
+			applySinceLastRun(parser)
+		}
+		if articles, err = loadArticles(cfg, parser); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+	// 监听模式下重新解析的文章需要与其它篇目重新关联系列
+	parsed := append([]*article.Article(nil), articles...)
+
+	if len(articles) == 0 {
+		log.Printf("⚠️ %s", emptyArticlesHint)
+	} else {
+		log.Printf("✅ 成功解析 %d 篇文章:", len(articles))
+		for i, art := range articles {
+			log.Printf("  %d. %s (%d行)", i+1, art.Title, art.GetContentLineCount())
+		}
+	}
+
+	// frontmatter 的 platforms 中指定了未启用的平台时一并打开，未指定 platforms 的文章仍只发布到配置中启用的平台
+	configuredPlatforms := make(map[string]bool, len(enabledPlatforms))
+	for name := range enabledPlatforms {
+		configuredPlatforms[name] = true
+	}
+	if options.updateTargets == nil {
+		enabledPlatforms = withArticlePlatforms(enabledPlatforms, articles)
+	}
+
+	// 发布前的正文预处理，监听模式下修改的文章也经过同样的处理
+	prep := newArticlePrep(cfg)
+	articles = prep.apply(articles)
+
+	// 加载发布历史，跳过自上次发布后未改动的文章
+	publishHistory := loadHistory()
+
+	// 加载选择器覆盖配置
+	loadSelectorOverrides(cfg)
+
+	// 按 EXIF 方向校正手机照片
+	autoOrient := cfg.AutoOrientImages()
+	if autoOrient {
+		orientImages(articles)
+	}
+
+	// 预览模式：渲染为 HTML 后在默认浏览器中打开，不发布
+	if options.preview {
+		previewArticles(articles)
+		return
+	}
+
+	platformNames := make([]string, 0, len(enabledPlatforms))
+	defaultNames := make([]string, 0, len(configuredPlatforms))
+	for name := range enabledPlatforms {
+		platformNames = append(platformNames, name)
+		if configuredPlatforms[name] {
+			defaultNames = append(defaultNames, name)
+		}
+	}
+	if staticPublisher != nil {
+		platformNames = append(platformNames, staticsite.Name)
+		defaultNames = append(defaultNames, staticsite.Name)
+	}
+	// 修改已发布的文章时即使内容与历史记录相同也照常更新
+	if options.updateTargets == nil {
+		articles = filterUnchanged(articles, publishHistory, platformNames, defaultNames)
+	}
+
+	// 发布前钩子，脚本失败时按配置中止对应文章的发布
+	hookRunner := hooks.NewRunner(cfg.GetHookOptions())
+	articles = runPreHooks(articles, hookRunner)
+
+	// 发布结果通知：配置了 Webhook 时汇总各平台结果，发布完成后推送
+	notifier, err := notify.NewNotifier(cfg.GetNotifyOptions())
+	if err != nil {
+		log.Fatalf("[notify] 配置错误: %v", err)
+	}
+	var report *notify.PublishReport
+	if notifier != nil {
+		report = notify.NewReport()
+	}
+
+	if staticPublisher != nil {
+		publishStatic(staticPublisher, articles, publishHistory, hookRunner, report)
+	}
+
+	// 只启用了静态博客时无需启动浏览器
+	if len(enabledPlatforms) == 0 && !options.watch {
+		notifier.Send(report)
+		if options.coversArticlesDir() {
+			saveLastRun(runStartedAt)
+		}
+		return
+	}
+
+	// 检查并安装 Playwright
+	if err := installer.EnsurePlaywrightInstalled(cfg.GetInstallerOptions()); err != nil {
+		log.Fatalf("安装 Playwright 失败: %v", err)
+	}
+
+	// 创建会话管理器
+	sessionManager, err := session.NewManager()
+	if err != nil {
+		log.Fatalf("无法创建会话管理器: %v", err)
+	}
+
+	// 创建浏览器管理器（带会话持久化和文章数据）
+	browserOptions := cfg.GetBrowserOptions()
+	browserOptions.Zhihu = cfg.GetZhihuOptions()
+	if browserOptions.Juejin, err = cfg.GetJuejinOptions(); err != nil {
+		log.Fatalf("[platform.juejin] 配置错误: %v", err)
+	}
+	browserOptions.SummaryLength = cfg.GetSummaryLength()
+	browserOptions.SeriesNavigation = cfg.SeriesNavigation()
+	// 进度条单行刷新输出到 stderr，非终端时不显示
+	if !options.noProgress && progress.IsTerminal(os.Stderr) {
+		browserOptions.Progress = progress.NewBar(os.Stderr)
+		log.SetOutput(logRouter.Writer(browserOptions.Progress.LogWriter(os.Stderr)))
+	}
+	if browserOptions.PublishMode, err = cfg.GetPublishMode(); err != nil {
+		log.Fatalf("[publish] mode 配置错误: %v", err)
+	}
+	if browserOptions.ImageStrategies, err = cfg.GetImageStrategies(); err != nil {
+		log.Fatalf("图片策略配置错误: %v", err)
+	}
+	if browserOptions.Visibilities, err = cfg.GetVisibilities(); err != nil {
+		log.Fatalf("可见范围配置错误: %v", err)
+	}
+	if browserOptions.LengthStrategies, err = cfg.GetLengthStrategies(); err != nil {
+		log.Fatalf("超长处理策略配置错误: %v", err)
+	}
+	if browserOptions.LanguageRoutes, err = cfg.GetLanguageRoutes(); err != nil {
+		log.Fatalf("[language_routes] 配置错误: %v", err)
+	}
+	browserOptions.DefaultPlatforms = configuredPlatforms
+	if browserOptions.UpdateTargets, err = cfg.GetUpdateTargets(); err != nil {
+		log.Fatalf("更新目标配置错误: %v", err)
+	}
+	if options.updateTargets != nil {
+		browserOptions.UpdateTargets = options.updateTargets
+	}
+	if hostOptions := cfg.GetImageHostOptions(); hostOptions.UploadURL != "" {
+		if browserOptions.ImageHost, err = imagehost.NewUploader(hostOptions); err != nil {
+			log.Fatalf("无法创建图床上传器: %v", err)
+		}
+	}
+	if coverOptions, enabled := cfg.GetCoverOptions(); enabled {
+		if browserOptions.Cover, err = cover.NewGenerator(coverOptions); err != nil {
+			log.Fatalf("无法创建封面生成器: %v", err)
+		}
+	}
+	if watermarkOptions, enabled := cfg.GetWatermarkOptions(); enabled {
+		if browserOptions.Watermark, err = watermark.NewWatermarker(watermarkOptions); err != nil {
+			log.Fatalf("无法创建水印处理器: %v", err)
+		}
+		browserOptions.WatermarkPlatforms = cfg.GetWatermarkPlatforms()
+	}
+	browserOptions.Report = report
+	browserOptions.Status = openStatusWriter(cfg)
+	defer browserOptions.Status.Close()
+	browserManager, err := browser.NewManager(sessionManager.GetUserDataDir(), articles, browserOptions)
+	if err != nil {
+		log.Fatalf("无法创建浏览器管理器: %v", err)
+	}
+	defer browserManager.Close()
+
+	if publishHistory != nil {
+		browserManager.SetHistory(publishHistory)
+	}
+	browserManager.SetPublishedHandler(hookRunner.AfterPublish)
+
+	// 打开所有平台
+	browserManager.OpenPlatforms(enabledPlatforms)
+	notifier.Send(report)
+	// quick 命令、任务文件和按标签筛选发布的文章不代表整个 articles 目录，不影响增量发布的记录
+	if options.coversArticlesDir() {
+		saveLastRun(runStartedAt)
+	}
+
+	// 监听模式：articles 目录中的文章新增或修改后自动发布
+	if options.watch {
+		articleWatcher, err := watcher.NewWatcher("articles", 2*time.Second)
+		if err != nil {
+			// log.Fatalf 不会执行 defer，先关闭浏览器避免遗留进程
+			browserManager.Close()
+			log.Fatalf("无法启动监听模式: %v", err)
+		}
+		defer articleWatcher.Close()
+
+		go articleWatcher.Watch(func(path string) {
+			art, err := parser.ParseFile(path)
+			if err != nil {
+				log.Printf("❌ 解析文章失败: %v", err)
+				return
+			}
+			if ok, reason := options.filter.Match(art); !ok {
+				log.Printf("⏭️ 跳过《%s》: %s", art.Title, reason)
+				return
+			}
+			parsed = relinkSeries(parsed, art)
+			// 与启动时的处理一致后再比较内容哈希，否则修改过的文章每次保存都会重新发布
+			changed := filterUnchanged(prep.apply([]*article.Article{art}), publishHistory, platformNames, defaultNames)
+			if len(changed) == 0 {
+				return
+			}
+			if changed = runPreHooks(changed, hookRunner); len(changed) == 0 {
+				return
+			}
+			if autoOrient {
+				orientImages(changed)
+			}
+			report.Reset()
+			if staticPublisher != nil {
+				publishStatic(staticPublisher, changed, publishHistory, hookRunner, report)
+			}
+			browserManager.PublishArticles(changed)
+			notifier.Send(report)
+		})
+	}
+
+	// 等待用户退出
+	browserManager.WaitForExit()
+}
+
+// applySinceLastRun 让解析器只解析上次运行以来修改过的文章，首次运行时解析全部
+func applySinceLastRun(parser *article.Parser) {
+	lastRunPath, err := history.LastRunPath()
+	if err != nil {
+		log.Printf("⚠️ 无法确定上次运行记录路径，发布全部文章: %v", err)
+		return
+	}
+	lastRun, err := history.LoadLastRun(lastRunPath, "articles")
+	if err != nil {
+		log.Printf("⚠️ %v，发布全部文章", err)
+		return
+	}
+	if lastRun.IsZero() {
+		log.Println("首次增量发布，发布全部文章")
+		return
+	}
+	log.Printf("只发布 %s 之后新增或修改的文章", lastRun.Format("2006-01-02 15:04:05"))
+	parser.SetModifiedAfter(lastRun)
+}
+
+// saveLastRun 记录本次运行的开始时间，运行期间修改的文章下次仍会发布
+func saveLastRun(runStartedAt time.Time) {
+	lastRunPath, err := history.LastRunPath()
+	if err != nil {
+		log.Printf("⚠️ 无法确定上次运行记录路径: %v", err)
+		return
+	}
+	if err := history.SaveLastRun(lastRunPath, "articles", runStartedAt); err != nil {
+		log.Printf("⚠️ 保存本次运行时间失败: %v", err)
+	}
+}
+
+// statusFilePath 返回实时进度状态文件路径：[log] status_file，未配置时使用默认路径
+func statusFilePath(cfg *config.Config) (string, error) {
+	if path := cfg.GetStatusFile(); path != "" {
+		return path, nil
+	}
+	return status.DefaultPath()
+}
+
+// openStatusWriter 创建实时进度状态文件，失败时返回 nil（不影响发布）
+func openStatusWriter(cfg *config.Config) *status.Writer {
+	path, err := statusFilePath(cfg)
+	if err != nil {
+		log.Printf("⚠️ 无法确定状态文件路径: %v", err)
+		return nil
+	}
+	writer, err := status.NewWriter(path)
+	if err != nil {
+		log.Printf("⚠️ 创建状态文件失败: %v", err)
+		return nil
+	}
+	log.Printf("📍 发布进度实时写入 %s，可在另一个终端运行 auto-blog status 查看", path)
+	return writer
+}
+
+// loadHistory 加载发布历史，失败时返回 nil（不影响发布）
+func loadHistory() *history.History {
+	historyPath, err := history.DefaultPath()
+	if err != nil {
+		log.Printf("⚠️ 无法确定发布历史路径: %v", err)
+		return nil
+	}
+	publishHistory, err := history.Load(historyPath)
+	if err != nil {
+		log.Printf("⚠️ 加载发布历史失败: %v", err)
+		return nil
+	}
+	return publishHistory
+}
+
+// updatePlatforms 从启用的平台中选出有更新目标的平台
+func updatePlatforms(enabledPlatforms map[string]string, targets map[string]map[string]string) map[string]string {
+	selected := make(map[string]string)
+	for name, url := range enabledPlatforms {
+		if len(targets[name]) > 0 {
+			selected[name] = url
+		}
+	}
+	return selected
+}
+
+// withArticlePlatforms 把文章 frontmatter 的 platforms 中指定、但未在配置中启用的平台加入要打开的平台，
+// 无法识别的平台只给出警告
+func withArticlePlatforms(enabledPlatforms map[string]string, articles []*article.Article) map[string]string {
+	merged := make(map[string]string, len(enabledPlatforms))
+	for name, url := range enabledPlatforms {
+		merged[name] = url
+	}
+	for _, art := range articles {
+		for _, key := range art.Meta.Platforms {
+			if strings.EqualFold(key, staticsite.ID) || key == staticsite.Name {
+				continue
+			}
+			_, name, url, ok := config.LookupPlatform(key)
+			if !ok {
+				log.Printf("⚠️ 《%s》的 platforms 中的平台 %q 无法识别，已忽略", art.Title, key)
+				continue
+			}
+			if _, enabled := merged[name]; !enabled {
+				log.Printf("《%s》指定发布到未启用的平台 %s，本次一并打开", art.Title, name)
+				merged[name] = url
+			}
+		}
+	}
+	return merged
+}
+
+// articlePlatformNames 从 platformNames 中选出文章要发布的平台（frontmatter 的 platforms），
+// 未设置 platforms 时为 defaultNames（配置中启用的平台）
+func articlePlatformNames(art *article.Article, platformNames, defaultNames []string) []string {
+	if len(art.Meta.Platforms) == 0 {
+		return defaultNames
+	}
+	names := make([]string, 0, len(platformNames))
+	for _, name := range platformNames {
+		id := staticsite.ID
+		if name != staticsite.Name {
+			id, _, _, _ = config.LookupPlatform(name)
+		}
+		if art.PublishesTo(id, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// filterUnchanged 过滤掉自上次发布后未改动的文章，platformNames 为打开的全部平台，defaultNames 为配置中启用的平台
+func filterUnchanged(articles []*article.Article, publishHistory *history.History, platformNames, defaultNames []string) []*article.Article {
+	if publishHistory == nil {
+		return articles
+	}
+
+	changed := make([]*article.Article, 0, len(articles))
+	for _, art := range articles {
+		if publishHistory.IsUnchanged(art.Path, art.ContentHash(), articlePlatformNames(art, platformNames, defaultNames)) {
+			log.Printf("⏭️ 《%s》自上次发布后未改动，跳过", art.Title)
+			continue
+		}
+		changed = append(changed, art)
+	}
+	return changed
+}
+
+// relinkSeries 把重新解析的文章放回已解析的文章列表（路径相同则替换），并重新关联系列
+func relinkSeries(parsed []*article.Article, art *article.Article) []*article.Article {
+	replaced := false
+	for i, existing := range parsed {
+		if existing.Path == art.Path {
+			parsed[i] = art
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		parsed = append(parsed, art)
+	}
+	article.LinkSeries(parsed)
+	return parsed
+}
+
+// articlePrep 保存发布前正文预处理的配置
+type articlePrep struct {
+	detectLanguage   bool
+	blankLineMode    article.BlankLineMode
+	convertEmoji     bool
+	altTextMode      article.AltTextMode
+	defaultPublishAt string
+	sensitiveFilter  *sensitive.Filter
+}
+
+// newArticlePrep 读取并校验预处理相关的配置，配置错误时直接退出
+func newArticlePrep(cfg *config.Config) *articlePrep {
+	prep := &articlePrep{
+		detectLanguage:   cfg.DetectCodeLanguage(),
+		convertEmoji:     cfg.ConvertEmojiShortcodes(),
+		defaultPublishAt: cfg.GetDefaultPublishAt(),
+	}
+
+	var err error
+	prep.blankLineMode, err = article.ParseBlankLineMode(cfg.GetBlankLineMode())
+	if err != nil {
+		log.Fatalf("[markdown] blank_lines 配置错误: %v", err)
+	}
+	prep.altTextMode, err = article.ParseAltTextMode(cfg.GetAltTextMode())
+	if err != nil {
+		log.Fatalf("[image] auto_alt 配置错误: %v", err)
+	}
+	if prep.defaultPublishAt != "" {
+		if _, err := article.ParsePublishTime(prep.defaultPublishAt); err != nil {
+			log.Fatalf("[publish] publish_at 配置错误: %v", err)
+		}
+	}
+	if wordsFile, strategy := cfg.GetSensitiveConfig(); wordsFile != "" {
+		prep.sensitiveFilter, err = sensitive.LoadFilter(wordsFile, sensitive.ParseStrategy(strategy))
+		if err != nil {
+			log.Fatalf("加载敏感词表失败: %v", err)
+		}
+		log.Printf("已加载 %d 个敏感词，策略: %s", prep.sensitiveFilter.WordCount(), prep.sensitiveFilter.Strategy())
+	}
+	return prep
+}
+
+// apply 按固定顺序预处理文章，返回未被敏感词拦截的文章
+func (p *articlePrep) apply(articles []*article.Article) []*article.Article {
+	// 发布前检查Markdown语法问题
+	for _, art := range articles {
+		warnings := art.Lint()
+		if len(warnings) == 0 {
+			continue
+		}
+		log.Printf("⚠️ 《%s》发现 %d 个潜在问题:", art.Title, len(warnings))
+		for _, warning := range warnings {
+			log.Printf("    - %s", warning)
+		}
+	}
+
+	// 为未标注语言的代码块自动补上语言
+	if p.detectLanguage {
+		detectCodeLanguages(articles)
+	}
+
+	// 规范化正文空行，统一各平台的段落间距（在检查之后执行，避免检查结果的行号错位）
+	normalizeBlankLines(articles, p.blankLineMode)
+
+	// 将 emoji 短代码转换为 Unicode 表情
+	if p.convertEmoji {
+		convertEmojiShortcodes(articles)
+	}
+
+	// 为 alt 为空的图片生成默认描述，用于图片占位符和图注
+	fillMissingAltText(articles, p.altTextMode)
+
+	// 未在 frontmatter 中指定发布时间的文章使用配置的默认定时发布时间
+	applyDefaultPublishAt(articles, p.defaultPublishAt)
+
+	// 敏感词预检
+	if p.sensitiveFilter != nil {
+		articles = checkSensitive(articles, p.sensitiveFilter)
+	}
+	return articles
+}
+
+// detectCodeLanguages 为文章中未标注语言的代码块补上自动检测到的语言
+func detectCodeLanguages(articles []*article.Article) {
+	for _, art := range articles {
+		if count := art.DetectCodeLanguages(); count > 0 {
+			log.Printf("🔤 《%s》已为 %d 个代码块补充语言标注", art.Title, count)
+		}
+	}
+}
+
+// normalizeBlankLines 按配置规范化文章正文中的空行
+func normalizeBlankLines(articles []*article.Article, mode article.BlankLineMode) {
+	for _, art := range articles {
+		if removed := art.NormalizeBlankLines(mode); removed > 0 {
+			log.Printf("📏 《%s》已压缩 %d 个多余空行", art.Title, removed)
+		}
+	}
+}
+
+// convertEmojiShortcodes 将文章正文中的 emoji 短代码转换为 Unicode 表情
+func convertEmojiShortcodes(articles []*article.Article) {
+	for _, art := range articles {
+		if count := art.ConvertEmojiShortcodes(); count > 0 {
+			log.Printf("😄 《%s》已转换 %d 个 emoji 短代码", art.Title, count)
+		}
+	}
+}
+
+// fillMissingAltText 按配置为 alt 为空的图片生成默认描述
+func fillMissingAltText(articles []*article.Article, mode article.AltTextMode) {
+	for _, art := range articles {
+		if count := art.FillMissingAltText(mode); count > 0 {
+			log.Printf("🏷️ 《%s》已为 %d 张图片生成 alt 描述", art.Title, count)
+		}
+	}
+}
+
+// applyDefaultPublishAt 为未指定发布时间的文章设置默认定时发布时间
+func applyDefaultPublishAt(articles []*article.Article, publishAt string) {
+	if publishAt == "" {
+		return
+	}
+	for _, art := range articles {
+		if art.Meta.PublishAt == "" {
+			art.Meta.PublishAt = publishAt
+		}
+	}
+}
+
+// runPreHooks 对每篇文章执行 pre_publish 钩子，返回允许发布的文章
+func runPreHooks(articles []*article.Article, hookRunner *hooks.Runner) []*article.Article {
+	allowed := make([]*article.Article, 0, len(articles))
+	for _, art := range articles {
+		if hookRunner.BeforePublish(art) {
+			allowed = append(allowed, art)
+		}
+	}
+	return allowed
+}
+
+// publishStatic 将文章输出到静态博客目录并记录发布历史
+func publishStatic(publisher *staticsite.Publisher, articles []*article.Article, publishHistory *history.History, hookRunner *hooks.Runner, report *notify.PublishReport) {
+	for _, art := range articles {
+		if !art.PublishesTo(staticsite.ID, staticsite.Name) {
+			continue
+		}
+		// 重新输出修改过的文章时沿用首次输出的时间，避免文章日期变化
+		var firstPublished time.Time
+		if publishHistory != nil {
+			record := publishHistory.Find(art.Path, staticsite.Name)
+			if record != nil && record.ContentHash == art.ContentHash() {
+				continue
+			}
+			if record != nil {
+				firstPublished = record.PublishedAt
+			}
+		}
+
+		outputPath, err := publisher.PublishArticle(art, firstPublished)
+		result := notify.Result{Title: art.Title, Path: art.Path, Platform: staticsite.Name, Success: err == nil, URL: outputPath}
+		if err != nil {
+			log.Printf("[%s] ❌ 《%s》输出失败: %v", staticsite.Name, art.Title, err)
+			result.Error = err.Error()
+			report.Add(result)
+			continue
+		}
+		report.Add(result)
+		hookRunner.AfterPublish(art, []string{staticsite.Name})
+
+		if publishHistory != nil {
+			publishHistory.Add(history.Record{
+				ArticlePath: art.Path,
+				Title:       art.Title,
+				Platform:    staticsite.Name,
+				ContentHash: art.ContentHash(),
+				PublishedAt: firstPublished,
+			})
+			if err := publishHistory.Save(); err != nil {
+				log.Printf("⚠️ 保存发布历史失败: %v", err)
+			}
+		}
+	}
+}
+
+// checkSensitive 扫描文章中的敏感词，按策略警告、替换或阻止发布
+func checkSensitive(articles []*article.Article, filter *sensitive.Filter) []*article.Article {
+	allowed := make([]*article.Article, 0, len(articles))
+	for _, art := range articles {
+		hits, blocked := filter.Apply(art)
+		for _, hit := range hits {
+			log.Printf("🚨 《%s》%s", art.Title, hit)
+		}
+		if blocked {
+			log.Printf("⛔ 《%s》包含 %d 处敏感词，已阻止发布", art.Title, len(hits))
+			continue
+		}
+		if len(hits) > 0 && filter.Strategy() == sensitive.StrategyReplace {
+			log.Printf("✂️ 《%s》中的敏感词已替换为 *", art.Title)
+		}
+		allowed = append(allowed, art)
+	}
+	return allowed
+}
+
+// orientImages 按 EXIF 方向校正文章中的图片，校正后的图片替换原路径用于上传
+func orientImages(articles []*article.Article) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		log.Printf("⚠️ 无法确定图片缓存目录: %v", err)
+		return
+	}
+	cacheDir := filepath.Join(homeDir, ".auto-blog", "cache", "images")
+
+	for _, art := range articles {
+		for i, img := range art.Images {
+			orientedPath, fixed, err := utils.FixOrientation(img.AbsolutePath, cacheDir)
+			if err != nil {
+				log.Printf("⚠️ 图片方向校正失败 %s: %v", img.RelativePath, err)
+				continue
+			}
+			if fixed {
+				tempfiles.Register(orientedPath)
+				art.Images[i].AbsolutePath = orientedPath
+				log.Printf("🔄 已按 EXIF 方向校正图片: %s", img.RelativePath)
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/browser"
+	"github.com/auto-blog/installer"
+	"github.com/auto-blog/tempfiles"
+	"github.com/auto-blog/utils"
+)
+
+// remoteImageRegex Markdown 中的网络图片，捕获图片地址
+var remoteImageRegex = regexp.MustCompile(`!\[[^\]]*\]\((https?://[^)\s]+)`)
+
+// runQuick 从剪贴板或网页即时构造文章并发布，不需要先把文章保存到 articles 目录
+func runQuick(args []string) {
+	flags, configPath := newFlagSet("quick")
+	url := flags.String("url", "", "抓取网页正文转换为 Markdown 后发布，不指定时读取剪贴板中的 Markdown")
+	title := flags.String("title", "", "文章标题，不指定时使用正文第一行（抓取网页时为网页标题）")
+	noProgress := flags.Bool("no-progress", false, "不显示发布进度条")
+	preview := flags.Bool("preview", false, "只在默认浏览器中预览渲染结果，不发布")
+	flags.Parse(args)
+
+	cfg, _, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	var markdown string
+	if *url != "" {
+		if err := installer.EnsurePlaywrightInstalled(cfg.GetInstallerOptions()); err != nil {
+			log.Fatalf("安装 Playwright 失败: %v", err)
+		}
+		log.Printf("正在抓取网页: %s", *url)
+		pageTitle, body, err := browser.FetchMarkdown(*url, cfg.GetBrowserOptions().ExecutablePath)
+		if err != nil {
+			log.Fatalf("抓取网页失败: %v", err)
+		}
+		if *title == "" {
+			*title = pageTitle
+		}
+		markdown = body
+	} else {
+		if markdown, err = utils.ReadClipboardText(); err != nil {
+			log.Fatalf("%v", err)
+		}
+		if strings.TrimSpace(markdown) == "" {
+			log.Fatalf("剪贴板中没有文本，请先复制 Markdown 内容，或使用 --url 抓取网页")
+		}
+	}
+
+	art, err := buildQuickArticle(newParser(cfg), localizeRemoteImages(markdown), *title)
+	if err != nil {
+		log.Fatalf("构造文章失败: %v", err)
+	}
+	log.Printf("✅ 已构造文章《%s》（%d 行，%d 张图片）", art.Title, art.GetContentLineCount(), len(art.Images))
+
+	publish(publishOptions{
+		configPath: *configPath,
+		noProgress: *noProgress,
+		preview:    *preview,
+		articles:   []*article.Article{art},
+	})
+}
+
+// buildQuickArticle 由 Markdown 文本构造文章：指定了标题时作为第一行，否则第一行为标题（去掉 # 标记）。
+// 文章路径为当前目录下按时间命名的虚拟文件，正文中的相对图片路径基于当前目录解析
+func buildQuickArticle(parser *article.Parser, markdown, title string) (*article.Article, error) {
+	markdown = strings.TrimLeft(markdown, "\r\n")
+	if title != "" {
+		markdown = title + "\n\n" + markdown
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("获取当前目录失败: %v", err)
+	}
+	articlePath := filepath.Join(dir, fmt.Sprintf("quick-%s.md", time.Now().Format("20060102-150405")))
+
+	art, err := parser.ParseContent([]byte(markdown), articlePath)
+	if err != nil {
+		return nil, err
+	}
+	return art, nil
+}
+
+// localizeRemoteImages 把正文中的网络图片下载到 ~/.auto-blog/cache/quick，改为本地路径，
+// 各平台的图片处理都基于本地文件；下载失败的图片保持原样
+func localizeRemoteImages(markdown string) string {
+	matches := remoteImageRegex.FindAllStringSubmatch(markdown, -1)
+	if len(matches) == 0 {
+		return markdown
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		log.Printf("⚠️ 获取用户主目录失败，网络图片保持原样: %v", err)
+		return markdown
+	}
+	cacheDir := filepath.Join(homeDir, ".auto-blog", "cache", "quick")
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		log.Printf("⚠️ 创建图片缓存目录失败，网络图片保持原样: %v", err)
+		return markdown
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	downloaded := make(map[string]string)
+	for _, match := range matches {
+		url := match[1]
+		if _, ok := downloaded[url]; ok {
+			continue
+		}
+		localPath, err := downloadImage(client, url, cacheDir)
+		if err != nil {
+			log.Printf("⚠️ 下载图片失败 %s: %v", url, err)
+			continue
+		}
+		tempfiles.Register(localPath)
+		downloaded[url] = localPath
+	}
+	log.Printf("🖼️ 已下载 %d/%d 张网络图片", len(downloaded), len(matches))
+
+	return remoteImageRegex.ReplaceAllStringFunc(markdown, func(image string) string {
+		url := remoteImageRegex.FindStringSubmatch(image)[1]
+		if localPath, ok := downloaded[url]; ok {
+			return strings.Replace(image, url, localPath, 1)
+		}
+		return image
+	})
+}
+
+// downloadImage 下载图片到 dir，文件名为地址的哈希，扩展名取自地址或响应的 Content-Type
+func downloadImage(client *http.Client, url, dir string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	ext := strings.ToLower(path.Ext(strings.SplitN(url, "?", 2)[0]))
+	switch ext {
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp":
+	default:
+		ext = ".png"
+		if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+			if extensions, _ := mime.ExtensionsByType(mediaType); len(extensions) > 0 {
+				ext = extensions[0]
+			}
+		}
+	}
+
+	sum := sha256.Sum256([]byte(url))
+	localPath := filepath.Join(dir, hex.EncodeToString(sum[:8])+ext)
+	file, err := os.Create(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		return "", err
+	}
+	return localPath, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/auto-blog/status"
+)
+
+// statusRefreshInterval --watch 模式下刷新进度的间隔
+const statusRefreshInterval = 2 * time.Second
+
+// runStatus 读取状态文件，显示正在进行（或最近一次）的发布进度
+func runStatus(args []string) {
+	flags, configPath := newFlagSet("status")
+	watch := flags.Bool("watch", false, "持续刷新进度，直到发布进程退出")
+	flags.Parse(args)
+
+	cfg, _, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	path, err := statusFilePath(cfg)
+	if err != nil {
+		log.Fatalf("无法确定状态文件路径: %v", err)
+	}
+
+	for {
+		current, err := status.Load(path)
+		if os.IsNotExist(err) {
+			fmt.Printf("没有找到状态文件 %s，还没有运行过发布任务\n", path)
+			return
+		}
+		if err != nil {
+			log.Fatalf("读取状态文件失败: %v", err)
+		}
+		printStatus(current)
+		if !*watch || current.State == status.StateFinished {
+			return
+		}
+		time.Sleep(statusRefreshInterval)
+		fmt.Println()
+	}
+}
+
+// printStatus 打印发布进度：总体进度、各文章在各平台的步骤和结果
+func printStatus(s *status.Status) {
+	switch s.State {
+	case status.StateRunning:
+		fmt.Printf("🚀 发布进行中（进程 %d，目录 %s），已运行 %s\n", s.PID, s.WorkDir, time.Since(s.StartedAt).Round(time.Second))
+	default:
+		finishedAt := s.UpdatedAt
+		if s.FinishedAt != nil {
+			finishedAt = *s.FinishedAt
+		}
+		fmt.Printf("🏁 发布已结束（进程 %d，目录 %s），结束于 %s\n", s.PID, s.WorkDir, finishedAt.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Printf("文章: %d/%d 已处理，最后更新 %s\n", s.Done, s.Total, s.UpdatedAt.Format("15:04:05"))
+
+	for _, art := range s.Articles {
+		marker := "  "
+		if art.Path == s.Current {
+			marker = "▶ "
+		}
+		fmt.Printf("%s《%s》(%s)\n", marker, art.Title, art.Path)
+		for _, p := range art.Platforms {
+			fmt.Printf("     %s: %s\n", p.Name, platformStatusText(p))
+		}
+	}
+}
+
+// platformStatusText 平台进度的文字描述：有结果时显示结果，否则显示当前步骤
+func platformStatusText(p status.Platform) string {
+	switch p.Result {
+	case status.ResultSucceeded:
+		return "✅ 完成"
+	case status.ResultUnverified:
+		return "⚠️ 已提交但未确认成功: " + p.Error
+	case status.ResultFailed:
+		return "❌ 失败: " + p.Error
+	}
+	if p.Step == status.StepImages {
+		return fmt.Sprintf("%s（%d/%d）", p.Step, p.ImagesDone, p.ImagesTotal)
+	}
+	return p.Step
+}
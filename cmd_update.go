@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/config"
+)
+
+// runUpdate 修改已发布的文章：打开文章编辑页，清空原内容后填入文章的新内容并保存更新
+func runUpdate(args []string) {
+	flags, configPath := newFlagSet("update")
+	platformIDs := flags.String("platform", "", "要更新的平台标识，多个用逗号分隔（如 zhihu,juejin），默认为所有启用的平台")
+	articleURL := flags.String("url", "", "已发布文章的链接（只能与单个 --platform 一起使用），不指定时使用配置或发布历史中的链接")
+	noProgress := flags.Bool("no-progress", false, "不显示发布进度条")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		log.Fatalf("用法: auto-blog update [--platform 平台] [--url 文章链接] <文章文件>")
+	}
+	cfg := mustLoadConfig(*configPath)
+
+	names, err := updatePlatformNames(cfg, *platformIDs)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *articleURL != "" && len(names) != 1 {
+		log.Fatalf("--url 只能与单个 --platform 一起使用")
+	}
+
+	art, err := newParser(cfg).ParseFile(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("解析文章失败: %v", err)
+	}
+
+	configured, err := cfg.GetUpdateTargets()
+	if err != nil {
+		log.Fatalf("更新目标配置错误: %v", err)
+	}
+	publishHistory := loadHistory()
+	fileName := filepath.Base(art.Path)
+	targets := make(map[string]map[string]string)
+	for _, name := range names {
+		url := *articleURL
+		if url == "" {
+			url = configured[name][fileName]
+		}
+		if url == "" && publishHistory != nil {
+			if record := publishHistory.Find(art.Path, name); record != nil {
+				url = record.URL
+			}
+		}
+		if url == "" {
+			log.Printf("⏭️ 没有找到《%s》在 %s 上的文章链接，跳过（可用 --url 指定）", art.Title, name)
+			continue
+		}
+		targets[name] = map[string]string{fileName: url}
+	}
+	if len(targets) == 0 {
+		log.Fatalf("没有可更新的平台")
+	}
+
+	publish(publishOptions{
+		configPath:    *configPath,
+		noProgress:    *noProgress,
+		articles:      []*article.Article{art},
+		updateTargets: targets,
+	})
+}
+
+// updatePlatformNames 把 --platform 指定的平台标识转换为平台名称（必须已启用），未指定时返回所有启用的平台
+func updatePlatformNames(cfg *config.Config, ids string) ([]string, error) {
+	var names []string
+	enabled := cfg.GetEnabledPlatforms()
+	if strings.TrimSpace(ids) == "" {
+		for name := range enabled {
+			names = append(names, name)
+		}
+		return names, nil
+	}
+	for _, id := range strings.Split(ids, ",") {
+		_, name, _, ok := config.LookupPlatform(id)
+		if !ok {
+			return nil, fmt.Errorf("平台 %s 不存在", strings.TrimSpace(id))
+		}
+		if _, ok := enabled[name]; !ok {
+			return nil, fmt.Errorf("平台 %s 未启用，请先在配置中开启", strings.TrimSpace(id))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
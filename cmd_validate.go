@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/baijiahao"
+	"github.com/auto-blog/cnblogs"
+	"github.com/auto-blog/cover"
+	"github.com/auto-blog/imagehost"
+	"github.com/auto-blog/juejin"
+	"github.com/auto-blog/notify"
+	"github.com/auto-blog/segmentfault"
+	"github.com/auto-blog/selectors"
+	"github.com/auto-blog/sensitive"
+	"github.com/auto-blog/staticsite"
+	"github.com/auto-blog/toutiao"
+	"github.com/auto-blog/watermark"
+	"github.com/auto-blog/zhihu"
+)
+
+// runValidate 校验配置文件和文章，发现错误时以状态码 1 退出（Markdown 问题只作为警告）
+func runValidate(args []string) {
+	flags, configPath := newFlagSet("validate")
+	taskPath := flags.String("task", "", "同时校验任务文件（JSON 或 YAML）及其中列出的文章")
+	flags.Parse(args)
+
+	errors := make([]string, 0)
+	warnings := 0
+
+	cfg, configFile, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("🔍 校验配置文件: %s\n", configFile)
+
+	// 配置项
+	if len(cfg.GetEnabledPlatforms()) == 0 {
+		if _, enabled := cfg.GetStaticSiteOptions(); !enabled {
+			errors = append(errors, "[publish] 没有启用任何平台")
+		}
+	}
+	if _, err := article.ParseSortOrder(cfg.GetSortOrder()); err != nil {
+		errors = append(errors, fmt.Sprintf("[publish] sort 配置错误: %v", err))
+	}
+	if _, err := article.ParseBlankLineMode(cfg.GetBlankLineMode()); err != nil {
+		errors = append(errors, fmt.Sprintf("[markdown] blank_lines 配置错误: %v", err))
+	}
+	if _, err := article.ParseAltTextMode(cfg.GetAltTextMode()); err != nil {
+		errors = append(errors, fmt.Sprintf("[image] auto_alt 配置错误: %v", err))
+	}
+	if publishAt := cfg.GetDefaultPublishAt(); publishAt != "" {
+		if _, err := article.ParsePublishTime(publishAt); err != nil {
+			errors = append(errors, fmt.Sprintf("[publish] publish_at 配置错误: %v", err))
+		}
+	}
+	if multiplier := cfg.GetBrowserOptions().TimeoutMultiplier; multiplier <= 0 {
+		errors = append(errors, fmt.Sprintf("[browser] timeout_multiplier 必须大于 0，当前为 %v", multiplier))
+	}
+	if concurrency := cfg.GetBrowserOptions().UploadConcurrency; concurrency < 1 {
+		errors = append(errors, fmt.Sprintf("[image] upload_concurrency 必须大于等于 1，当前为 %d", concurrency))
+	}
+	if delay := cfg.GetBrowserOptions().ActionDelay; delay < 0 {
+		errors = append(errors, fmt.Sprintf("[browser] humanize_delay 不能为负数，当前为 %d", delay.Milliseconds()))
+	}
+	if concurrency := cfg.GetParseConcurrency(); concurrency < 0 {
+		errors = append(errors, fmt.Sprintf("[publish] parse_concurrency 不能为负数，当前为 %d", concurrency))
+	}
+	if retryFailed := cfg.GetBrowserOptions().RetryFailed; retryFailed < 0 {
+		errors = append(errors, fmt.Sprintf("[publish] retry_failed 不能为负数，当前为 %d", retryFailed))
+	}
+	if _, err := cfg.GetPublishMode(); err != nil {
+		errors = append(errors, fmt.Sprintf("[publish] mode 配置错误: %v", err))
+	}
+	if _, err := cfg.GetImageStrategies(); err != nil {
+		errors = append(errors, fmt.Sprintf("图片策略配置错误: %v", err))
+	}
+	if _, err := cfg.GetJuejinOptions(); err != nil {
+		errors = append(errors, fmt.Sprintf("[platform.juejin] 配置错误: %v", err))
+	}
+	if _, err := cfg.GetVisibilities(); err != nil {
+		errors = append(errors, fmt.Sprintf("可见范围配置错误: %v", err))
+	}
+	if _, err := cfg.GetLengthStrategies(); err != nil {
+		errors = append(errors, fmt.Sprintf("超长处理策略配置错误: %v", err))
+	}
+	if _, err := cfg.GetLanguageRoutes(); err != nil {
+		errors = append(errors, fmt.Sprintf("[language_routes] 配置错误: %v", err))
+	}
+	if _, err := cfg.GetUpdateTargets(); err != nil {
+		errors = append(errors, fmt.Sprintf("更新目标配置错误: %v", err))
+	}
+	if hostOptions := cfg.GetImageHostOptions(); hostOptions.UploadURL != "" {
+		if _, err := imagehost.NewUploader(hostOptions); err != nil {
+			errors = append(errors, fmt.Sprintf("[imagehost] 配置错误: %v", err))
+		}
+	}
+	if selectorsFile := cfg.GetSelectorsFile(); selectorsFile != "" {
+		if _, err := selectors.LoadOverrides(selectorsFile); err != nil {
+			errors = append(errors, fmt.Sprintf("[selectors] 配置错误: %v", err))
+		}
+	}
+	if wordsFile, strategy := cfg.GetSensitiveConfig(); wordsFile != "" {
+		if _, err := sensitive.LoadFilter(wordsFile, sensitive.ParseStrategy(strategy)); err != nil {
+			errors = append(errors, fmt.Sprintf("[sensitive] 配置错误: %v", err))
+		}
+	}
+	if coverOptions, enabled := cfg.GetCoverOptions(); enabled {
+		if _, err := cover.NewGenerator(coverOptions); err != nil {
+			errors = append(errors, fmt.Sprintf("[cover] 配置错误: %v", err))
+		}
+	}
+	if _, err := notify.NewNotifier(cfg.GetNotifyOptions()); err != nil {
+		errors = append(errors, fmt.Sprintf("[notify] 配置错误: %v", err))
+	}
+	if watermarkOptions, enabled := cfg.GetWatermarkOptions(); enabled {
+		if _, err := watermark.NewWatermarker(watermarkOptions); err != nil {
+			errors = append(errors, fmt.Sprintf("[watermark] 配置错误: %v", err))
+		}
+	}
+	if staticOptions, enabled := cfg.GetStaticSiteOptions(); enabled {
+		if _, err := staticsite.NewPublisher(staticOptions); err != nil {
+			errors = append(errors, fmt.Sprintf("[staticsite] 配置错误: %v", err))
+		}
+	}
+
+	// 文章：逐个解析，收集所有文件的错误而不是遇到第一个就停止
+	parser := newParser(cfg)
+	count := 0
+	err = filepath.Walk("articles", func(path string, info os.FileInfo, err error) error {
+		if err != nil && path == "articles" && os.IsNotExist(err) {
+			fmt.Println("⚠️ articles 目录不存在（运行 publish 时会自动创建），请把 .md 文章放进 articles 目录")
+			warnings++
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(strings.ToLower(info.Name()), ".md") {
+			return nil
+		}
+		count++
+
+		art, err := parser.ParseFile(path)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", path, err))
+			return nil
+		}
+		for _, warning := range art.Lint() {
+			fmt.Printf("⚠️ %s: %s\n", path, warning)
+			warnings++
+		}
+		for _, key := range unknownTitlePlatforms(art) {
+			fmt.Printf("⚠️ %s: titles 中的平台 %q 无法识别（可使用平台标识或名称，如 juejin 或 掘金）\n", path, key)
+			warnings++
+		}
+		for _, key := range unknownPlatformKeys(art.Meta.Platforms) {
+			fmt.Printf("⚠️ %s: platforms 中的平台 %q 无法识别（可使用平台标识或名称，如 juejin 或 掘金）\n", path, key)
+			warnings++
+		}
+		return nil
+	})
+	if err != nil {
+		errors = append(errors, fmt.Sprintf("读取 articles 目录失败: %v", err))
+	}
+
+	// 任务文件：校验格式、文章能否解析以及其中的平台
+	if *taskPath != "" {
+		if articles, err := loadTaskArticles(cfg, parser, *taskPath, article.Filter{KeepDrafts: true}); err != nil {
+			errors = append(errors, fmt.Sprintf("任务文件: %v", err))
+		} else {
+			for _, art := range articles {
+				for _, key := range unknownPlatformKeys(art.Meta.Platforms) {
+					fmt.Printf("⚠️ %s: 任务文件中的平台 %q 无法识别（可使用平台标识或名称，如 juejin 或 掘金）\n", art.Path, key)
+					warnings++
+				}
+				for _, key := range unknownTitlePlatforms(art) {
+					fmt.Printf("⚠️ %s: 任务文件 titles 中的平台 %q 无法识别（可使用平台标识或名称，如 juejin 或 掘金）\n", art.Path, key)
+					warnings++
+				}
+			}
+			fmt.Printf("🔍 任务文件 %s 列出 %d 篇文章\n", *taskPath, len(articles))
+		}
+	}
+
+	for _, message := range errors {
+		fmt.Printf("❌ %s\n", message)
+	}
+	fmt.Printf("共检查 %d 篇文章，%d 个错误，%d 个警告\n", count, len(errors), warnings)
+	if len(errors) > 0 {
+		os.Exit(1)
+	}
+	fmt.Println("✅ 校验通过")
+}
+
+// knownPlatformKeys frontmatter 中可以使用的平台标识和名称
+var knownPlatformKeys = []string{
+	juejin.ID, juejin.Name,
+	cnblogs.ID, cnblogs.Name,
+	zhihu.ID, zhihu.Name,
+	segmentfault.ID, segmentfault.Name,
+	toutiao.ID, toutiao.Name,
+	baijiahao.ID, baijiahao.Name,
+	staticsite.ID, staticsite.Name,
+}
+
+// unknownTitlePlatforms 返回 frontmatter titles 中无法对应到任何平台的键
+func unknownTitlePlatforms(art *article.Article) []string {
+	keys := make([]string, 0, len(art.Meta.Titles))
+	for key := range art.Meta.Titles {
+		keys = append(keys, key)
+	}
+	return unknownPlatformKeys(keys)
+}
+
+// unknownPlatformKeys 返回无法对应到任何平台的平台标识或名称，按字母顺序排列
+func unknownPlatformKeys(keys []string) []string {
+	unknown := make([]string, 0)
+	for _, key := range keys {
+		matched := false
+		for _, platformKey := range knownPlatformKeys {
+			if strings.EqualFold(strings.TrimSpace(key), platformKey) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
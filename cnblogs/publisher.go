@@ -8,6 +8,7 @@ import (
 
 	"github.com/auto-blog/article"
 	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
 	"github.com/playwright-community/playwright-go"
 )
 
@@ -26,6 +27,10 @@ func NewPublisher(page playwright.Page) *Publisher {
 // PublishArticle 发布文章到博客园
 func (p *Publisher) PublishArticle(art *article.Article) error {
 	log.Printf("开始发布文章到博客园: %s", art.Title)
+	// 博客园编辑器不认识代码块的额外参数（如 ```js {run}），只保留语言名
+	art = art.WithoutCodeParams()
+	// 提示框语法（> [!NOTE]、:::tip）改写为普通引用块
+	art = art.WithQuotedAdmonitions()
 	
 	// 1. 填写标题
 	if err := p.fillTitle(art.Title); err != nil {
@@ -48,13 +53,10 @@ func (p *Publisher) PublishArticle(art *article.Article) error {
 // fillTitle 填写文章标题
 func (p *Publisher) fillTitle(title string) error {
 	// 等待标题输入框出现并可见
-	titleLocator := p.page.Locator("#post-title")
+	titleLocator := p.page.Locator(selectors.Get(Name, selectors.Title))
 	
 	// 等待元素可见
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000), // 10秒超时
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(titleLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待标题输入框超时: %v", err)
 	}
 	
@@ -74,7 +76,7 @@ func (p *Publisher) fillContent(art *article.Article) error {
 	// 使用统一的富文本处理器
 	config := common.RichContentConfig{
 		PlatformName:        "博客园",
-		EditorSelector:      "#md-editor",              // markdown编辑器
+		EditorSelector:      selectors.Get(Name, selectors.Editor), // markdown编辑器
 		TitleSelector:       "",                       // 标题已在fillTitle中处理
 		UseMarkdownMode:     false,                    // 博客园不需要markdown解析对话框
 		ParseButtonCheck:    "",
@@ -104,9 +106,26 @@ func (p *Publisher) fillTextOnlyContent(content []string) error {
 
 // fillContentWithImages 填写带图片的内容 - 使用通用图片处理器
 func (p *Publisher) fillContentWithImages(art *article.Article) error {
-	// 创建博客园的图片上传配置
-	config := common.ImageUploadConfig{
-		PlatformName: "博客园",
+	uploader := common.NewImageUploader(p.page, p.imageUploadConfig(), p)
+	return uploader.ProcessArticleWithImages(art)
+}
+
+// UploadImage 查找占位符并通过编辑器的上传控件插入图片
+func (p *Publisher) UploadImage(placeholder string, img article.Image) error {
+	uploader := common.NewImageUploader(p.page, p.imageUploadConfig(), p)
+	return uploader.UploadAtPlaceholder(placeholder, &img)
+}
+
+// ReplaceTextWithText 查找占位符并替换为文本（图床链接或跳过图片时的 alt 文本）
+func (p *Publisher) ReplaceTextWithText(placeholder, text string) error {
+	return common.ReplaceTextInMarkdownEditor(p.page, selectors.Get(Name, selectors.Editor), placeholder, text)
+}
+
+// imageUploadConfig 博客园的图片上传配置
+func (p *Publisher) imageUploadConfig() common.ImageUploadConfig {
+	return common.ImageUploadConfig{
+		PlatformName:   "博客园",
+		EditorSelector: selectors.Get(Name, selectors.Editor),
 		UploadButtonJs: `
 			(function() {
 				// 第一步：点击上传图片按钮
@@ -128,24 +147,9 @@ func (p *Publisher) fillContentWithImages(art *article.Article) error {
 				return true;
 			})()
 		`,
-		ImageCheckJs: `
-			(function() {
-				// 检查编辑器中是否有图片
-				const editor = document.querySelector('#md-editor');
-				if (editor) {
-					const images = editor.querySelectorAll('img');
-					return images.length > 0;
-				}
-				return false;
-			})()
-		`,
 		UploadTimeout: 15 * time.Second,
 		IntervalDelay: 2 * time.Second,
 	}
-	
-	// 使用通用图片上传器
-	uploader := common.NewImageUploader(p.page, config, p)
-	return uploader.ProcessArticleWithImages(art)
 }
 
 // SetContent 实现EditorHandler接口 - 设置编辑器内容
@@ -153,9 +157,9 @@ func (p *Publisher) SetContent(content string) error {
 	// 博客园的编辑器可能是CodeMirror或其他类型
 	// 尝试多种设置方式
 	jsCode := `
-		(function(content) {
+		(function([content, selector]) {
 			// 尝试1: 直接设置textarea的value
-			const editor = document.querySelector('#md-editor');
+			const editor = document.querySelector(selector);
 			if (editor) {
 				if (editor.tagName.toLowerCase() === 'textarea') {
 					editor.value = content;
@@ -166,7 +170,7 @@ func (p *Publisher) SetContent(content string) error {
 			}
 			
 			// 尝试2: CodeMirror方式
-			const cmElement = document.querySelector('#md-editor .CodeMirror');
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				cmElement.CodeMirror.setValue(content);
 				return true;
@@ -182,7 +186,7 @@ func (p *Publisher) SetContent(content string) error {
 		})
 	`
 	
-	result, err := p.page.Evaluate(jsCode, content)
+	result, err := p.page.Evaluate(jsCode, []interface{}{content, selectors.Get(Name, selectors.Editor)})
 	if err != nil {
 		return fmt.Errorf("设置编辑器内容失败: %v", err)
 	}
@@ -198,8 +202,8 @@ func (p *Publisher) SetContent(content string) error {
 func (p *Publisher) FindAndSelectText(text string) error {
 	// 博客园编辑器的文本查找和选择
 	jsCode := `
-		(function(searchText) {
-			const editor = document.querySelector('#md-editor');
+		(function([searchText, selector]) {
+			const editor = document.querySelector(selector);
 			if (!editor) return false;
 			
 			// 如果是textarea
@@ -214,7 +218,7 @@ func (p *Publisher) FindAndSelectText(text string) error {
 			}
 			
 			// 如果是CodeMirror
-			const cmElement = document.querySelector('#md-editor .CodeMirror');
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				const cm = cmElement.CodeMirror;
 				const content = cm.getValue();
@@ -235,7 +239,7 @@ func (p *Publisher) FindAndSelectText(text string) error {
 		})
 	`
 	
-	result, err := p.page.Evaluate(jsCode, text)
+	result, err := p.page.Evaluate(jsCode, []interface{}{text, selectors.Get(Name, selectors.Editor)})
 	if err != nil {
 		return fmt.Errorf("查找文本失败: %v", err)
 	}
@@ -253,9 +257,9 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 	log.Printf("[博客园] 🔍 开始替换占位符: %s", placeholder)
 	
 	// 1. 使用JavaScript查找并选中占位符
-	jsCode := fmt.Sprintf(`
-		(function(searchText) {
-			const editor = document.querySelector('#md-editor');
+	jsCode := `
+		(function([searchText, selector]) {
+			const editor = document.querySelector(selector);
 			if (!editor) return false;
 			
 			// 如果是textarea
@@ -270,7 +274,7 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 			}
 			
 			// 如果是CodeMirror
-			const cmElement = document.querySelector('#md-editor .CodeMirror');
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				const cm = cmElement.CodeMirror;
 				const content = cm.getValue();
@@ -288,10 +292,10 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 			}
 			
 			return false;
-		})(%q)
-	`, placeholder)
+		})
+	`
 	
-	result, err := p.page.Evaluate(jsCode)
+	result, err := p.page.Evaluate(jsCode, []interface{}{placeholder, selectors.Get(Name, selectors.Editor)})
 	if err != nil {
 		return fmt.Errorf("查找占位符失败: %v", err)
 	}
@@ -313,7 +317,7 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 	}
 	
 	// 4. 粘贴图片到编辑器
-	if err := common.PasteImageToEditor(p.page); err != nil {
+	if err := common.PasteImageToEditor(p.page, selectors.Get(Name, selectors.Editor)); err != nil {
 		return fmt.Errorf("粘贴图片失败: %v", err)
 	}
 	
@@ -334,9 +338,9 @@ func (p *Publisher) waitForImageUploadComplete() error {
 	// 等待图片出现在编辑器中
 	for i := 0; i < 15; i++ { // 最多等待15秒
 		result, err := p.page.Evaluate(`
-			(function() {
+			(function([selector, previewSelector]) {
 				// 检查markdown编辑器中是否有图片
-				const editor = document.querySelector('#md-editor');
+				const editor = document.querySelector(selector);
 				if (editor) {
 					let content = '';
 					
@@ -346,7 +350,7 @@ func (p *Publisher) waitForImageUploadComplete() error {
 					} 
 					// 如果是CodeMirror
 					else {
-						const cmElement = document.querySelector('#md-editor .CodeMirror');
+						const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 						if (cmElement && cmElement.CodeMirror) {
 							content = cmElement.CodeMirror.getValue();
 						}
@@ -361,14 +365,15 @@ func (p *Publisher) waitForImageUploadComplete() error {
 				}
 				
 				// 也检查编辑器渲染区域是否有图片
-				const images = document.querySelectorAll('#md-editor img, .markdown-body img, .editor-preview img');
-				if (images.length > 0) {
-					return { success: true, type: 'rendered', count: images.length };
+				const count = (editor ? editor.querySelectorAll('img').length : 0) +
+					Array.from(document.querySelectorAll(previewSelector)).reduce((sum, el) => sum + el.querySelectorAll('img').length, 0);
+				if (count > 0) {
+					return { success: true, type: 'rendered', count: count };
 				}
 				
 				return { success: false };
-			})()
-		`)
+			})
+		`, []interface{}{selectors.Get(Name, selectors.Editor), selectors.Get(Name, selectors.Preview)})
 		
 		if err != nil {
 			log.Printf("[博客园] 检查图片状态失败: %v", err)
@@ -389,20 +394,14 @@ func (p *Publisher) waitForImageUploadComplete() error {
 // WaitForEditor 等待编辑器加载完成
 func (p *Publisher) WaitForEditor() error {
 	// 等待标题输入框
-	titleLocator := p.page.Locator("#post-title")
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(15000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	titleLocator := p.page.Locator(selectors.Get(Name, selectors.Title))
+	if err := common.WaitVisible(titleLocator, 15*time.Second); err != nil {
 		return fmt.Errorf("等待标题输入框超时: %v", err)
 	}
 	
 	// 等待编辑器
-	editorLocator := p.page.Locator("#md-editor")
-	if err := editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(15000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	editorLocator := p.page.Locator(selectors.Get(Name, selectors.Editor))
+	if err := common.WaitVisible(editorLocator, 15*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器超时: %v", err)
 	}
 	
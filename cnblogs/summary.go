@@ -0,0 +1,15 @@
+package cnblogs
+
+import (
+	"fmt"
+
+	"github.com/auto-blog/common"
+)
+
+// SetSummary 在编辑页的"摘要"中填写文章摘要
+func (p *Publisher) SetSummary(summary string) error {
+	if err := common.FillLabeledTextarea(p.page, "body", "摘要", summary); err != nil {
+		return fmt.Errorf("填写摘要失败: %v", err)
+	}
+	return nil
+}
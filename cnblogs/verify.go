@@ -0,0 +1,21 @@
+package cnblogs
+
+import (
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+)
+
+// 博客园后台的草稿列表和随笔列表
+const (
+	draftsURL   = "https://i.cnblogs.com/posts?isPublished=false"
+	articlesURL = "https://i.cnblogs.com/posts"
+)
+
+// VerifySubmitted 提交后打开草稿箱或随笔列表，确认文章确实出现且标题匹配
+func (p *Publisher) VerifySubmitted(title string, mode platform.PublishMode) (string, error) {
+	listURL := draftsURL
+	if mode == platform.PublishModePublish {
+		listURL = articlesURL
+	}
+	return common.VerifyArticleListed(p.page, Name, listURL, title)
+}
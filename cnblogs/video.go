@@ -0,0 +1,16 @@
+package cnblogs
+
+import (
+	"fmt"
+
+	"github.com/auto-blog/article"
+)
+
+// VideoEmbedCode 博客园的 Markdown 正文支持 HTML，YouTube 和 Bilibili 视频转为播放器 iframe
+func (p *Publisher) VideoEmbedCode(video article.Video) string {
+	embedURL := video.EmbedURL()
+	if embedURL == "" {
+		return ""
+	}
+	return fmt.Sprintf(`<iframe src="%s" width="640" height="360" frameborder="0" allowfullscreen></iframe>`, embedURL)
+}
@@ -0,0 +1,58 @@
+package cnblogs
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/auto-blog/platform"
+)
+
+// SupportsVisibility 博客园只能设置公开或仅自己可见
+func (p *Publisher) SupportsVisibility(visibility platform.Visibility) bool {
+	return visibility == platform.VisibilityPublic || visibility == platform.VisibilityPrivate
+}
+
+// SetVisibility 勾选或取消编辑页"其他选项"中的"仅自己可见"
+func (p *Publisher) SetVisibility(visibility platform.Visibility) error {
+	result, err := p.page.Evaluate(`
+		(private) => {
+			const isVisible = (el) => el && el.offsetParent !== null;
+			const textOf = (el) => (el.innerText || el.textContent || '').trim();
+
+			const label = Array.from(document.querySelectorAll('label, span, div'))
+				.filter(el => isVisible(el) && textOf(el).includes('仅自己可见') && textOf(el).length < 20)
+				.sort((a, b) => textOf(a).length - textOf(b).length)[0];
+			if (!label) {
+				return { success: false, error: '未找到"仅自己可见"选项' };
+			}
+			const checkbox = label.querySelector('input[type="checkbox"]') ||
+				(label.htmlFor && document.getElementById(label.htmlFor)) ||
+				(label.parentElement && label.parentElement.querySelector('input[type="checkbox"]'));
+			if (!checkbox) {
+				return { success: false, error: '"仅自己可见"选项中没有复选框' };
+			}
+			if (checkbox.checked !== private) {
+				checkbox.click();
+			}
+			return { success: checkbox.checked === private };
+		}
+	`, visibility == platform.VisibilityPrivate)
+	if err != nil {
+		return fmt.Errorf("设置可见范围失败: %v", err)
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("设置可见范围返回了无法识别的结果: %v", result)
+	}
+	if success, _ := resultMap["success"].(bool); !success {
+		errorMsg, _ := resultMap["error"].(string)
+		if errorMsg == "" {
+			errorMsg = "勾选\"仅自己可见\"后状态未改变"
+		}
+		return fmt.Errorf("%s", errorMsg)
+	}
+
+	log.Printf("[博客园] [发布设置] 可见范围 -> %s", visibility)
+	return nil
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// selectAllKey 全选快捷键，macOS 使用 Command
+func selectAllKey() string {
+	if runtime.GOOS == "darwin" {
+		return "Meta+a"
+	}
+	return "Control+a"
+}
+
+// clearEditorScript 按编辑器类型清空内容：CodeMirror 调用 setValue 置空，textarea 通过原生 setter 置空并触发 input 事件，
+// 可编辑区域（知乎、ProseMirror 等）用 selectNodeContents 选中全部节点后 execCommand('delete')，
+// 让编辑器框架收到删除事件同步内部状态。Ctrl+A 在部分编辑器中只选中当前段落，不能依赖
+const clearEditorScript = `(selector) => {
+	const editor = document.querySelector(selector);
+	if (!editor) return { success: false, error: '未找到编辑器' };
+
+	const cmElement = editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror');
+	if (cmElement && cmElement.CodeMirror) {
+		cmElement.CodeMirror.setValue('');
+		cmElement.CodeMirror.focus();
+		return { success: true, method: 'codemirror' };
+	}
+
+	const textarea = editor.tagName === 'TEXTAREA' ? editor : null;
+	if (textarea) {
+		const setter = Object.getOwnPropertyDescriptor(HTMLTextAreaElement.prototype, 'value').set;
+		textarea.focus();
+		setter.call(textarea, '');
+		textarea.dispatchEvent(new Event('input', { bubbles: true }));
+		return { success: true, method: 'textarea' };
+	}
+
+	const target = editor.isContentEditable ? editor : (editor.querySelector('[contenteditable="true"]') || editor);
+	target.focus({ preventScroll: true });
+	const range = document.createRange();
+	range.selectNodeContents(target);
+	const selection = window.getSelection();
+	selection.removeAllRanges();
+	selection.addRange(range);
+	document.execCommand('delete');
+	return { success: true, method: 'selection' };
+}`
+
+// editorTextScript 读取编辑器当前的文本：CodeMirror 读取 getValue()，textarea 读取 value，其它读取 innerText
+const editorTextScript = `(selector) => {
+	const editor = document.querySelector(selector);
+	if (!editor) return '';
+	const cmElement = editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror');
+	if (cmElement && cmElement.CodeMirror) return cmElement.CodeMirror.getValue();
+	if (editor.tagName === 'TEXTAREA') return editor.value;
+	return editor.innerText || '';
+}`
+
+// EditorText 读取编辑器当前的文本内容
+func EditorText(page playwright.Page, editorSelector string) (string, error) {
+	result, err := page.Evaluate(editorTextScript, editorSelector)
+	if err != nil {
+		return "", fmt.Errorf("读取编辑器内容失败: %v", err)
+	}
+	text, _ := result.(string)
+	return text, nil
+}
+
+// ClearEditor 可靠地清空编辑器：先按编辑器类型用脚本清空，仍有残留时再用全选删除兜底，
+// 最后确认编辑器为空。填入新内容前调用，避免新内容追加到旧内容后面
+func ClearEditor(page playwright.Page, editorSelector string) error {
+	if err := EnsureEditorFocus(page, editorSelector); err != nil {
+		return err
+	}
+	HumanPause()
+
+	if _, err := page.Evaluate(clearEditorScript, editorSelector); err != nil {
+		log.Printf("⚠️ 脚本清空编辑器失败，改用全选删除: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	remaining, err := editorRemaining(page, editorSelector)
+	if err != nil {
+		return err
+	}
+	if remaining == 0 {
+		return nil
+	}
+
+	// 兜底：全选后删除（部分编辑器的全选只选中当前段落，重复几次）
+	for attempt := 1; attempt <= focusAttempts && remaining > 0; attempt++ {
+		if err := page.Keyboard().Press(selectAllKey()); err != nil {
+			return fmt.Errorf("全选编辑器内容失败: %v", err)
+		}
+		if err := page.Keyboard().Press("Backspace"); err != nil {
+			return fmt.Errorf("删除编辑器内容失败: %v", err)
+		}
+		time.Sleep(300 * time.Millisecond)
+		if remaining, err = editorRemaining(page, editorSelector); err != nil {
+			return err
+		}
+	}
+	if remaining > 0 {
+		return fmt.Errorf("编辑器未能清空，仍有 %d 个字符", remaining)
+	}
+	return nil
+}
+
+// editorRemaining 返回编辑器中剩余的字符数（忽略空白和空 CodeMirror 中的零宽空格占位）
+func editorRemaining(page playwright.Page, editorSelector string) (int, error) {
+	text, err := EditorText(page, editorSelector)
+	if err != nil {
+		return 0, err
+	}
+	return len([]rune(strings.Trim(text, " \t\r\n\u200b"))), nil
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/auto-blog/tempfiles"
+	"github.com/auto-blog/utils"
+	"github.com/playwright-community/playwright-go"
+)
+
+// maxClipboardImageSize 通过剪贴板复制的图片大小上限。剪贴板 API 需要把整张图片转成 base64 data URL
+// 传入页面，几十 MB 的图片会占用大量内存，甚至导致 Evaluate 失败
+const maxClipboardImageSize = 8 << 20
+
+// ImageTooLargeForClipboard 判断图片是否超过剪贴板复制的大小上限，读取文件信息失败时按未超限处理
+func ImageTooLargeForClipboard(imagePath string) bool {
+	info, err := os.Stat(imagePath)
+	return err == nil && info.Size() > maxClipboardImageSize
+}
+
+// shrinkForClipboard 图片超过剪贴板大小上限时压缩到缓存目录，返回用于复制的图片路径
+func shrinkForClipboard(imagePath string) (string, error) {
+	if !ImageTooLargeForClipboard(imagePath) {
+		return imagePath, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("无法确定图片缓存目录: %v", err)
+	}
+	cacheDir := filepath.Join(homeDir, ".auto-blog", "cache", "images")
+
+	shrunk, ok, err := utils.ShrinkImage(imagePath, cacheDir, maxClipboardImageSize)
+	if err != nil {
+		return "", fmt.Errorf("图片超过 %d MB 且压缩失败: %v", maxClipboardImageSize>>20, err)
+	}
+	if !ok {
+		return "", fmt.Errorf("图片超过 %d MB 且无法压缩（如 GIF 动图），请改用 upload 图片策略", maxClipboardImageSize>>20)
+	}
+	tempfiles.Register(shrunk)
+	log.Printf("📎 图片超过 %d MB，已压缩后复制: %s", maxClipboardImageSize>>20, shrunk)
+	return shrunk, nil
+}
+
+// ProbeClipboard 探测页面能否通过 navigator.clipboard 写入图片，不可用时返回原因。
+// 无头模式、非安全上下文或权限被系统拒绝时，即使浏览器上下文申请了剪贴板权限，写入仍会失败
+func ProbeClipboard(page playwright.Page) error {
+	result, err := page.Evaluate(`
+		async () => {
+			if (!window.isSecureContext) return '页面不是安全上下文（https），剪贴板 API 不可用';
+			if (!navigator.clipboard || !navigator.clipboard.write || typeof ClipboardItem === 'undefined') {
+				return '浏览器不支持剪贴板 API';
+			}
+			if (navigator.permissions && navigator.permissions.query) {
+				try {
+					const status = await navigator.permissions.query({ name: 'clipboard-write' });
+					if (status.state === 'denied') return '剪贴板写入权限被拒绝';
+				} catch (e) {
+					// 部分环境不支持查询剪贴板权限，按可用处理
+				}
+			}
+			return '';
+		}
+	`)
+	if err != nil {
+		return fmt.Errorf("探测剪贴板失败: %v", err)
+	}
+	if reason, _ := result.(string); reason != "" {
+		return fmt.Errorf("%s", reason)
+	}
+	return nil
+}
+
+// copyImageWithSystemClipboard 通过操作系统剪贴板复制图片（剪贴板 API 不可用时的回退）
+func copyImageWithSystemClipboard(page playwright.Page, imagePath string) error {
+	if err := utils.NewImageProcessor(page).CopyImageToClipboard(imagePath); err != nil {
+		return fmt.Errorf("系统剪贴板也无法复制图片: %v", err)
+	}
+	log.Printf("📎 ✅ 图片已通过系统剪贴板复制")
+	return nil
+}
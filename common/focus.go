@@ -0,0 +1,103 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// focusAttempts 确认页面或编辑器焦点的最大尝试次数
+const focusAttempts = 3
+
+// EnsurePageActive 将页面切到前台并确认其确实处于可见状态。
+// 多个平台页面交替粘贴时 BringToFront 偶尔不生效，粘贴会落到其它标签页
+func EnsurePageActive(page playwright.Page) error {
+	for attempt := 1; attempt <= focusAttempts; attempt++ {
+		if err := page.BringToFront(); err != nil {
+			return fmt.Errorf("切换到目标页面失败: %v", err)
+		}
+		visible, err := page.Evaluate(`() => document.visibilityState === 'visible'`)
+		if err != nil {
+			return fmt.Errorf("检查页面状态失败: %v", err)
+		}
+		if ok, _ := visible.(bool); ok {
+			return nil
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+	return fmt.Errorf("目标页面未能切换到前台")
+}
+
+// EnsureEditorFocus 确认页面在前台且编辑器获得焦点，焦点不在编辑器内时点击编辑器重新聚焦。
+// 会移动光标，只能在整体粘贴前使用；已选中占位符时应使用 EnsureSelectionFocus
+func EnsureEditorFocus(page playwright.Page, editorSelector string) error {
+	if err := EnsurePageActive(page); err != nil {
+		return err
+	}
+
+	editor := page.Locator(editorSelector).First()
+	for attempt := 1; attempt <= focusAttempts; attempt++ {
+		focused, err := editorHasFocus(page, editorSelector)
+		if err != nil {
+			return err
+		}
+		if focused {
+			return nil
+		}
+		if err := editor.Click(); err != nil {
+			return fmt.Errorf("点击编辑器失败: %v", err)
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+	return fmt.Errorf("编辑器未能获得焦点")
+}
+
+// EnsureSelectionFocus 确认页面在前台且编辑器获得焦点，焦点丢失时通过 focus() 恢复，
+// 不点击编辑器，保留已选中的占位符
+func EnsureSelectionFocus(page playwright.Page, editorSelector string) error {
+	if err := EnsurePageActive(page); err != nil {
+		return err
+	}
+
+	for attempt := 1; attempt <= focusAttempts; attempt++ {
+		focused, err := editorHasFocus(page, editorSelector)
+		if err != nil {
+			return err
+		}
+		if focused {
+			return nil
+		}
+		if _, err := page.Evaluate(`(selector) => {
+			const editor = document.querySelector(selector);
+			if (!editor) return;
+			const cmElement = editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror');
+			if (cmElement && cmElement.CodeMirror) {
+				cmElement.CodeMirror.focus();
+				return;
+			}
+			const target = editor.querySelector('textarea, [contenteditable="true"]') || editor;
+			target.focus({ preventScroll: true });
+		}`, editorSelector); err != nil {
+			return fmt.Errorf("聚焦编辑器失败: %v", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return fmt.Errorf("编辑器未能获得焦点")
+}
+
+// editorHasFocus 判断当前焦点元素是否为编辑器或位于编辑器内部（CodeMirror 的焦点在其内部隐藏的 textarea 上）
+func editorHasFocus(page playwright.Page, editorSelector string) (bool, error) {
+	result, err := page.Evaluate(`(selector) => {
+		const editor = document.querySelector(selector);
+		const active = document.activeElement;
+		if (!editor || !active) return false;
+		const root = editor.closest('.CodeMirror') || editor;
+		return root === active || root.contains(active);
+	}`, editorSelector)
+	if err != nil {
+		return false, fmt.Errorf("检查编辑器焦点失败: %v", err)
+	}
+	focused, _ := result.(bool)
+	return focused, nil
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// DefaultActionDelay 拟人化操作前的默认平均停顿
+const DefaultActionDelay = 200 * time.Millisecond
+
+// keystrokeDivisor 逐字输入时每个字的平均间隔为操作停顿的几分之一
+const keystrokeDivisor = 4
+
+// actionDelay 拟人化操作前的平均停顿（[browser] humanize_delay），按纳秒存储以便原子读写
+var actionDelay atomic.Int64
+
+func init() {
+	actionDelay.Store(int64(DefaultActionDelay))
+}
+
+// SetActionDelay 设置拟人化操作前的平均停顿，0 表示关闭拟人化节流，负数按 0 处理
+func SetActionDelay(delay time.Duration) {
+	if delay < 0 {
+		delay = 0
+	}
+	actionDelay.Store(int64(delay))
+}
+
+// jittered 返回在 base 的 50%~150% 之间随机抖动的时长
+func jittered(base time.Duration) time.Duration {
+	return base/2 + time.Duration(rand.Int63n(int64(base)+1))
+}
+
+// HumanPause 在两次浏览器操作之间插入随机停顿，平均为全局停顿时长，未开启拟人化节流时立即返回
+func HumanPause() {
+	if base := time.Duration(actionDelay.Load()); base > 0 {
+		time.Sleep(jittered(base))
+	}
+}
+
+// HumanClick 随机停顿后点击元素
+func HumanClick(locator playwright.Locator) error {
+	HumanPause()
+	return locator.Click()
+}
+
+// HumanType 随机停顿后逐字输入文字，字与字之间的间隔随机抖动，适合标题等短文本；
+// 未开启拟人化节流时一次性输入。长正文请继续使用各平台的输入方式，逐字输入会很慢
+func HumanType(page playwright.Page, text string) error {
+	HumanPause()
+	base := time.Duration(actionDelay.Load()) / keystrokeDivisor
+	if base <= 0 {
+		return page.Keyboard().Type(text)
+	}
+	for _, r := range text {
+		if err := page.Keyboard().Type(string(r)); err != nil {
+			return err
+		}
+		time.Sleep(jittered(base))
+	}
+	return nil
+}
+
+// HumanGoto 随机停顿后打开页面
+func HumanGoto(page playwright.Page, url string) (playwright.Response, error) {
+	HumanPause()
+	return page.Goto(url)
+}
@@ -0,0 +1,134 @@
+package common
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// listItemRegex 匹配列表项：缩进 + 标记（-/*/+ 或 1. / 1)）+ 空白 + 内容
+var listItemRegex = regexp.MustCompile(`^([ \t]*)([-*+]|\d+[.)])[ \t]+(.*)$`)
+
+// listItem 列表项
+type listItem struct {
+	indent  int    // 缩进宽度（Tab 按 4 个空格计）
+	ordered bool   // 是否有序列表
+	text    string // 列表项内容
+}
+
+// listLevel 已打开的列表层级
+type listLevel struct {
+	indent  int
+	ordered bool
+}
+
+// parseListItem 解析列表项，不是列表项时返回 false
+func parseListItem(line string) (listItem, bool) {
+	// 分隔线（如 "- - -"、"* * *"）不是列表
+	if isHorizontalRule(strings.TrimSpace(line)) {
+		return listItem{}, false
+	}
+
+	match := listItemRegex.FindStringSubmatch(line)
+	if match == nil {
+		return listItem{}, false
+	}
+
+	indent := 0
+	for _, ch := range match[1] {
+		if ch == '\t' {
+			indent += 4
+		} else {
+			indent++
+		}
+	}
+
+	marker := match[2]
+	return listItem{
+		indent:  indent,
+		ordered: marker[0] >= '0' && marker[0] <= '9',
+		text:    match[3],
+	}, true
+}
+
+// isHorizontalRule 判断是否为分割线：3 个及以上相同的 -、* 或 _，中间可夹空格
+func isHorizontalRule(trimmed string) bool {
+	if trimmed == "" {
+		return false
+	}
+	marker := trimmed[0]
+	if marker != '-' && marker != '*' && marker != '_' {
+		return false
+	}
+	count := 0
+	for i := 0; i < len(trimmed); i++ {
+		switch trimmed[i] {
+		case marker:
+			count++
+		case ' ', '\t':
+		default:
+			return false
+		}
+	}
+	return count >= 3
+}
+
+// listRenderer 把连续的列表项渲染为嵌套的 <ul>/<ol>
+type listRenderer struct {
+	stack []listLevel
+}
+
+// add 写入一个列表项，根据缩进自动打开/关闭嵌套列表
+func (r *listRenderer) add(b *strings.Builder, item listItem) {
+	// 关闭比当前项缩进更深的列表
+	for len(r.stack) > 0 && r.stack[len(r.stack)-1].indent > item.indent {
+		r.closeLevel(b)
+	}
+
+	if len(r.stack) > 0 && r.stack[len(r.stack)-1].indent == item.indent {
+		if r.stack[len(r.stack)-1].ordered == item.ordered {
+			// 同级列表项
+			b.WriteString("</li>")
+		} else {
+			// 同级但列表类型变化，开始新列表
+			r.closeLevel(b)
+			r.openLevel(b, item)
+		}
+	} else {
+		// 第一项或更深一级的嵌套列表
+		r.openLevel(b, item)
+	}
+
+	b.WriteString("<li>" + html.EscapeString(item.text))
+}
+
+// active 是否有未关闭的列表
+func (r *listRenderer) active() bool {
+	return len(r.stack) > 0
+}
+
+// close 关闭所有未关闭的列表
+func (r *listRenderer) close(b *strings.Builder) {
+	for len(r.stack) > 0 {
+		r.closeLevel(b)
+	}
+}
+
+func (r *listRenderer) openLevel(b *strings.Builder, item listItem) {
+	if item.ordered {
+		b.WriteString("<ol>")
+	} else {
+		b.WriteString("<ul>")
+	}
+	r.stack = append(r.stack, listLevel{indent: item.indent, ordered: item.ordered})
+}
+
+func (r *listRenderer) closeLevel(b *strings.Builder) {
+	top := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+	if top.ordered {
+		b.WriteString("</li></ol>")
+	} else {
+		b.WriteString("</li></ul>")
+	}
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/auto-blog/article"
+	"github.com/playwright-community/playwright-go"
+)
+
+// MarkdownRender 粘贴后渲染区域中的 Markdown 结构，用于判断平台是否解析了粘贴的 Markdown
+type MarkdownRender struct {
+	Found       bool // 是否找到渲染区域（掘金的预览区可能被关闭）
+	Headings    int  // 渲染出的标题元素数
+	CodeBlocks  int  // 渲染出的代码块数
+	RawHeadings int  // 代码块外仍以 # 开头的文本行数，即原样显示的 Markdown 标题
+}
+
+// inspectMarkdownScript 统计渲染区域中的标题、代码块和原样显示的 # 标题行（先去掉代码块，避免把注释算进去）
+const inspectMarkdownScript = `(selector) => {
+	const root = document.querySelector(selector);
+	if (!root) return { found: false };
+	const clone = root.cloneNode(true);
+	clone.querySelectorAll('pre, code').forEach(el => el.remove());
+	const text = clone.innerText || clone.textContent || '';
+	const rawHeadings = text.split('\n').filter(line => /^\s*#{1,6}\s+\S/.test(line)).length;
+	return {
+		found: true,
+		headings: root.querySelectorAll('h1, h2, h3, h4, h5, h6').length,
+		codeBlocks: root.querySelectorAll('pre').length,
+		rawHeadings: rawHeadings,
+	};
+}`
+
+// InspectMarkdownRender 统计 rootSelector 区域（知乎的编辑器、掘金的预览区）中渲染出的 Markdown 结构
+func InspectMarkdownRender(page playwright.Page, rootSelector string) (MarkdownRender, error) {
+	result, err := page.Evaluate(inspectMarkdownScript, rootSelector)
+	if err != nil {
+		return MarkdownRender{}, fmt.Errorf("检查 Markdown 渲染结果失败: %v", err)
+	}
+	values, _ := result.(map[string]interface{})
+	render := MarkdownRender{}
+	render.Found, _ = values["found"].(bool)
+	if headings, ok := values["headings"].(float64); ok {
+		render.Headings = int(headings)
+	}
+	if codeBlocks, ok := values["codeBlocks"].(float64); ok {
+		render.CodeBlocks = int(codeBlocks)
+	}
+	if rawHeadings, ok := values["rawHeadings"].(float64); ok {
+		render.RawHeadings = int(rawHeadings)
+	}
+	return render, nil
+}
+
+// Parsed 对照文章结构判断 Markdown 是否已被解析：有原样显示的 # 标题行、渲染出的标题不到一半，
+// 或文章有代码块却一个也没渲染出来时视为未解析。文章没有标题和代码块时无从判断，视为已解析
+func (r MarkdownRender) Parsed(stats article.Stats) bool {
+	headings := stats.HeadingCount()
+	if r.RawHeadings > 0 {
+		return false
+	}
+	if headings > 0 && r.Headings*2 < headings {
+		return false
+	}
+	return stats.CodeBlocks == 0 || r.CodeBlocks > 0
+}
+
+// Describe 渲染结果的简短描述，用于日志
+func (r MarkdownRender) Describe(stats article.Stats) string {
+	return fmt.Sprintf("标题 %d/%d，代码块 %d/%d，原样显示的 # 标题行 %d", r.Headings, stats.HeadingCount(), r.CodeBlocks, stats.CodeBlocks, r.RawHeadings)
+}
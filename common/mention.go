@@ -0,0 +1,64 @@
+package common
+
+import (
+	"log"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/playwright-community/playwright-go"
+)
+
+// mentionTypeDelay 输入提及和话题时每个字符的间隔（毫秒），逐字输入才能触发平台的候选下拉框
+const mentionTypeDelay = 120
+
+// mentionOptionTimeout 输入后等待候选下拉框出现的最长时间
+const mentionOptionTimeout = 5 * time.Second
+
+// ApplyMentions 把富文本编辑器中的提及/话题占位符（article.MentionPlaceholderFor）按编号顺序替换为平台的提及和话题：
+// 选中占位符后逐字输入 @用户名 或 #话题，等待候选下拉框中的第一项（optionSelector）出现并点击；
+// 没有候选项（如用户不存在）时补全为纯文本。单个标记失败不影响其它标记
+func ApplyMentions(page playwright.Page, platformName, editorSelector, optionSelector string, mentions []article.Mention) {
+	if len(mentions) == 0 {
+		return
+	}
+	log.Printf("[%s] 👥 开始处理 %d 个提及/话题", platformName, len(mentions))
+
+	applied := 0
+	for i, mention := range mentions {
+		placeholder := article.MentionPlaceholderFor(i)
+		if err := SelectTextInRichEditor(page, editorSelector, placeholder); err != nil {
+			log.Printf("[%s] ⚠️ 未找到占位符 %s，跳过 %s: %v", platformName, placeholder, mention.Text(), err)
+			continue
+		}
+		if applyMention(page, optionSelector, mention) {
+			applied++
+			continue
+		}
+		log.Printf("[%s] ⚠️ %s 没有出现候选项，保留为纯文本", platformName, mention.Text())
+	}
+	log.Printf("[%s] ✅ 已转换 %d/%d 个提及/话题", platformName, applied, len(mentions))
+}
+
+// applyMention 在选中的占位符处输入标记并选择候选项，返回是否选中了候选项
+func applyMention(page playwright.Page, optionSelector string, mention article.Mention) bool {
+	trigger := "@"
+	if mention.Kind == article.MentionTopic {
+		trigger = "#"
+	}
+	// 选中状态下输入会替换占位符
+	typeOptions := playwright.KeyboardTypeOptions{Delay: playwright.Float(mentionTypeDelay)}
+	if err := page.Keyboard().Type(trigger+mention.Name, typeOptions); err != nil {
+		return false
+	}
+
+	option := page.Locator(optionSelector).First()
+	if optionSelector == "" || WaitVisible(option, mentionOptionTimeout) != nil || option.Click() != nil {
+		// 话题补上结尾的 #，保持与原文一致
+		if mention.Kind == article.MentionTopic {
+			page.Keyboard().Type("#", typeOptions)
+		}
+		return false
+	}
+	time.Sleep(300 * time.Millisecond)
+	return true
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"encoding/base64"
 	"fmt"
+	"html"
 	"log"
 	"os"
 	"path/filepath"
@@ -17,8 +18,9 @@ import (
 type InputMethod string
 
 const (
-	InputMethodPaste InputMethod = "paste" // 粘贴方式（知乎）
-	InputMethodType  InputMethod = "type"  // 打字方式（掘金、博客园）
+	InputMethodPaste    InputMethod = "paste"    // 粘贴方式（知乎，掘金可配置）
+	InputMethodType     InputMethod = "type"     // 打字方式（掘金、博客园）
+	InputMethodRichText InputMethod = "richtext" // 转换为 HTML 后粘贴富文本（头条号、百家号）
 )
 
 // RichContentConfig 富文本内容配置
@@ -55,14 +57,11 @@ func (h *RichContentHandler) FillTitle(title string) error {
 	log.Printf("[%s] 开始填写标题: %s", h.config.PlatformName, title)
 	
 	titleLocator := h.page.Locator(h.config.TitleSelector)
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := WaitVisible(titleLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("标题输入框未出现: %v", err)
 	}
 
-	if err := titleLocator.Click(); err != nil {
+	if err := HumanClick(titleLocator); err != nil {
 		return fmt.Errorf("点击标题输入框失败: %v", err)
 	}
 
@@ -84,6 +83,8 @@ func (h *RichContentHandler) FillContent(art *article.Article) error {
 		return h.fillContentWithPaste(art)
 	case InputMethodType:
 		return h.fillContentWithType(art)
+	case InputMethodRichText:
+		return h.fillContentWithRichText(art)
 	default:
 		// 默认使用粘贴方式
 		log.Printf("[%s] ⚠️ 未指定输入方式，使用默认粘贴方式", h.config.PlatformName)
@@ -104,6 +105,7 @@ func (h *RichContentHandler) fillContentWithPaste(art *article.Article) error {
 	if err != nil {
 		return fmt.Errorf("创建临时页面失败: %v", err)
 	}
+	defer CloseTempPage(tempPage)
 	log.Printf("[%s] ✅ Step 2: 临时窗口已创建并加载内容", h.config.PlatformName)
 	
 	// 保持窗口打开一段时间让内容渲染
@@ -111,13 +113,12 @@ func (h *RichContentHandler) fillContentWithPaste(art *article.Article) error {
 	
 	// Step 3: 在临时窗口中全选并复制内容
 	if err := h.SelectAndCopyContent(tempPage); err != nil {
-		tempPage.Close()
 		return fmt.Errorf("复制内容失败: %v", err)
 	}
 	log.Printf("[%s] ✅ Step 3: 内容已复制到剪贴板", h.config.PlatformName)
 	
 	// 关闭临时页面
-	tempPage.Close()
+	CloseTempPage(tempPage)
 	log.Printf("[%s] 📄 临时页面已关闭", h.config.PlatformName)
 	
 	// Step 4: 切换回目标页面并粘贴内容
@@ -149,19 +150,19 @@ func (h *RichContentHandler) fillContentWithType(art *article.Article) error {
 	
 	// Step 1: 等待编辑器准备好
 	editorLocator := h.page.Locator(h.config.EditorSelector)
-	if err := editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := WaitVisible(editorLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器失败: %v", err)
 	}
 	log.Printf("[%s] ✅ Step 1: 编辑器已准备好", h.config.PlatformName)
 	
-	// Step 2: 点击编辑器获取焦点
+	// Step 2: 点击编辑器获取焦点，清空已有内容
 	if err := editorLocator.Click(); err != nil {
 		return fmt.Errorf("点击编辑器失败: %v", err)
 	}
 	time.Sleep(500 * time.Millisecond)
+	if err := ClearEditor(h.page, h.config.EditorSelector); err != nil {
+		return fmt.Errorf("清空编辑器失败: %v", err)
+	}
 	
 	// Step 3: 准备带占位符的纯文本内容
 	textWithPlaceholders := h.PrepareTextWithPlaceholders(art)
@@ -177,6 +178,36 @@ func (h *RichContentHandler) fillContentWithType(art *article.Article) error {
 	return nil
 }
 
+// fillContentWithRichText 把文章转换为 HTML 富文本后粘贴（头条号、百家号方式），
+// 适用于不解析 Markdown 的富文本编辑器
+func (h *RichContentHandler) fillContentWithRichText(art *article.Article) error {
+	log.Printf("[%s] 🚀 使用富文本粘贴方式发布文章", h.config.PlatformName)
+
+	richContent, err := h.prepareRichContent(art)
+	if err != nil {
+		return fmt.Errorf("准备富文本内容失败: %v", err)
+	}
+
+	tempPage, err := h.createTempPage(richContent)
+	if err != nil {
+		return fmt.Errorf("创建临时页面失败: %v", err)
+	}
+	defer CloseTempPage(tempPage)
+	time.Sleep(1 * time.Second)
+
+	if err := h.SelectAndCopyContent(tempPage); err != nil {
+		return fmt.Errorf("复制内容失败: %v", err)
+	}
+	CloseTempPage(tempPage)
+	log.Printf("[%s] ✅ 富文本内容已复制到剪贴板", h.config.PlatformName)
+
+	if err := h.PasteToEditor(); err != nil {
+		return fmt.Errorf("粘贴内容失败: %v", err)
+	}
+	log.Printf("[%s] 🎉 富文本粘贴完成", h.config.PlatformName)
+	return nil
+}
+
 // prepareRichContent 准备富文本内容
 func (h *RichContentHandler) prepareRichContent(art *article.Article) (string, error) {
 	var htmlContent strings.Builder
@@ -186,67 +217,92 @@ func (h *RichContentHandler) prepareRichContent(art *article.Article) (string, e
 	// HTML 开头
 	htmlContent.WriteString("<div>")
 	
-	// 添加标题（如果需要）
-	if !h.config.UseMarkdownMode {
-		htmlContent.WriteString(fmt.Sprintf("<h1>%s</h1>", art.Title))
-	}
-	
 	// 处理内容行
+	var lists listRenderer
+	inCodeBlock := false
+	var codeFence article.CodeFence
+	var codeLines []string
+	htmlBlockEnd := -1
+	admonitionEnd := -1
 	for i, line := range art.Content {
-		// 检查是否是图片行
-		isImageLine := false
-		for _, img := range art.Images {
-			if img.LineIndex == i {
-				// 读取图片并转换为base64
-				imageData, err := os.ReadFile(img.AbsolutePath)
-				if err != nil {
-					log.Printf("[%s] ⚠️ 读取图片失败: %s, %v", h.config.PlatformName, img.AbsolutePath, err)
-					// 如果图片读取失败，用文本代替
-					htmlContent.WriteString(fmt.Sprintf("<p>[图片：%s]</p>", img.AltText))
-				} else {
-					// 检测图片格式
-					var mimeType string
-					if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".png") {
-						mimeType = "image/png"
-					} else if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpg") || 
-							strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpeg") {
-						mimeType = "image/jpeg"
-					} else {
-						mimeType = "image/png"
-					}
-					
-					// 转换为base64并嵌入HTML
-					base64Data := base64.StdEncoding.EncodeToString(imageData)
-					dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
-					
-					htmlContent.WriteString(fmt.Sprintf(`<img src="%s" alt="%s" style="max-width:100%%;" />`, 
-						dataURL, img.AltText))
-					
-					log.Printf("[%s] 🖼️ 嵌入图片: %s (%d bytes)", h.config.PlatformName, img.AltText, len(imageData))
-				}
-				isImageLine = true
-				break
+		trimmedLine := strings.TrimSpace(line)
+		
+		// 代码块：收集代码行，结束时整体输出。info string 中只取语言名，
+		// 额外参数（如 ```js {run}）富文本无法表达，直接忽略
+		if fence, ok := article.ParseCodeFence(trimmedLine); ok && (!inCodeBlock || fence.Closes(codeFence)) {
+			if !inCodeBlock {
+				lists.close(&htmlContent)
+				inCodeBlock = true
+				codeFence = fence
+				codeLines = codeLines[:0]
+			} else {
+				inCodeBlock = false
+				writeCodeBlock(&htmlContent, codeFence.Language, codeLines)
 			}
+			continue
+		}
+		if inCodeBlock {
+			codeLines = append(codeLines, line)
+			continue
 		}
 		
-		if !isImageLine && strings.TrimSpace(line) != "" {
-			// 处理普通文本行
-			htmlLine := line
+		// HTML 原样块（如 <details>、<iframe>）：原样输出，不转义也不包段落
+		if i < htmlBlockEnd {
+			htmlContent.WriteString(h.rawHTMLLine(art, i) + "\n")
+			continue
+		}
+		if end := article.HTMLBlockEnd(art.Content, i); end > 0 {
+			lists.close(&htmlContent)
+			htmlBlockEnd = end
+			htmlContent.WriteString(h.rawHTMLLine(art, i) + "\n")
+			continue
+		}
+		
+		// 提示框（> [!NOTE]、:::tip）：整体输出为带样式的引用块
+		if i < admonitionEnd {
+			continue
+		}
+		if adm, ok := article.AdmonitionAt(art.Content, i); ok {
+			lists.close(&htmlContent)
+			admonitionEnd = adm.End
+			writeAdmonition(&htmlContent, adm, art.RenderAdmonitionBody(adm, html.EscapeString, h.imageHTML))
+			continue
+		}
+		
+		// 列表识别（代码块已在上面处理）
+		if item, ok := parseListItem(line); ok && len(art.ImagesOnLine(i)) == 0 {
+			lists.add(&htmlContent, item)
+			continue
+		}
+		// 空行不打断列表，其它内容结束列表
+		if lists.active() && trimmedLine != "" {
+			lists.close(&htmlContent)
+		}
+		
+		// 独立成行的分割线（前一行为空，避免与 setext 标题下划线混淆）
+		if isHorizontalRule(trimmedLine) && (i == 0 || strings.TrimSpace(art.Content[i-1]) == "") {
+			htmlContent.WriteString("<hr>")
+			continue
+		}
+		
+		// 只有图片的行每张图片独立成段
+		if art.IsImageOnlyLine(i) {
+			for _, index := range art.ImagesOnLine(i) {
+				htmlContent.WriteString("<p>" + h.imageHTML(index, art.Images[index]) + "</p>")
+			}
+			continue
+		}
+		
+		if strings.TrimSpace(line) != "" {
+			// 处理普通文本行：先转义正文中的 <、>、& 等字符，避免被当作标签解析，
+			// 再把 markdown 标记转换为 HTML；行内图片保持在文字中的位置
+			htmlLine := art.RenderLine(i, html.EscapeString, h.imageHTML)
 			
 			// 简单的markdown转HTML处理
 			if strings.HasPrefix(strings.TrimSpace(htmlLine), "##") {
 				htmlLine = strings.Replace(htmlLine, "##", "<h2>", 1) + "</h2>"
 			} else if strings.HasPrefix(strings.TrimSpace(htmlLine), "#") {
 				htmlLine = strings.Replace(htmlLine, "#", "<h1>", 1) + "</h1>"
-			} else if strings.HasPrefix(strings.TrimSpace(htmlLine), "```") {
-				// 代码块处理
-				if strings.Contains(htmlLine, "```") && len(strings.TrimSpace(htmlLine)) > 3 {
-					// 单行代码块
-					htmlLine = "<pre><code>" + strings.Trim(htmlLine, "`") + "</code></pre>"
-				} else {
-					// 多行代码块开始/结束
-					htmlLine = strings.Replace(htmlLine, "```", "<pre><code>", 1) + "</code></pre>"
-				}
 			} else {
 				// 普通段落
 				htmlLine = "<p>" + htmlLine + "</p>"
@@ -256,6 +312,12 @@ func (h *RichContentHandler) prepareRichContent(art *article.Article) (string, e
 		}
 	}
 	
+	lists.close(&htmlContent)
+	if inCodeBlock {
+		// 未闭合的代码块按 markdown 规则延续到文末
+		writeCodeBlock(&htmlContent, codeFence.Language, codeLines)
+	}
+	
 	// HTML 结尾
 	htmlContent.WriteString("</div>")
 	
@@ -265,66 +327,120 @@ func (h *RichContentHandler) prepareRichContent(art *article.Article) (string, e
 	return result, nil
 }
 
-// PrepareMarkdownWithPlaceholders 准备带占位符的Markdown内容
-func (h *RichContentHandler) PrepareMarkdownWithPlaceholders(art *article.Article) string {
-	var content strings.Builder
-	imageIndex := 0
+// RenderHTML 把文章渲染为富文本平台粘贴的同一份 HTML（图片以 base64 内嵌），用于本地预览
+func RenderHTML(art *article.Article) (string, error) {
+	h := &RichContentHandler{config: RichContentConfig{PlatformName: "预览"}}
+	return h.prepareRichContent(art)
+}
+
+// imageHTML 图片在富文本中的内容：跳过图片替换时保留占位符，留待统一阶段替换为图片，否则嵌入图片
+func (h *RichContentHandler) imageHTML(index int, img article.Image) string {
+	if h.config.SkipImageReplacement {
+		return article.PlaceholderFor(index)
+	}
+	return h.embedImage(img)
+}
+
+// embedImage 读取图片并转换为 base64 嵌入的 <img> 标签，读取失败时用文本代替
+func (h *RichContentHandler) embedImage(img article.Image) string {
+	imageData, err := os.ReadFile(img.AbsolutePath)
+	if err != nil {
+		log.Printf("[%s] ⚠️ 读取图片失败: %s, %v", h.config.PlatformName, img.AbsolutePath, err)
+		return fmt.Sprintf("<p>[图片：%s]</p>", html.EscapeString(img.AltText))
+	}
 	
-	for i, line := range art.Content {
-		// 检查是否是图片行
-		isImageLine := false
-		for _, img := range art.Images {
-			if img.LineIndex == i {
-				// 统一使用简单的占位符格式，便于所有平台查找和替换
-				placeholder := fmt.Sprintf("IMAGE_PLACEHOLDER_%d", imageIndex)
-				content.WriteString(placeholder)
-				content.WriteString("\n")
-				imageIndex++
-				isImageLine = true
-				break
-			}
-		}
-		
-		if !isImageLine {
-			content.WriteString(line)
-			content.WriteString("\n")
-		}
+	// 检测图片格式
+	var mimeType string
+	if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".png") {
+		mimeType = "image/png"
+	} else if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpg") || 
+			strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpeg") {
+		mimeType = "image/jpeg"
+	} else {
+		mimeType = "image/png"
 	}
 	
-	return content.String()
+	// 转换为base64并嵌入HTML
+	base64Data := base64.StdEncoding.EncodeToString(imageData)
+	dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
+	
+	log.Printf("[%s] 🖼️ 嵌入图片: %s (%d bytes)", h.config.PlatformName, img.AltText, len(imageData))
+	return fmt.Sprintf(`<img src="%s" alt="%s"%s style="max-width:100%%;" />`, 
+		dataURL, html.EscapeString(img.AltText), img.SizeAttributes())
 }
 
-// PrepareTextWithPlaceholders 准备带占位符的纯文本内容（用于打字方式）
-func (h *RichContentHandler) PrepareTextWithPlaceholders(art *article.Article) string {
-	var content strings.Builder
-	imageIndex := 0
-	
-	for i, line := range art.Content {
-		// 检查是否是图片行
-		isImageLine := false
-		for _, img := range art.Images {
-			if img.LineIndex == i {
-				// 使用简单的占位符格式（因为打字方式不支持复杂的图片替换）
-				placeholder := fmt.Sprintf("IMAGE_PLACEHOLDER_%d", imageIndex)
-				content.WriteString(placeholder)
-				content.WriteString("\n")
-				imageIndex++
-				isImageLine = true
-				break
-			}
+// rawHTMLLine 返回 HTML 块中的一行原文，行内的图片占位符替换为嵌入的图片（跳过图片替换时保留占位符）
+func (h *RichContentHandler) rawHTMLLine(art *article.Article, lineIndex int) string {
+	line := art.Content[lineIndex]
+	indexes := art.ImagesOnLine(lineIndex)
+	// 从后往前替换，前面图片的列号不受影响
+	for k := len(indexes) - 1; k >= 0; k-- {
+		img := art.Images[indexes[k]]
+		placeholder := article.PlaceholderFor(indexes[k])
+		if img.Column <= len(line) && strings.HasPrefix(line[img.Column:], placeholder) {
+			line = line[:img.Column] + h.imageHTML(indexes[k], img) + line[img.Column+len(placeholder):]
 		}
-		
-		if !isImageLine {
-			content.WriteString(line)
-			content.WriteString("\n")
+	}
+	return line
+}
+
+// writeCodeBlock 输出代码块 HTML，语言名写入 language-xxx class 供平台高亮
+func writeCodeBlock(htmlContent *strings.Builder, language string, lines []string) {
+	if language != "" {
+		htmlContent.WriteString(fmt.Sprintf(`<pre><code class="language-%s">`, html.EscapeString(language)))
+	} else {
+		htmlContent.WriteString("<pre><code>")
+	}
+	htmlContent.WriteString(html.EscapeString(strings.Join(lines, "\n")))
+	htmlContent.WriteString("</code></pre>")
+}
+
+// admonitionColors 各类型提示框的边框色和背景色
+var admonitionColors = map[article.AdmonitionKind][2]string{
+	article.AdmonitionNote:    {"#1f6feb", "#eef5ff"},
+	article.AdmonitionTip:     {"#1a7f37", "#eefbf1"},
+	article.AdmonitionWarning: {"#d4a72c", "#fff8e5"},
+	article.AdmonitionDanger:  {"#cf222e", "#ffefef"},
+}
+
+// writeAdmonition 输出提示框 HTML：左侧彩色边框的引用块，首段为图标和标题，正文每行一段
+func writeAdmonition(htmlContent *strings.Builder, adm article.Admonition, body []string) {
+	colors := admonitionColors[adm.Kind]
+	htmlContent.WriteString(fmt.Sprintf(`<blockquote style="border-left:4px solid %s;background:%s;padding:8px 16px;margin:16px 0;">`, colors[0], colors[1]))
+	htmlContent.WriteString("<p><strong>" + html.EscapeString(adm.Heading()) + "</strong></p>")
+	for _, line := range body {
+		if line != "" {
+			htmlContent.WriteString("<p>" + line + "</p>")
 		}
 	}
-	
-	return content.String()
+	htmlContent.WriteString("</blockquote>")
+}
+
+// PrepareMarkdownWithPlaceholders 准备带占位符的Markdown内容
+// 占位符按图片在正文中的真实顺序编号，同一行多张图片各自独立
+func (h *RichContentHandler) PrepareMarkdownWithPlaceholders(art *article.Article) string {
+	lines := art.ContentWithPlaceholders(func(index int, img article.Image) string {
+		return article.PlaceholderFor(index)
+	})
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// PrepareTextWithPlaceholders 准备带占位符的纯文本内容（用于打字方式）
+// 占位符按图片在正文中的真实顺序编号，同一行多张图片各自独立
+func (h *RichContentHandler) PrepareTextWithPlaceholders(art *article.Article) string {
+	lines := art.ContentWithPlaceholders(func(index int, img article.Image) string {
+		return article.PlaceholderFor(index)
+	})
+	return strings.Join(lines, "\n") + "\n"
 }
 
 // CreateAndLoadTempPage 创建临时页面并加载内容
 func (h *RichContentHandler) CreateAndLoadTempPage(content string) (playwright.Page, error) {
+	return h.createTempPage(strings.ReplaceAll(html.EscapeString(content), "\n", "<br>"))
+}
+
+// createTempPage 创建临时页面，把 HTML 内容放入可编辑区域 #editor
+func (h *RichContentHandler) createTempPage(body string) (playwright.Page, error) {
 	context := h.page.Context()
 	tempPage, err := context.NewPage()
 	if err != nil {
@@ -365,7 +481,7 @@ func (h *RichContentHandler) CreateAndLoadTempPage(content string) (playwright.P
 			</script>
 		</body>
 		</html>
-	`, strings.ReplaceAll(content, "\n", "<br>"))
+	`, body)
 	
 	if err := tempPage.SetContent(htmlContent); err != nil {
 		tempPage.Close()
@@ -431,23 +547,23 @@ func (h *RichContentHandler) PasteToEditor() error {
 	editorLocator := h.page.Locator(h.config.EditorSelector).First()
 	
 	// 等待编辑器出现
-	if err := editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := WaitVisible(editorLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器超时: %v", err)
 	}
 	
-	// 点击编辑器获取焦点
+	// 点击编辑器获取焦点，并确认焦点确实在目标编辑器内
 	if err := editorLocator.Click(); err != nil {
 		return fmt.Errorf("点击编辑器失败: %v", err)
 	}
+	if err := EnsureEditorFocus(h.page, h.config.EditorSelector); err != nil {
+		return fmt.Errorf("粘贴前确认焦点失败: %v", err)
+	}
 	
 	time.Sleep(500 * time.Millisecond)
 	
-	// 清空现有内容（如果有）
-	if err := h.page.Keyboard().Press("Meta+a"); err != nil {
-		h.page.Keyboard().Press("Control+a")
+	// 清空现有内容并确认已清空，避免新内容追加到旧内容后面
+	if err := ClearEditor(h.page, h.config.EditorSelector); err != nil {
+		return fmt.Errorf("清空编辑器失败: %v", err)
 	}
 	
 	time.Sleep(300 * time.Millisecond)
@@ -569,7 +685,8 @@ func (h *RichContentHandler) handleMarkdownParsing() error {
 	return fmt.Errorf("markdown解析按钮等待超时")
 }
 
-// CopyImageToClipboard 通用的图片复制到剪贴板方法（所有平台统一使用）
+// CopyImageToClipboard 通用的图片复制到剪贴板方法（所有平台统一使用）。
+// 优先使用页面的剪贴板 API，不可用或写入失败时回退到操作系统剪贴板
 func CopyImageToClipboard(page playwright.Page, imagePath string) error {
 	log.Printf("📎 开始复制图片到剪贴板: %s", imagePath)
 	
@@ -586,6 +703,16 @@ func CopyImageToClipboard(page playwright.Page, imagePath string) error {
 		return fmt.Errorf("检查图片文件失败: %v", err)
 	}
 	
+	// 超大图片先压缩，避免整张图片转成 base64 后拖垮页面
+	if absPath, err = shrinkForClipboard(absPath); err != nil {
+		return err
+	}
+	
+	if err := ProbeClipboard(page); err != nil {
+		log.Printf("📎 ⚠️ %v，改用系统剪贴板复制图片", err)
+		return copyImageWithSystemClipboard(page, absPath)
+	}
+	
 	// 读取图片文件并转换为data URL
 	imageData, err := os.ReadFile(absPath)
 	if err != nil {
@@ -703,30 +830,36 @@ func CopyImageToClipboard(page playwright.Page, imagePath string) error {
 	`, dataURL))
 	
 	if err != nil {
-		return fmt.Errorf("JavaScript复制图片失败: %v", err)
+		log.Printf("📎 ⚠️ JavaScript复制图片失败: %v，改用系统剪贴板复制图片", err)
+		return copyImageWithSystemClipboard(page, absPath)
 	}
 	
 	// 检查复制结果
 	if result, ok := copyResult.(map[string]interface{}); ok {
 		if success, _ := result["success"].(bool); success {
-			log.Printf("📎 ✅ 图片已成功复制到剪贴板")
+			log.Printf("📎 ✅ 图片已通过剪贴板 API 复制")
 			return nil
-		} else {
-			errorMsg, _ := result["error"].(string)
-			return fmt.Errorf("图片复制失败: %s", errorMsg)
 		}
+		errorMsg, _ := result["error"].(string)
+		log.Printf("📎 ⚠️ 剪贴板 API 复制图片失败: %s，改用系统剪贴板复制图片", errorMsg)
+		return copyImageWithSystemClipboard(page, absPath)
 	}
 	
 	return fmt.Errorf("未知的复制结果")
 }
 
 // PasteImageToEditor 通用的从剪贴板粘贴图片到编辑器方法
-func PasteImageToEditor(page playwright.Page) error {
+func PasteImageToEditor(page playwright.Page, editorSelector string) error {
 	log.Printf("📎 从剪贴板粘贴图片到编辑器")
 	
 	// 等待一小段时间确保剪贴板内容已准备好
 	time.Sleep(500 * time.Millisecond)
 	
+	// 确认目标页面在前台且编辑器保有焦点，避免粘贴到其它平台的页面
+	if err := EnsureSelectionFocus(page, editorSelector); err != nil {
+		return fmt.Errorf("粘贴前确认焦点失败: %v", err)
+	}
+	
 	// 尝试粘贴图片（优先使用Meta+v，兼容Control+v）
 	if err := page.Keyboard().Press("Meta+v"); err != nil {
 		log.Printf("📎 Meta+v失败，尝试Control+v: %v", err)
@@ -737,4 +870,50 @@ func PasteImageToEditor(page playwright.Page) error {
 	
 	log.Printf("📎 ✅ 图片已粘贴到编辑器")
 	return nil
-}
\ No newline at end of file
+}
+
+// ReplaceTextInMarkdownEditor 在 Markdown 编辑器（textarea 或 CodeMirror）中把占位符替换为文本
+func ReplaceTextInMarkdownEditor(page playwright.Page, editorSelector, placeholder, text string) error {
+	result, err := page.Evaluate(`
+		([selector, searchText, replacement]) => {
+			const editor = document.querySelector(selector);
+			if (!editor) return false;
+
+			// textarea 编辑器
+			if (editor.tagName.toLowerCase() === 'textarea') {
+				const index = editor.value.indexOf(searchText);
+				if (index === -1) return false;
+				editor.focus();
+				editor.setRangeText(replacement, index, index + searchText.length, 'end');
+				editor.dispatchEvent(new Event('input', { bubbles: true }));
+				return true;
+			}
+
+			// CodeMirror 编辑器（选择器可能指向 CodeMirror 本身、其父容器或内部滚动区域）
+			const cmElement = editor.CodeMirror ? editor : (editor.querySelector('.CodeMirror') || editor.closest('.CodeMirror'));
+			if (cmElement && cmElement.CodeMirror) {
+				const cm = cmElement.CodeMirror;
+				const index = cm.getValue().indexOf(searchText);
+				if (index === -1) return false;
+				cm.replaceRange(replacement, cm.posFromIndex(index), cm.posFromIndex(index + searchText.length));
+				cm.focus();
+				return true;
+			}
+			return false;
+		}
+	`, []interface{}{editorSelector, placeholder, text})
+	if err != nil {
+		return fmt.Errorf("替换占位符失败: %v", err)
+	}
+	if found, ok := result.(bool); !ok || !found {
+		return fmt.Errorf("未找到占位符: %s", placeholder)
+	}
+	return nil
+}
+
+// CloseTempPage 关闭临时页面（已关闭时忽略），放在 defer 中可确保出错或 panic 时也不会遗留页面
+func CloseTempPage(page playwright.Page) {
+	if page != nil && !page.IsClosed() {
+		page.Close()
+	}
+}
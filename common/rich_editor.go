@@ -0,0 +1,112 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// SelectTextInRichEditor 在富文本编辑器（contenteditable）中选中文字 text，用于替换图片占位符
+func SelectTextInRichEditor(page playwright.Page, editorSelector, text string) error {
+	result, err := page.Evaluate(`
+		([selector, searchText]) => {
+			const editor = document.querySelector(selector);
+			if (!editor) {
+				return { success: false, error: '找不到编辑器' };
+			}
+
+			const walker = document.createTreeWalker(editor, NodeFilter.SHOW_TEXT);
+			let node;
+			while ((node = walker.nextNode())) {
+				const index = node.textContent.indexOf(searchText);
+				if (index === -1) continue;
+
+				const range = document.createRange();
+				range.setStart(node, index);
+				range.setEnd(node, index + searchText.length);
+				editor.focus();
+				const selection = window.getSelection();
+				selection.removeAllRanges();
+				selection.addRange(range);
+				return { success: true };
+			}
+			return { success: false, error: '未找到文本: ' + searchText };
+		}
+	`, []interface{}{editorSelector, text})
+	if err != nil {
+		return fmt.Errorf("查找文本失败: %v", err)
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("查找文本返回了无法识别的结果: %v", result)
+	}
+	if success, _ := resultMap["success"].(bool); !success {
+		message, _ := resultMap["error"].(string)
+		return fmt.Errorf("%s", message)
+	}
+	time.Sleep(300 * time.Millisecond)
+	return nil
+}
+
+// ReplaceTextInRichEditor 在富文本编辑器中把占位符替换为文本
+func ReplaceTextInRichEditor(page playwright.Page, editorSelector, placeholder, text string) error {
+	if err := SelectTextInRichEditor(page, editorSelector, placeholder); err != nil {
+		return err
+	}
+	if err := page.Keyboard().InsertText(text); err != nil {
+		return fmt.Errorf("输入替换文本失败: %v", err)
+	}
+	return nil
+}
+
+// ReplacePlaceholderWithImage 在富文本编辑器中选中占位符，删除后从剪贴板粘贴图片，
+// 并等待编辑器中的图片数量增加（平台把图片转存到自己的图床后才会显示）
+func ReplacePlaceholderWithImage(page playwright.Page, platformName, editorSelector, placeholder, imagePath string) error {
+	before := countEditorImages(page, editorSelector)
+
+	if err := SelectTextInRichEditor(page, editorSelector, placeholder); err != nil {
+		return fmt.Errorf("查找占位符失败: %v", err)
+	}
+	if err := page.Keyboard().Press("Delete"); err != nil {
+		return fmt.Errorf("删除占位符失败: %v", err)
+	}
+	if err := CopyImageToClipboard(page, imagePath); err != nil {
+		return fmt.Errorf("复制图片失败: %v", err)
+	}
+	if err := PasteImageToEditor(page, editorSelector); err != nil {
+		return fmt.Errorf("粘贴图片失败: %v", err)
+	}
+
+	deadline := time.Now().Add(Timeout(15 * time.Second))
+	for time.Now().Before(deadline) {
+		if countEditorImages(page, editorSelector) > before {
+			log.Printf("[%s] ✅ 占位符 %s 已替换为图片", platformName, placeholder)
+			return nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	log.Printf("[%s] ⚠️ 等待图片 %s 显示超时，图片可能仍在上传", platformName, placeholder)
+	return nil
+}
+
+// countEditorImages 统计编辑器中的图片数量，查询失败时返回 0
+func countEditorImages(page playwright.Page, editorSelector string) int {
+	result, err := page.Evaluate(`(selector) => {
+		const editor = document.querySelector(selector);
+		return editor ? editor.querySelectorAll('img').length : 0;
+	}`, editorSelector)
+	if err != nil {
+		return 0
+	}
+	// 整数结果可能以 int 或 float64 返回
+	switch count := result.(type) {
+	case int:
+		return count
+	case float64:
+		return int(count)
+	}
+	return 0
+}
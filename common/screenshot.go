@@ -0,0 +1,32 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// SaveScreenshot 保存当前页面截图到 ~/.auto-blog/screenshots，文件名包含平台、场景和时间，返回截图路径。
+// 用于元素等待超时等难以复现的问题排查
+func SaveScreenshot(page playwright.Page, platformName, scene string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("获取用户主目录失败: %v", err)
+	}
+	dir := filepath.Join(homeDir, ".auto-blog", "screenshots")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("创建截图目录失败: %v", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.png", platformName, scene, time.Now().Format("20060102-150405")))
+	if _, err := page.Screenshot(playwright.PageScreenshotOptions{
+		Path:     playwright.String(path),
+		FullPage: playwright.Bool(true),
+	}); err != nil {
+		return "", fmt.Errorf("保存截图失败: %v", err)
+	}
+	return path, nil
+}
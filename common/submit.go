@@ -0,0 +1,69 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ClickButtonByText 在 scopeSelector 范围内点击文字与 texts 之一完全相同的可见按钮
+// （scopeSelector 为空时在整个页面查找），按 texts 的顺序优先匹配，点击前有拟人化的随机停顿
+func ClickButtonByText(page playwright.Page, scopeSelector string, texts ...string) error {
+	HumanPause()
+	result, err := page.Evaluate(`
+		(args) => {
+			const isVisible = (el) => el && el.offsetParent !== null;
+			const textOf = (el) => (el.innerText || el.textContent || '').trim();
+			const scope = (args.scope && document.querySelector(args.scope)) || document;
+			const buttons = Array.from(scope.querySelectorAll('button, [role="button"], a, input[type="button"], input[type="submit"]'))
+				.filter(el => isVisible(el) && !el.disabled);
+
+			for (const text of args.texts) {
+				const button = buttons.find(el => textOf(el) === text || el.value === text);
+				if (button) {
+					button.click();
+					return { success: true, text: text };
+				}
+			}
+			return { success: false };
+		}
+	`, map[string]interface{}{
+		"scope": scopeSelector,
+		"texts": texts,
+	})
+	if err != nil {
+		return fmt.Errorf("点击%s按钮失败: %v", texts[0], err)
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("点击%s按钮返回了无法识别的结果: %v", texts[0], result)
+	}
+	if success, _ := resultMap["success"].(bool); !success {
+		return fmt.Errorf("未找到%s按钮", strings.Join(texts, "/"))
+	}
+	return nil
+}
+
+// WaitForPageText 等待页面上出现包含 texts 之一的可见文字（如"保存成功"提示），
+// 超时时间按全局倍率放大，超时返回空字符串
+func WaitForPageText(page playwright.Page, timeout time.Duration, texts ...string) string {
+	deadline := time.Now().Add(Timeout(timeout))
+	for time.Now().Before(deadline) {
+		found, err := page.Evaluate(`
+			(texts) => {
+				const body = document.body ? document.body.innerText : '';
+				return texts.find(text => body.includes(text)) || '';
+			}
+		`, texts)
+		if err == nil {
+			if text, _ := found.(string); text != "" {
+				return text
+			}
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return ""
+}
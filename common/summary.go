@@ -0,0 +1,52 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// FillLabeledTextarea 在 scopeSelector 范围内查找文字包含 label 的表单项，填写其中的文本框。
+// 发布面板多为 React/Vue 受控组件，需要通过原生 setter 赋值并触发 input 事件
+func FillLabeledTextarea(page playwright.Page, scopeSelector, label, text string) error {
+	result, err := page.Evaluate(`
+		(args) => {
+			const isVisible = (el) => el && el.offsetParent !== null;
+			const textOf = (el) => (el.innerText || el.textContent || '').trim();
+			const scope = document.querySelector(args.scope) || document;
+
+			const row = Array.from(scope.querySelectorAll('.form-item, .item, .form-group, div, label'))
+				.filter(el => isVisible(el) && textOf(el).includes(args.label) && el.querySelector('textarea'))
+				.sort((a, b) => textOf(a).length - textOf(b).length)[0];
+			const textarea = row && row.querySelector('textarea');
+			if (!textarea) {
+				return { success: false, error: '未找到' + args.label + '输入框' };
+			}
+
+			const setter = Object.getOwnPropertyDescriptor(HTMLTextAreaElement.prototype, 'value').set;
+			textarea.focus();
+			setter.call(textarea, args.text);
+			textarea.dispatchEvent(new Event('input', { bubbles: true }));
+			textarea.dispatchEvent(new Event('change', { bubbles: true }));
+			textarea.blur();
+			return { success: true };
+		}
+	`, map[string]interface{}{
+		"scope": scopeSelector,
+		"label": label,
+		"text":  text,
+	})
+	if err != nil {
+		return fmt.Errorf("填写%s失败: %v", label, err)
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("填写%s返回了无法识别的结果: %v", label, result)
+	}
+	if success, _ := resultMap["success"].(bool); !success {
+		errorMsg, _ := resultMap["error"].(string)
+		return fmt.Errorf("%s", errorMsg)
+	}
+	return nil
+}
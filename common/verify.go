@@ -0,0 +1,50 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// verifyTimeout 等待文章出现在文章页或文章列表中的最长时间（平台列表可能有几秒的同步延迟）
+const verifyTimeout = 20 * time.Second
+
+// VerifyArticleListed 打开文章列表页 listURL（为空时在当前页面查找），等待出现标题为 title 的文章，
+// 返回文章链接（找到的标题不在链接中时为空）。超时时间按全局倍率放大，超时返回错误
+func VerifyArticleListed(page playwright.Page, platformName, listURL, title string) (string, error) {
+	if listURL != "" {
+		if _, err := HumanGoto(page, listURL); err != nil {
+			return "", fmt.Errorf("打开文章列表失败: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(Timeout(verifyTimeout))
+	for time.Now().Before(deadline) {
+		result, err := page.Evaluate(`
+			(title) => {
+				const normalize = (text) => (text || '').replace(/\s+/g, ' ').trim();
+				const target = normalize(title);
+				const candidates = Array.from(document.querySelectorAll('a, h1, h2, h3, [class*="title"]'))
+					.filter(el => el.offsetParent !== null && normalize(el.innerText).includes(target));
+				if (candidates.length === 0) {
+					return { found: false };
+				}
+				const link = candidates.map(el => el.closest('a') || el.querySelector('a')).find(a => a && a.href);
+				return { found: true, url: link ? link.href : '' };
+			}
+		`, title)
+		if err == nil {
+			if resultMap, ok := result.(map[string]interface{}); ok {
+				if found, _ := resultMap["found"].(bool); found {
+					url, _ := resultMap["url"].(string)
+					log.Printf("[%s] 🔎 已确认文章《%s》存在", platformName, title)
+					return url, nil
+				}
+			}
+		}
+		time.Sleep(time.Second)
+	}
+	return "", fmt.Errorf("%v 内未在文章列表中找到《%s》，可能被审核拦截或提交失败", Timeout(verifyTimeout), title)
+}
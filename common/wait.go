@@ -0,0 +1,79 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// firstWaitAttempt 元素等待第一次尝试的超时，之后每次翻倍
+const firstWaitAttempt = 500 * time.Millisecond
+
+// timeoutMultiplier 全局超时倍率（[browser] timeout_multiplier），按千分之一存储以便原子读写
+var timeoutMultiplier atomic.Int64
+
+func init() {
+	timeoutMultiplier.Store(1000)
+}
+
+// SetTimeoutMultiplier 设置全局超时倍率，慢机器调大后所有元素等待按比例放宽，不大于 0 时忽略
+func SetTimeoutMultiplier(multiplier float64) {
+	if multiplier <= 0 {
+		return
+	}
+	timeoutMultiplier.Store(int64(math.Round(multiplier * 1000)))
+}
+
+// Timeout 按全局倍率放大超时时间
+func Timeout(base time.Duration) time.Duration {
+	return base * time.Duration(timeoutMultiplier.Load()) / 1000
+}
+
+// TimeoutMs 按全局倍率放大超时时间，返回 playwright 选项使用的毫秒数
+func TimeoutMs(base time.Duration) *float64 {
+	return playwright.Float(float64(Timeout(base).Milliseconds()))
+}
+
+// WaitVisible 等待元素可见，见 WaitForElement
+func WaitVisible(locator playwright.Locator, timeout time.Duration) error {
+	return WaitForElement(locator, playwright.WaitForSelectorStateVisible, timeout)
+}
+
+// WaitAttached 等待元素出现在 DOM 中（文件输入框等不可见元素），见 WaitForElement
+func WaitAttached(locator playwright.Locator, timeout time.Duration) error {
+	return WaitForElement(locator, playwright.WaitForSelectorStateAttached, timeout)
+}
+
+// WaitForElement 等待元素达到指定状态，总超时为 timeout 乘以全局倍率。
+// 从较短的超时开始尝试，超时后按指数退避加倍重试，页面关闭等非超时错误立即返回
+func WaitForElement(locator playwright.Locator, state *playwright.WaitForSelectorState, timeout time.Duration) error {
+	total := Timeout(timeout)
+	deadline := time.Now().Add(total)
+	attempt := firstWaitAttempt
+
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("等待元素超时（%v）", total)
+		}
+		if attempt > remaining {
+			attempt = remaining
+		}
+
+		err := locator.WaitFor(playwright.LocatorWaitForOptions{
+			Timeout: playwright.Float(float64(attempt.Milliseconds())),
+			State:   state,
+		})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, playwright.ErrTimeout) {
+			return err
+		}
+		attempt *= 2
+	}
+}
@@ -1,9 +1,21 @@
 package config
 
 import (
-	"github.com/auto-blog/cnblogs"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/browser"
+	"github.com/auto-blog/cover"
+	"github.com/auto-blog/hooks"
+	"github.com/auto-blog/imagehost"
+	"github.com/auto-blog/installer"
 	"github.com/auto-blog/juejin"
-	"github.com/auto-blog/segmentfault"
+	"github.com/auto-blog/notify"
+	"github.com/auto-blog/platform"
+	"github.com/auto-blog/staticsite"
+	"github.com/auto-blog/watermark"
 	"github.com/auto-blog/zhihu"
 	"gopkg.in/ini.v1"
 )
@@ -13,33 +25,422 @@ type Config struct {
 	file *ini.File
 }
 
-// LoadConfig 加载配置文件
+// LoadConfig 加载配置文件，配置值中的 ${VAR} 在读取时展开为环境变量，密钥无需明文写在配置文件中
 func LoadConfig(filename string) (*Config, error) {
 	cfg, err := ini.Load(filename)
 	if err != nil {
 		return nil, err
 	}
+	cfg.ValueMapper = expandEnv
 	
 	return &Config{file: cfg}, nil
 }
 
-// GetEnabledPlatforms 获取启用的平台
+// GetEnabledPlatforms 获取启用的平台（[platform.<id>] enabled > [publish] <id> > [defaults] enabled）
 func (c *Config) GetEnabledPlatforms() map[string]string {
-	publishSection := c.file.Section("publish")
 	enabledPlatforms := make(map[string]string)
-	
-	if publishSection.Key("juejin").MustBool(false) {
-		enabledPlatforms["掘金"] = juejin.URL()
+	for _, p := range platforms {
+		if key := c.platformKey(p.id, "enabled", "publish", p.id); key != nil && key.MustBool(false) {
+			enabledPlatforms[p.name] = p.url()
+		}
 	}
-	if publishSection.Key("cnblogs").MustBool(false) {
-		enabledPlatforms["博客园"] = cnblogs.URL()
+	return enabledPlatforms
+}
+
+// GetDefaultPublishAt 获取默认定时发布时间（未配置时返回空字符串）
+func (c *Config) GetDefaultPublishAt() string {
+	return c.file.Section("publish").Key("publish_at").String()
+}
+
+// GetSortOrder 获取文章发布顺序（name/prefix/weight/date，默认 name）
+func (c *Config) GetSortOrder() string {
+	return c.file.Section("publish").Key("sort").MustString("name")
+}
+
+// GetPublishMode 获取内容填写完成后的提交方式（draft/publish，默认 draft）
+func (c *Config) GetPublishMode() (platform.PublishMode, error) {
+	return platform.ParsePublishMode(c.file.Section("publish").Key("mode").String())
+}
+
+// GetParseConcurrency 获取同时解析的文章文件数（默认 0，按 CPU 核数）
+func (c *Config) GetParseConcurrency() int {
+	return c.file.Section("publish").Key("parse_concurrency").MustInt(0)
+}
+
+// GetTitleTrailingPunctuation 获取从标题末尾去掉的标点，未配置时使用默认标点，配置为空时不去掉
+func (c *Config) GetTitleTrailingPunctuation() string {
+	section := c.file.Section("publish")
+	if !section.HasKey("title_trailing_punctuation") {
+		return article.DefaultTitleTrailingPunctuation
 	}
-	if publishSection.Key("zhihu").MustBool(false) {
-		enabledPlatforms["知乎"] = zhihu.URL()
+	return section.Key("title_trailing_punctuation").String()
+}
+
+// GetSummaryLength 获取自动提取摘要的长度（字符数，默认 100）
+func (c *Config) GetSummaryLength() int {
+	return c.file.Section("publish").Key("summary_length").MustInt(article.DefaultSummaryLength)
+}
+
+// GetBrowserOptions 获取浏览器配置，未配置的项使用默认值
+func (c *Config) GetBrowserOptions() browser.Options {
+	browserSection := c.file.Section("browser")
+	options := browser.DefaultOptions()
+
+	if userAgent := browserSection.Key("user_agent").String(); userAgent != "" {
+		options.UserAgent = userAgent
 	}
-	if publishSection.Key("segmentfault").MustBool(false) {
-		enabledPlatforms["SegmentFault"] = segmentfault.URL()
+	if locale := browserSection.Key("locale").String(); locale != "" {
+		options.Locale = locale
 	}
-	
-	return enabledPlatforms
-}
\ No newline at end of file
+	if timezone := browserSection.Key("timezone").String(); timezone != "" {
+		options.TimezoneID = timezone
+	}
+	options.ExecutablePath = browserSection.Key("executable_path").String()
+	options.MaxRestarts = browserSection.Key("max_restarts").MustInt(options.MaxRestarts)
+	options.TimeoutMultiplier = browserSection.Key("timeout_multiplier").MustFloat64(options.TimeoutMultiplier)
+	options.ActionDelay = time.Duration(browserSection.Key("humanize_delay").MustInt(int(options.ActionDelay/time.Millisecond))) * time.Millisecond
+	options.UploadConcurrency = c.file.Section("image").Key("upload_concurrency").MustInt(options.UploadConcurrency)
+
+	// 发布限流：[platform.<id>] publish_interval > [publish] publish_interval > [defaults] publish_interval
+	publishSection := c.file.Section("publish")
+	options.PublishInterval = time.Duration(publishSection.Key("publish_interval").MustInt(int(options.PublishInterval/time.Second))) * time.Second
+	options.RetryFailed = publishSection.Key("retry_failed").MustInt(options.RetryFailed)
+	options.PublishJitter = time.Duration(publishSection.Key("publish_jitter").MustInt(int(options.PublishJitter/time.Second))) * time.Second
+	options.PublishIntervals = make(map[string]time.Duration)
+	for _, p := range platforms {
+		if key := c.platformKey(p.id, "publish_interval", "publish", "publish_interval"); key != nil {
+			options.PublishIntervals[p.name] = time.Duration(key.MustInt(int(options.PublishInterval/time.Second))) * time.Second
+		}
+	}
+
+	// 按平台限制正文字数：[platform.<id>] > [defaults] > 平台内置上限
+	options.MaxLengths = make(map[string]int)
+	options.TruncateSuffixes = make(map[string]string)
+	for _, p := range platforms {
+		if key := c.platformKey(p.id, "max_length", "", ""); key != nil {
+			options.MaxLengths[p.name] = key.MustInt(0)
+		} else if p.maxLength > 0 {
+			options.MaxLengths[p.name] = p.maxLength
+		}
+		if key := c.platformKey(p.id, "truncate_suffix", "", ""); key != nil {
+			options.TruncateSuffixes[p.name] = key.String()
+		}
+	}
+
+	return options
+}
+
+// GetLengthStrategies 获取各平台正文超过字数上限时的处理策略：[platform.<id>] > [defaults] length_strategy，
+// 未配置时截断
+func (c *Config) GetLengthStrategies() (map[string]platform.LengthStrategy, error) {
+	strategies := make(map[string]platform.LengthStrategy)
+	for _, p := range platforms {
+		key := c.platformKey(p.id, "length_strategy", "", "")
+		if key == nil {
+			continue
+		}
+		strategy, err := platform.ParseLengthStrategy(key.String())
+		if err != nil {
+			return nil, fmt.Errorf("%s 的超长处理策略: %v", p.id, err)
+		}
+		strategies[p.name] = strategy
+	}
+	return strategies, nil
+}
+
+// GetLanguageRoutes 获取按文章语言路由平台的规则（[language_routes] 语言 = 平台标识列表），
+// 返回语言 -> 平台名称集合，未配置时返回空表
+func (c *Config) GetLanguageRoutes() (map[string]map[string]bool, error) {
+	routes := make(map[string]map[string]bool)
+	section, err := c.file.GetSection("language_routes")
+	if err != nil {
+		return routes, nil
+	}
+	for _, key := range section.Keys() {
+		lang := article.NormalizeLanguage(key.Name())
+		names := make(map[string]bool)
+		for _, id := range key.Strings(",") {
+			info, ok := platformByID(id)
+			if !ok {
+				return nil, fmt.Errorf("%s 的平台 %s 不存在", key.Name(), id)
+			}
+			names[info.name] = true
+		}
+		routes[lang] = names
+	}
+	return routes, nil
+}
+
+// GetUpdateTargets 获取要修改的已发布文章（[<平台标识>_updates] 文章文件名 = 已发布文章链接），
+// 返回平台名称 -> 文章文件名 -> 链接，未配置时返回空表
+func (c *Config) GetUpdateTargets() (map[string]map[string]string, error) {
+	targets := make(map[string]map[string]string)
+	for _, info := range platforms {
+		section, err := c.file.GetSection(info.id + "_updates")
+		if err != nil {
+			continue
+		}
+		for _, key := range section.Keys() {
+			articleURL := strings.TrimSpace(key.String())
+			if !strings.HasPrefix(articleURL, "http://") && !strings.HasPrefix(articleURL, "https://") {
+				return nil, fmt.Errorf("[%s_updates] %s 的文章链接无效: %q", info.id, key.Name(), articleURL)
+			}
+			if targets[info.name] == nil {
+				targets[info.name] = make(map[string]string)
+			}
+			targets[info.name][key.Name()] = articleURL
+		}
+	}
+	return targets, nil
+}
+
+// GetInstallerOptions 获取 Playwright 安装配置（下载镜像、本地浏览器路径）
+func (c *Config) GetInstallerOptions() installer.Options {
+	browserSection := c.file.Section("browser")
+	return installer.Options{
+		DownloadHost:   browserSection.Key("download_host").String(),
+		ExecutablePath: browserSection.Key("executable_path").String(),
+	}
+}
+
+// AutoOrientImages 是否按 EXIF 方向自动校正图片（默认开启）
+func (c *Config) AutoOrientImages() bool {
+	return c.file.Section("image").Key("auto_orient").MustBool(true)
+}
+
+// DetectCodeLanguage 是否为未标注语言的代码块自动检测语言（默认关闭）
+func (c *Config) DetectCodeLanguage() bool {
+	return c.file.Section("markdown").Key("detect_code_language").MustBool(false)
+}
+
+// ConvertEmojiShortcodes 是否将正文中的 emoji 短代码（如 :smile:）转换为 Unicode 表情（默认关闭）
+func (c *Config) ConvertEmojiShortcodes() bool {
+	return c.file.Section("markdown").Key("emoji_shortcodes").MustBool(false)
+}
+
+// SeriesNavigation 是否在系列文章的正文首尾插入系列导航（默认关闭）
+func (c *Config) SeriesNavigation() bool {
+	return c.file.Section("markdown").Key("series_navigation").MustBool(false)
+}
+
+// GetPlatformLogDir 获取按平台分文件输出日志的目录（未配置时返回空字符串，不分文件）
+func (c *Config) GetPlatformLogDir() string {
+	return c.file.Section("log").Key("platform_dir").String()
+}
+
+// GetStatusFile 获取实时进度状态文件路径（未配置时返回空字符串，使用默认路径）
+func (c *Config) GetStatusFile() string {
+	return c.file.Section("log").Key("status_file").String()
+}
+
+// KeepTempFiles 退出时是否保留临时文件（生成的封面、加水印或压缩后的图片、下载的远程图片），默认清理
+func (c *Config) KeepTempFiles() bool {
+	return c.file.Section("log").Key("keep_temp_files").MustBool(false)
+}
+
+// GetAltTextMode 获取图片 alt 为空时自动生成描述的方式（off/filename/context，默认 off）
+func (c *Config) GetAltTextMode() string {
+	return c.file.Section("image").Key("auto_alt").MustString("off")
+}
+
+// GetBlankLineMode 获取正文空行处理方式（keep/collapse，默认 keep）
+func (c *Config) GetBlankLineMode() string {
+	return c.file.Section("markdown").Key("blank_lines").MustString("keep")
+}
+
+// GetZhihuOptions 获取知乎发布设置
+func (c *Config) GetZhihuOptions() zhihu.Options {
+	answers := make(map[string]string)
+	for _, key := range c.file.Section("zhihu_answers").Keys() {
+		answers[key.Name()] = key.String()
+	}
+	options := zhihu.Options{Answers: answers}
+	if key := c.platformKey("zhihu", "enable_reward", "zhihu", "enable_reward"); key != nil {
+		options.EnableReward = key.MustBool(false)
+	}
+	if key := c.platformKey("zhihu", "column", "zhihu", "column"); key != nil {
+		options.Column = key.String()
+	}
+	if key := c.platformKey("zhihu", "fill_title", "zhihu", "fill_title"); key != nil {
+		options.FillTitle = key.MustBool(false)
+	}
+	if key := c.platformKey("zhihu", "share_pin", "zhihu", "share_pin"); key != nil {
+		options.SharePin = key.MustBool(false)
+	}
+	if key := c.platformKey("zhihu", "pin_template", "zhihu", "pin_template"); key != nil {
+		options.PinTemplate = key.String()
+	}
+	if key := c.platformKey("zhihu", "paste_chunk_size", "zhihu", "paste_chunk_size"); key != nil {
+		options.PasteChunkSize = key.MustInt(0)
+	}
+	return options
+}
+
+// GetJuejinOptions 获取掘金发布设置（[platform.juejin] > [defaults] input_method，默认逐行打字）
+func (c *Config) GetJuejinOptions() (juejin.Options, error) {
+	var options juejin.Options
+	if key := c.platformKey(juejin.ID, "input_method", "", ""); key != nil {
+		method, err := juejin.ParseInputMethod(key.String())
+		if err != nil {
+			return options, fmt.Errorf("juejin 的输入方式: %v", err)
+		}
+		options.InputMethod = method
+	}
+	return options, nil
+}
+
+// GetSensitiveConfig 获取敏感词表路径和处理策略（未配置词表时返回空路径）
+func (c *Config) GetSensitiveConfig() (string, string) {
+	sensitiveSection := c.file.Section("sensitive")
+	return sensitiveSection.Key("words_file").String(), sensitiveSection.Key("strategy").MustString("warn")
+}
+
+// GetSelectorsFile 获取选择器覆盖文件路径（未配置时返回空字符串，使用内置默认值）
+func (c *Config) GetSelectorsFile() string {
+	return c.file.Section("selectors").Key("file").String()
+}
+
+// GetStaticSiteOptions 获取静态博客（Hugo/Hexo）输出配置，未启用时第二个返回值为 false
+func (c *Config) GetStaticSiteOptions() (staticsite.Options, bool) {
+	if !c.file.Section("publish").Key("staticsite").MustBool(false) {
+		return staticsite.Options{}, false
+	}
+
+	staticSection := c.file.Section("staticsite")
+	defaults := staticsite.DefaultOptions()
+	return staticsite.Options{
+		Root:       staticSection.Key("root").String(),
+		ContentDir: staticSection.Key("content_dir").MustString(defaults.ContentDir),
+		ImageDir:   staticSection.Key("image_dir").MustString(defaults.ImageDir),
+		ImageURL:   staticSection.Key("image_url").MustString(defaults.ImageURL),
+	}, true
+}
+
+// GetImageStrategies 获取各平台的图片处理策略
+// （[platform.<id>] image_strategy > [image_strategy] <id> > [defaults] image_strategy，都未配置的平台使用剪贴板粘贴）。
+// 图床策略插入的是 Markdown 图片链接，富文本编辑器会原样显示：[defaults] 中的图床策略只作用于 Markdown 编辑器平台，
+// 富文本平台保持剪贴板粘贴；为富文本平台单独配置图床策略时报错
+func (c *Config) GetImageStrategies() (map[string]platform.ImageStrategy, error) {
+	strategies := make(map[string]platform.ImageStrategy)
+	for _, p := range platforms {
+		key := c.platformKey(p.id, "image_strategy", "image_strategy", p.id)
+		if key == nil {
+			continue
+		}
+		strategy, err := platform.ParseImageStrategy(key.String())
+		if err != nil {
+			return nil, fmt.Errorf("%s 的图片策略: %v", p.id, err)
+		}
+		if strategy == platform.ImageStrategyImageHost && p.richText {
+			if c.isDefaultsKey(key) {
+				continue
+			}
+			return nil, fmt.Errorf("%s 的图片策略: %s 使用富文本编辑器，图片链接会以 Markdown 原文显示，不支持 imagehost", p.id, p.name)
+		}
+		strategies[p.name] = strategy
+	}
+	return strategies, nil
+}
+
+// GetVisibilities 获取各平台的文章可见范围：[platform.<id>] visibility > [publish] visibility > [defaults] visibility，
+// 未配置的平台不在结果中（保持公开）
+func (c *Config) GetVisibilities() (map[string]platform.Visibility, error) {
+	visibilities := make(map[string]platform.Visibility)
+	for _, p := range platforms {
+		key := c.platformKey(p.id, "visibility", "publish", "visibility")
+		if key == nil {
+			continue
+		}
+		visibility, err := platform.ParseVisibility(key.String())
+		if err != nil {
+			return nil, fmt.Errorf("%s 的可见范围: %v", p.id, err)
+		}
+		visibilities[p.name] = visibility
+	}
+	return visibilities, nil
+}
+
+// GetImageHostOptions 获取图床配置（未配置上传地址时返回空配置）
+func (c *Config) GetImageHostOptions() imagehost.Options {
+	hostSection := c.file.Section("imagehost")
+	return imagehost.Options{
+		UploadURL: hostSection.Key("upload_url").String(),
+		FileField: hostSection.Key("file_field").MustString("file"),
+		URLField:  hostSection.Key("url_field").MustString("data.url"),
+		Token:     hostSection.Key("token").String(),
+	}
+}
+
+// GetCoverOptions 获取封面自动生成配置，未开启时第二个返回值为 false
+func (c *Config) GetCoverOptions() (cover.Options, bool) {
+	coverSection := c.file.Section("cover")
+	if !coverSection.Key("auto_generate").MustBool(false) {
+		return cover.Options{}, false
+	}
+
+	defaults := cover.DefaultOptions()
+	options := cover.Options{
+		Width:         coverSection.Key("width").MustInt(defaults.Width),
+		Height:        coverSection.Key("height").MustInt(defaults.Height),
+		Background:    coverSection.Key("background").MustString(defaults.Background),
+		BackgroundEnd: defaults.BackgroundEnd,
+		TextColor:     coverSection.Key("text_color").MustString(defaults.TextColor),
+		FontFile:      coverSection.Key("font_file").String(),
+		FontSize:      coverSection.Key("font_size").MustFloat64(defaults.FontSize),
+	}
+	// 显式配置为空时使用纯色背景
+	if coverSection.HasKey("background_end") {
+		options.BackgroundEnd = coverSection.Key("background_end").String()
+	}
+	return options, true
+}
+
+// GetWatermarkOptions 获取图片水印配置，未配置水印文字和图片时返回 false
+func (c *Config) GetWatermarkOptions() (watermark.Options, bool) {
+	watermarkSection := c.file.Section("watermark")
+	defaults := watermark.DefaultOptions()
+	options := watermark.Options{
+		Text:     watermarkSection.Key("text").String(),
+		Image:    watermarkSection.Key("image").String(),
+		Position: watermark.Position(watermarkSection.Key("position").MustString(string(defaults.Position))),
+		Opacity:  watermarkSection.Key("opacity").MustFloat64(defaults.Opacity),
+		FontFile: watermarkSection.Key("font_file").String(),
+		FontSize: watermarkSection.Key("font_size").MustFloat64(defaults.FontSize),
+		Color:    watermarkSection.Key("color").MustString(defaults.Color),
+		Margin:   watermarkSection.Key("margin").MustInt(defaults.Margin),
+	}
+	return options, options.Text != "" || options.Image != ""
+}
+
+// GetWatermarkPlatforms 获取各平台是否给图片加水印（平台名称 -> 是否开启，默认开启）
+func (c *Config) GetWatermarkPlatforms() map[string]bool {
+	enabled := make(map[string]bool)
+	for _, p := range platforms {
+		enabled[p.name] = true
+		if key := c.platformKey(p.id, "watermark", "watermark", p.id); key != nil {
+			enabled[p.name] = key.MustBool(true)
+		}
+	}
+	return enabled
+}
+
+// GetNotifyOptions 获取发布结果的 Webhook 通知配置（未配置地址时不通知）
+func (c *Config) GetNotifyOptions() notify.Options {
+	notifySection := c.file.Section("notify")
+	return notify.Options{
+		URL:     notifySection.Key("webhook_url").String(),
+		Format:  notify.Format(notifySection.Key("format").MustString(string(notify.FormatJSON))),
+		Timeout: time.Duration(notifySection.Key("timeout").MustInt(10)) * time.Second,
+	}
+}
+
+// GetHookOptions 获取发布前后的钩子脚本配置
+func (c *Config) GetHookOptions() hooks.Options {
+	hookSection := c.file.Section("hooks")
+	return hooks.Options{
+		PrePublish:     hookSection.Key("pre_publish").String(),
+		PostPublish:    hookSection.Key("post_publish").String(),
+		AbortOnFailure: hookSection.Key("abort_on_failure").MustBool(true),
+		Timeout:        time.Duration(hookSection.Key("timeout").MustInt(60)) * time.Second,
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"regexp"
+)
+
+// envReference 配置值中的环境变量引用，形如 ${DEVTO_TOKEN}
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv 展开配置值中的 ${VAR} 引用，未设置的环境变量展开为空字符串。
+// 只识别带花括号的写法，避免误改选择器等本身包含 $ 的值
+func expandEnv(value string) string {
+	return envReference.ReplaceAllStringFunc(value, func(reference string) string {
+		return os.Getenv(envReference.FindStringSubmatch(reference)[1])
+	})
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/auto-blog/baijiahao"
+	"github.com/auto-blog/cnblogs"
+	"github.com/auto-blog/juejin"
+	"github.com/auto-blog/segmentfault"
+	"github.com/auto-blog/toutiao"
+	"github.com/auto-blog/zhihu"
+	"gopkg.in/ini.v1"
+)
+
+// defaultsSection 全局默认配置段，各平台未单独配置的项从这里读取
+const defaultsSection = "defaults"
+
+// platformInfo 配置中的平台标识及对应的平台名称、编辑器地址、正文字数上限（0 表示没有已知上限）
+// 和编辑器是否为富文本编辑器（插入的 Markdown 不会被解析）
+type platformInfo struct {
+	id        string
+	name      string
+	url       func() string
+	maxLength int
+	richText  bool
+}
+
+// platforms 支持在配置中开启的平台，平台专属配置写在 [platform.<id>] 段中
+var platforms = []platformInfo{
+	{juejin.ID, juejin.Name, juejin.URL, juejin.MaxLength, false},
+	{cnblogs.ID, cnblogs.Name, cnblogs.URL, 0, false},
+	{zhihu.ID, zhihu.Name, zhihu.URL, zhihu.MaxLength, true},
+	{segmentfault.ID, segmentfault.Name, segmentfault.URL, 0, false},
+	{toutiao.ID, toutiao.Name, toutiao.URL, 0, true},
+	{baijiahao.ID, baijiahao.Name, baijiahao.URL, 0, true},
+}
+
+// platformByID 按平台标识（不区分大小写）查找平台
+func platformByID(id string) (platformInfo, bool) {
+	for _, p := range platforms {
+		if strings.EqualFold(p.id, strings.TrimSpace(id)) {
+			return p, true
+		}
+	}
+	return platformInfo{}, false
+}
+
+// LookupPlatform 按平台标识或名称（不区分大小写）查找平台，返回平台标识、名称和编辑器地址
+func LookupPlatform(key string) (id, name, url string, ok bool) {
+	for _, p := range platforms {
+		if strings.EqualFold(p.id, strings.TrimSpace(key)) || p.name == strings.TrimSpace(key) {
+			return p.id, p.name, p.url(), true
+		}
+	}
+	return "", "", "", false
+}
+
+// platformKey 按 [platform.<id>] > 旧版配置位置 > [defaults] 的顺序查找平台配置项，都未配置时返回 nil。
+// legacySection 为空表示该配置项没有旧版位置
+func (c *Config) platformKey(id, key, legacySection, legacyKey string) *ini.Key {
+	if section, err := c.file.GetSection("platform." + id); err == nil && section.HasKey(key) {
+		return section.Key(key)
+	}
+	if legacySection != "" {
+		if section, err := c.file.GetSection(legacySection); err == nil && section.HasKey(legacyKey) {
+			return section.Key(legacyKey)
+		}
+	}
+	if section, err := c.file.GetSection(defaultsSection); err == nil && section.HasKey(key) {
+		return section.Key(key)
+	}
+	return nil
+}
+
+// isDefaultsKey 判断 platformKey 返回的配置项是否来自 [defaults]（平台自身和旧版位置都未配置）
+func (c *Config) isDefaultsKey(key *ini.Key) bool {
+	section, err := c.file.GetSection(defaultsSection)
+	return err == nil && section.HasKey(key.Name()) && section.Key(key.Name()) == key
+}
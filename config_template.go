@@ -0,0 +1,28 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// defaultConfig 随程序发布的带注释配置模板（即仓库中的 config.ini）
+//
+//go:embed config.ini
+var defaultConfig []byte
+
+// platformSwitchRegex [publish] 中的平台开关行
+var platformSwitchRegex = regexp.MustCompile(`(?m)^((?:juejin|cnblogs|zhihu|segmentfault|toutiao|baijiahao|staticsite)\s*=\s*)true\s*$`)
+
+// writeDefaultConfig 在 path 生成默认配置文件：内容为配置模板，所有平台开关改为关闭，由用户按需开启
+func writeDefaultConfig(path string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("创建目录失败: %v", err)
+		}
+	}
+	content := platformSwitchRegex.ReplaceAll(defaultConfig, []byte("${1}false"))
+	return os.WriteFile(path, content, 0644)
+}
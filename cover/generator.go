@@ -0,0 +1,302 @@
+package cover
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/auto-blog/tempfiles"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	textWidthRatio = 0.8 // 标题文字最大宽度占封面宽度的比例
+	lineSpacing    = 1.4 // 行高相对字号的倍数
+	maxLines       = 4   // 标题最多绘制的行数，超出部分以省略号结尾
+)
+
+// systemFonts 未配置字体时依次尝试的系统中文字体
+var systemFonts = []string{
+	"/System/Library/Fonts/PingFang.ttc",
+	"/System/Library/Fonts/STHeiti Medium.ttc",
+	"/System/Library/Fonts/Hiragino Sans GB.ttc",
+	"/usr/share/fonts/opentype/noto/NotoSansCJK-Regular.ttc",
+	"/usr/share/fonts/noto-cjk/NotoSansCJK-Regular.ttc",
+	"/usr/share/fonts/truetype/wqy/wqy-microhei.ttc",
+	"C:\\Windows\\Fonts\\msyh.ttc",
+	"C:\\Windows\\Fonts\\simhei.ttf",
+}
+
+// Options 封面生成配置
+type Options struct {
+	Width         int     // 封面宽度（像素）
+	Height        int     // 封面高度（像素）
+	Background    string  // 背景色，如 #1e3c72
+	BackgroundEnd string  // 渐变结束色（从左上到右下），留空为纯色背景
+	TextColor     string  // 标题文字颜色
+	FontFile      string  // 字体文件（.ttf/.otf/.ttc），留空时尝试系统中文字体
+	FontSize      float64 // 标题字号
+}
+
+// DefaultOptions 返回默认的封面配置
+func DefaultOptions() Options {
+	return Options{
+		Width:         1280,
+		Height:        720,
+		Background:    "#1e3c72",
+		BackgroundEnd: "#2a5298",
+		TextColor:     "#ffffff",
+		FontSize:      64,
+	}
+}
+
+// Generator 封面生成器：把标题居中绘制在纯色或渐变背景上
+type Generator struct {
+	options    Options
+	background color.RGBA
+	gradient   *color.RGBA
+	textColor  color.RGBA
+	face       font.Face
+	outputDir  string
+}
+
+// NewGenerator 创建封面生成器，未配置的项使用默认值
+func NewGenerator(options Options) (*Generator, error) {
+	defaults := DefaultOptions()
+	if options.Width <= 0 {
+		options.Width = defaults.Width
+	}
+	if options.Height <= 0 {
+		options.Height = defaults.Height
+	}
+	if options.Background == "" {
+		options.Background = defaults.Background
+	}
+	if options.TextColor == "" {
+		options.TextColor = defaults.TextColor
+	}
+	if options.FontSize <= 0 {
+		options.FontSize = defaults.FontSize
+	}
+
+	generator := &Generator{
+		options:   options,
+		outputDir: filepath.Join(os.TempDir(), "auto-blog-covers"),
+	}
+
+	var err error
+	if generator.background, err = ParseColor(options.Background); err != nil {
+		return nil, fmt.Errorf("背景色配置错误: %v", err)
+	}
+	if options.BackgroundEnd != "" {
+		end, err := ParseColor(options.BackgroundEnd)
+		if err != nil {
+			return nil, fmt.Errorf("渐变结束色配置错误: %v", err)
+		}
+		generator.gradient = &end
+	}
+	if generator.textColor, err = ParseColor(options.TextColor); err != nil {
+		return nil, fmt.Errorf("文字颜色配置错误: %v", err)
+	}
+	if generator.face, err = LoadFace(options.FontFile, options.FontSize); err != nil {
+		return nil, err
+	}
+	return generator, nil
+}
+
+// Generate 根据标题生成封面 PNG 并返回临时文件路径，相同标题和配置只生成一次
+func (g *Generator) Generate(title string) (string, error) {
+	if err := os.MkdirAll(g.outputDir, 0755); err != nil {
+		return "", fmt.Errorf("创建封面目录失败: %v", err)
+	}
+
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%+v", title, g.options)))
+	outputPath := filepath.Join(g.outputDir, hex.EncodeToString(sum[:8])+".png")
+	if _, err := os.Stat(outputPath); err == nil {
+		tempfiles.Register(outputPath)
+		return outputPath, nil
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, g.options.Width, g.options.Height))
+	g.drawBackground(canvas)
+	g.drawTitle(canvas, title)
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("创建封面文件失败: %v", err)
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, canvas); err != nil {
+		os.Remove(outputPath)
+		return "", fmt.Errorf("写入封面失败: %v", err)
+	}
+	tempfiles.Register(outputPath)
+	return outputPath, nil
+}
+
+// drawBackground 绘制纯色或从左上到右下的线性渐变背景
+func (g *Generator) drawBackground(canvas *image.RGBA) {
+	width, height := g.options.Width, g.options.Height
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if g.gradient == nil {
+				canvas.SetRGBA(x, y, g.background)
+				continue
+			}
+			t := float64(x+y) / float64(width+height-2)
+			canvas.SetRGBA(x, y, mix(g.background, *g.gradient, t))
+		}
+	}
+}
+
+// drawTitle 按最大宽度折行后将标题水平、垂直居中绘制
+func (g *Generator) drawTitle(canvas *image.RGBA, title string) {
+	maxWidth := fixed.I(int(float64(g.options.Width) * textWidthRatio))
+	lines := wrapText(g.face, strings.TrimSpace(title), maxWidth)
+
+	lineHeight := int(g.options.FontSize * lineSpacing)
+	metrics := g.face.Metrics()
+	textHeight := lineHeight*(len(lines)-1) + (metrics.Ascent + metrics.Descent).Ceil()
+	top := (g.options.Height-textHeight)/2 + metrics.Ascent.Ceil()
+
+	drawer := &font.Drawer{
+		Dst:  canvas,
+		Src:  image.NewUniform(g.textColor),
+		Face: g.face,
+	}
+	for i, line := range lines {
+		width := drawer.MeasureString(line)
+		drawer.Dot = fixed.Point26_6{
+			X: (fixed.I(g.options.Width) - width) / 2,
+			Y: fixed.I(top + i*lineHeight),
+		}
+		drawer.DrawString(line)
+	}
+}
+
+// wrapText 逐字符折行（兼顾中文无空格的情况），英文单词尽量不拆开
+func wrapText(face font.Face, text string, maxWidth fixed.Int26_6) []string {
+	lines := make([]string, 0)
+	current := ""
+	for _, word := range splitWords(text) {
+		candidate := current + word
+		if current == "" || font.MeasureString(face, candidate) <= maxWidth {
+			current = candidate
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(current))
+		current = strings.TrimLeft(word, " ")
+	}
+	if strings.TrimSpace(current) != "" {
+		lines = append(lines, strings.TrimSpace(current))
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		for len(last) > 0 && font.MeasureString(face, string(last)+"…") > maxWidth {
+			last = last[:len(last)-1]
+		}
+		lines[maxLines-1] = string(last) + "…"
+	}
+	return lines
+}
+
+// splitWords 将文本切分为折行单位：连续的 ASCII 字母数字为一个单位，其余每个字符为一个单位
+func splitWords(text string) []string {
+	words := make([]string, 0)
+	word := ""
+	for _, r := range text {
+		if r < 128 && r != ' ' {
+			word += string(r)
+			continue
+		}
+		if word != "" {
+			words = append(words, word)
+			word = ""
+		}
+		words = append(words, string(r))
+	}
+	if word != "" {
+		words = append(words, word)
+	}
+	return words
+}
+
+// LoadFace 加载字体，未指定时依次尝试系统中文字体，都不存在时使用内置英文字体
+func LoadFace(fontFile string, size float64) (font.Face, error) {
+	candidates := systemFonts
+	if fontFile != "" {
+		candidates = []string{fontFile}
+	}
+
+	for _, path := range candidates {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if fontFile != "" {
+				return nil, fmt.Errorf("读取字体文件失败: %v", err)
+			}
+			continue
+		}
+		face, err := parseFace(data, size)
+		if err != nil {
+			return nil, fmt.Errorf("解析字体 %s 失败: %v", path, err)
+		}
+		return face, nil
+	}
+
+	// 内置字体不包含中文，中文标题需要配置 font_file
+	return parseFace(goregular.TTF, size)
+}
+
+// parseFace 解析 TTF/OTF 或 TTC 字体集合（取第一个字体）
+func parseFace(data []byte, size float64) (font.Face, error) {
+	collection, err := opentype.ParseCollection(data)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := collection.Font(0)
+	if err != nil {
+		return nil, err
+	}
+	return opentype.NewFace(parsed, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+// ParseColor 解析 #RGB 或 #RRGGBB 格式的颜色
+func ParseColor(value string) (color.RGBA, error) {
+	hexValue := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(hexValue) == 3 {
+		hexValue = string([]byte{hexValue[0], hexValue[0], hexValue[1], hexValue[1], hexValue[2], hexValue[2]})
+	}
+	if len(hexValue) != 6 {
+		return color.RGBA{}, fmt.Errorf("无效的颜色 %q，应为 #RRGGBB 格式", value)
+	}
+	rgb, err := strconv.ParseUint(hexValue, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("无效的颜色 %q，应为 #RRGGBB 格式", value)
+	}
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}, nil
+}
+
+// mix 按比例 t（0~1）混合两种颜色
+func mix(from, to color.RGBA, t float64) color.RGBA {
+	blend := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+	return color.RGBA{R: blend(from.R, to.R), G: blend(from.G, to.G), B: blend(from.B, to.B), A: 255}
+}
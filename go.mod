@@ -3,9 +3,13 @@ module github.com/auto-blog
 go 1.20
 
 require (
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/jonfriesen/playwright-go-stealth v0.0.1
 	github.com/playwright-community/playwright-go v0.4201.1
+	github.com/rwcarlsen/goexif v0.0.0-20190401172101-9e8deecbddbd
+	golang.org/x/image v0.15.0
 	gopkg.in/ini.v1 v1.67.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -14,4 +18,6 @@ require (
 	github.com/go-stack/stack v1.8.1 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/exp v0.0.0-20240531132922-fd00a4e0eefc // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
 )
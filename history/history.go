@@ -0,0 +1,123 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/auto-blog/utils"
+)
+
+// Record 单条发布记录
+type Record struct {
+	ArticlePath string    `json:"article_path"` // 文章文件路径
+	Title       string    `json:"title"`        // 发布时的标题
+	Platform    string    `json:"platform"`     // 平台名称
+	ContentHash string    `json:"content_hash"` // 发布时的内容哈希
+	URL         string    `json:"url"`          // 文章链接（可能为空）
+	PublishedAt time.Time `json:"published_at"` // 发布时间
+}
+
+// History 发布历史记录
+type History struct {
+	path     string
+	Records  []Record   `json:"records"`
+	Progress []Progress `json:"progress,omitempty"` // 未完成的发布进度
+	mutex    sync.Mutex
+}
+
+// DefaultPath 返回默认的发布历史文件路径
+func DefaultPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".auto-blog", "history.json"), nil
+}
+
+// Load 加载发布历史，文件不存在时返回空历史
+func Load(path string) (*History, error) {
+	h := &History{
+		path:    path,
+		Records: make([]Record, 0),
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取发布历史失败: %v", err)
+	}
+
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("解析发布历史失败: %v", err)
+	}
+	return h, nil
+}
+
+// Save 保存发布历史
+func (h *History) Save() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	// 每完成一个步骤都会保存，写入中途被中断也不能损坏历史文件
+	return utils.WriteFileAtomic(h.path, data)
+}
+
+// Find 查找文章在指定平台的发布记录
+func (h *History) Find(articlePath, platform string) *Record {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for i := range h.Records {
+		if h.Records[i].ArticlePath == articlePath && h.Records[i].Platform == platform {
+			record := h.Records[i]
+			return &record
+		}
+	}
+	return nil
+}
+
+// Add 添加或更新发布记录（同一文章同一平台只保留最新一条）
+func (h *History) Add(record Record) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if record.PublishedAt.IsZero() {
+		record.PublishedAt = time.Now()
+	}
+
+	for i := range h.Records {
+		if h.Records[i].ArticlePath == record.ArticlePath && h.Records[i].Platform == record.Platform {
+			h.Records[i] = record
+			return
+		}
+	}
+	h.Records = append(h.Records, record)
+}
+
+// IsUnchanged 判断文章在所有指定平台上都已发布且内容未改动
+func (h *History) IsUnchanged(articlePath, contentHash string, platforms []string) bool {
+	if len(platforms) == 0 {
+		return false
+	}
+	for _, platform := range platforms {
+		record := h.Find(articlePath, platform)
+		if record == nil || record.ContentHash != contentHash {
+			return false
+		}
+	}
+	return true
+}
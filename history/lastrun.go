@@ -0,0 +1,69 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LastRunPath 返回记录上次运行时间的状态文件路径
+func LastRunPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".auto-blog", "last_run.json"), nil
+}
+
+// LoadLastRun 读取 articlesDir 上次运行发布的时间，从未运行过时返回零值
+func LoadLastRun(path, articlesDir string) (time.Time, error) {
+	runs, err := loadLastRuns(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return runs[lastRunKey(articlesDir)], nil
+}
+
+// SaveLastRun 记录 articlesDir 本次运行发布的时间（各文章目录分别记录）
+func SaveLastRun(path, articlesDir string, runAt time.Time) error {
+	runs, err := loadLastRuns(path)
+	if err != nil {
+		return err
+	}
+	runs[lastRunKey(articlesDir)] = runAt
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// loadLastRuns 读取状态文件（文章目录绝对路径 -> 上次运行时间），文件不存在时返回空表
+func loadLastRuns(path string) (map[string]time.Time, error) {
+	runs := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return runs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取上次运行时间失败: %v", err)
+	}
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("解析上次运行时间失败: %v", err)
+	}
+	return runs, nil
+}
+
+// lastRunKey 文章目录的绝对路径，无法解析时使用原路径
+func lastRunKey(articlesDir string) string {
+	if abs, err := filepath.Abs(articlesDir); err == nil {
+		return abs
+	}
+	return articlesDir
+}
@@ -0,0 +1,107 @@
+package history
+
+import "time"
+
+// Step 发布步骤。重新运行时编辑器是空白的，提交之前的步骤需要重新填写；
+// 已提交的平台直接记为已发布，不会重复提交
+type Step int
+
+// 数值与已保存的历史文件保持一致
+const (
+	StepNone      Step = 0 // 尚未开始
+	StepTitle     Step = 1 // 已开始填写（各平台先填写标题再填写正文）
+	StepContent   Step = 2 // 正文已填写
+	StepImages    Step = 3 // 图片已全部替换
+	StepSubmitted Step = 4 // 已保存草稿或发布
+)
+
+// String 步骤的中文描述
+func (s Step) String() string {
+	switch s {
+	case StepTitle:
+		return "标题"
+	case StepContent:
+		return "正文"
+	case StepImages:
+		return "图片"
+	case StepSubmitted:
+		return "提交"
+	default:
+		return "未开始"
+	}
+}
+
+// Progress 文章在某个平台上未完成的发布进度：图片未全部替换的平台不记入发布历史，下次运行重新发布；
+// 已提交但还没来得及记入历史（提交后崩溃）的平台，下次运行直接记入历史
+type Progress struct {
+	ArticlePath string    `json:"article_path"` // 文章文件路径
+	Platform    string    `json:"platform"`     // 平台名称
+	ContentHash string    `json:"content_hash"` // 对应的内容哈希，内容变化后进度作废
+	Step        Step      `json:"step"`         // 已完成的步骤
+	ImagesDone  int       `json:"images_done"`  // 已替换完成的图片数
+	UpdatedAt   time.Time `json:"updated_at"`   // 更新时间
+}
+
+// GetProgress 获取文章在指定平台的发布进度，内容已变化时返回 nil
+func (h *History) GetProgress(articlePath, platform, contentHash string) *Progress {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for i := range h.Progress {
+		p := h.Progress[i]
+		if p.ArticlePath == articlePath && p.Platform == platform {
+			if p.ContentHash != contentHash {
+				return nil
+			}
+			return &p
+		}
+	}
+	return nil
+}
+
+// IsCompleted 判断文章在指定平台是否已完成发布（内容未改动）
+func (h *History) IsCompleted(articlePath, platform, contentHash string) bool {
+	record := h.Find(articlePath, platform)
+	return record != nil && record.ContentHash == contentHash
+}
+
+// UpdateProgress 更新发布进度并立即保存，崩溃后下次运行仍能知道哪些平台未完成
+func (h *History) UpdateProgress(articlePath, platform, contentHash string, step Step, imagesDone int) error {
+	h.mutex.Lock()
+	progress := Progress{
+		ArticlePath: articlePath,
+		Platform:    platform,
+		ContentHash: contentHash,
+		Step:        step,
+		ImagesDone:  imagesDone,
+		UpdatedAt:   time.Now(),
+	}
+
+	updated := false
+	for i := range h.Progress {
+		if h.Progress[i].ArticlePath == articlePath && h.Progress[i].Platform == platform {
+			h.Progress[i] = progress
+			updated = true
+			break
+		}
+	}
+	if !updated {
+		h.Progress = append(h.Progress, progress)
+	}
+	h.mutex.Unlock()
+
+	return h.Save()
+}
+
+// ClearProgress 清除文章在指定平台的发布进度（发布完成后调用）
+func (h *History) ClearProgress(articlePath, platform string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for i := range h.Progress {
+		if h.Progress[i].ArticlePath == articlePath && h.Progress[i].Platform == platform {
+			h.Progress = append(h.Progress[:i], h.Progress[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/article"
+)
+
+// 钩子名称，通过 AUTO_BLOG_HOOK 环境变量传给脚本
+const (
+	PrePublish  = "pre_publish"
+	PostPublish = "post_publish"
+)
+
+// Options 钩子配置
+type Options struct {
+	PrePublish     string        // 发布前执行的脚本路径
+	PostPublish    string        // 发布后执行的脚本路径
+	AbortOnFailure bool          // pre_publish 非零退出时是否中止该文章的发布
+	Timeout        time.Duration // 单次执行超时，0 表示不限制
+}
+
+// Runner 钩子脚本执行器。
+// 文章信息通过环境变量（AUTO_BLOG_TITLE、AUTO_BLOG_PATH 等）传给脚本，
+// 同时将文章的 JSON 写入脚本的标准输入
+type Runner struct {
+	options Options
+}
+
+// NewRunner 创建钩子执行器，未配置任何脚本时返回 nil
+func NewRunner(options Options) *Runner {
+	if options.PrePublish == "" && options.PostPublish == "" {
+		return nil
+	}
+	return &Runner{options: options}
+}
+
+// BeforePublish 执行 pre_publish 脚本，返回 false 表示应中止该文章的发布
+func (r *Runner) BeforePublish(art *article.Article) bool {
+	if r == nil || r.options.PrePublish == "" {
+		return true
+	}
+	if err := r.run(PrePublish, r.options.PrePublish, art, nil); err != nil {
+		if r.options.AbortOnFailure {
+			log.Printf("⛔ 《%s》pre_publish 钩子失败，已中止发布: %v", art.Title, err)
+			return false
+		}
+		log.Printf("⚠️ 《%s》pre_publish 钩子失败: %v", art.Title, err)
+	}
+	return true
+}
+
+// AfterPublish 执行 post_publish 脚本，platforms 为发布成功的平台
+func (r *Runner) AfterPublish(art *article.Article, platforms []string) {
+	if r == nil || r.options.PostPublish == "" || len(platforms) == 0 {
+		return
+	}
+	if err := r.run(PostPublish, r.options.PostPublish, art, platforms); err != nil {
+		log.Printf("⚠️ 《%s》post_publish 钩子失败: %v", art.Title, err)
+	}
+}
+
+// run 执行脚本，脚本输出写入日志
+func (r *Runner) run(hook, script string, art *article.Article, platforms []string) error {
+	ctx := context.Background()
+	if r.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.options.Timeout)
+		defer cancel()
+	}
+
+	input, err := json.Marshal(art)
+	if err != nil {
+		return fmt.Errorf("序列化文章失败: %v", err)
+	}
+
+	cmd := exec.CommandContext(ctx, script)
+	cmd.Env = append(os.Environ(),
+		"AUTO_BLOG_HOOK="+hook,
+		"AUTO_BLOG_TITLE="+art.Title,
+		"AUTO_BLOG_PATH="+art.Path,
+		"AUTO_BLOG_IMAGES="+strconv.Itoa(len(art.Images)),
+		"AUTO_BLOG_PUBLISH_AT="+art.Meta.PublishAt,
+		"AUTO_BLOG_TAGS="+strings.Join(art.Meta.Tags, ","),
+		"AUTO_BLOG_PLATFORMS="+strings.Join(platforms, ","),
+	)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = log.Writer()
+	cmd.Stderr = log.Writer()
+
+	log.Printf("🪝 执行 %s 钩子: %s（《%s》）", hook, script, art.Title)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("执行超时（%s）", r.options.Timeout)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package imagehost
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options 图床配置
+type Options struct {
+	UploadURL string // 上传接口地址（multipart/form-data POST）
+	FileField string // 文件字段名，默认 file
+	URLField  string // 响应 JSON 中图片链接的字段路径，用 . 分隔，默认 data.url
+	Token     string // 可选，作为 Authorization 请求头发送
+}
+
+// Uploader 图床上传器，同一张图片只上传一次
+type Uploader struct {
+	options Options
+	client  *http.Client
+	cache   map[string]string
+	mutex   sync.Mutex
+}
+
+// NewUploader 创建图床上传器
+func NewUploader(options Options) (*Uploader, error) {
+	if options.UploadURL == "" {
+		return nil, fmt.Errorf("未配置图床上传地址")
+	}
+	if options.FileField == "" {
+		options.FileField = "file"
+	}
+	if options.URLField == "" {
+		options.URLField = "data.url"
+	}
+	return &Uploader{
+		options: options,
+		client:  &http.Client{Timeout: 60 * time.Second},
+		cache:   make(map[string]string),
+	}, nil
+}
+
+// Upload 上传图片并返回图片链接，已上传过的图片直接返回缓存的链接
+func (u *Uploader) Upload(imagePath string) (string, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if url, ok := u.cache[imagePath]; ok {
+		return url, nil
+	}
+
+	url, err := u.upload(imagePath)
+	if err != nil {
+		return "", err
+	}
+	u.cache[imagePath] = url
+	return url, nil
+}
+
+// upload 以 multipart/form-data 上传图片并从响应中提取链接
+func (u *Uploader) upload(imagePath string) (string, error) {
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return "", fmt.Errorf("打开图片失败: %v", err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(u.options.FileField, filepath.Base(imagePath))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return "", fmt.Errorf("读取图片失败: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, u.options.UploadURL, &body)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	if u.options.Token != "" {
+		request.Header.Set("Authorization", u.options.Token)
+	}
+
+	response, err := u.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("上传图片失败: %v", err)
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取图床响应失败: %v", err)
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("图床返回错误状态 %d: %s", response.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("解析图床响应失败: %v", err)
+	}
+	url, ok := lookupField(result, u.options.URLField)
+	if !ok || url == "" {
+		return "", fmt.Errorf("图床响应中未找到字段 %s: %s", u.options.URLField, strings.TrimSpace(string(data)))
+	}
+	return url, nil
+}
+
+// lookupField 按 . 分隔的路径从 JSON 中取出字符串字段
+func lookupField(value interface{}, path string) (string, bool) {
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if value, ok = object[key]; !ok {
+			return "", false
+		}
+	}
+	text, ok := value.(string)
+	return text, ok
+}
@@ -1,32 +1,54 @@
 package installer
 
 import (
+	"fmt"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/playwright-community/playwright-go"
 )
 
+// downloadHostEnv Playwright 驱动和浏览器下载地址的环境变量
+const downloadHostEnv = "PLAYWRIGHT_DOWNLOAD_HOST"
+
+// recommendedMirror 国内可用的 Playwright 下载镜像，安装失败时在提示中推荐
+const recommendedMirror = "https://npmmirror.com/mirrors/playwright"
+
+// Options 安装配置
+type Options struct {
+	DownloadHost   string // 驱动和浏览器的下载镜像地址，环境变量 PLAYWRIGHT_DOWNLOAD_HOST 优先
+	ExecutablePath string // 本机已下载的 Chrome/Chromium 可执行文件路径，配置后跳过浏览器下载
+}
+
 // EnsurePlaywrightInstalled 检查并安装 Playwright 浏览器
-func EnsurePlaywrightInstalled() error {
+func EnsurePlaywrightInstalled(options Options) error {
+	applyDownloadHost(options.DownloadHost)
+	if options.ExecutablePath != "" {
+		if _, err := os.Stat(options.ExecutablePath); err != nil {
+			return fmt.Errorf("[browser] executable_path 指定的浏览器不存在: %v", err)
+		}
+		log.Printf("使用本地浏览器: %s", options.ExecutablePath)
+	}
+
 	// 尝试启动 playwright 来检查是否已安装
 	pw, err := playwright.Run()
 	if err != nil {
 		if strings.Contains(err.Error(), "no such file or directory") ||
 			strings.Contains(err.Error(), "could not start driver") {
 			log.Println("检测到 Playwright 未安装，开始安装...")
-			return installPlaywright()
+			return installPlaywright(options)
 		}
 		return err
 	}
 
 	// 尝试启动浏览器来验证安装
-	browser, err := pw.Chromium.Launch()
+	browser, err := pw.Chromium.Launch(launchOptions(options))
 	if err != nil {
 		pw.Stop()
-		if strings.Contains(err.Error(), "Executable doesn't exist") {
+		if strings.Contains(err.Error(), "Executable doesn't exist") && options.ExecutablePath == "" {
 			log.Println("检测到浏览器文件缺失，重新安装...")
-			return installPlaywright()
+			return installPlaywright(options)
 		}
 		return err
 	}
@@ -36,17 +58,41 @@ func EnsurePlaywrightInstalled() error {
 	return nil
 }
 
+// applyDownloadHost 未设置环境变量时使用配置的下载镜像（驱动和浏览器的下载都读取该环境变量）
+func applyDownloadHost(host string) {
+	if current := os.Getenv(downloadHostEnv); current != "" {
+		log.Printf("使用环境变量 %s 指定的下载地址: %s", downloadHostEnv, current)
+		return
+	}
+	if host == "" {
+		return
+	}
+	os.Setenv(downloadHostEnv, strings.TrimSuffix(host, "/"))
+	log.Printf("使用下载镜像: %s", host)
+}
+
+// launchOptions 验证安装时的浏览器启动参数，配置了本地浏览器时使用该浏览器
+func launchOptions(options Options) playwright.BrowserTypeLaunchOptions {
+	var launch playwright.BrowserTypeLaunchOptions
+	if options.ExecutablePath != "" {
+		launch.ExecutablePath = playwright.String(options.ExecutablePath)
+	}
+	return launch
+}
+
 // installPlaywright 安装 Playwright 浏览器
-func installPlaywright() error {
+func installPlaywright(options Options) error {
 	log.Println("正在安装 Playwright Chromium 浏览器...")
 
-	// 只安装 Chromium 浏览器
-	options := &playwright.RunOptions{
-		Browsers: []string{"chromium"},
+	// 只安装 Chromium 浏览器，指定了本地浏览器时只安装驱动
+	runOptions := &playwright.RunOptions{
+		Browsers:            []string{"chromium"},
+		SkipInstallBrowsers: options.ExecutablePath != "",
 	}
 
-	if err := playwright.Install(options); err != nil {
+	if err := playwright.Install(runOptions); err != nil {
 		log.Printf("安装失败: %v", err)
+		logMirrorHint()
 		return err
 	}
 
@@ -60,7 +106,7 @@ func installPlaywright() error {
 	defer pw.Stop()
 
 	// 测试浏览器是否可以启动
-	browser, err := pw.Chromium.Launch()
+	browser, err := pw.Chromium.Launch(launchOptions(options))
 	if err != nil {
 		return err
 	}
@@ -68,4 +114,15 @@ func installPlaywright() error {
 
 	log.Println("浏览器验证成功")
 	return nil
-}
\ No newline at end of file
+}
+
+// logMirrorHint 下载失败时提示配置镜像或使用本地浏览器
+func logMirrorHint() {
+	if host := os.Getenv(downloadHostEnv); host != "" {
+		log.Printf("💡 当前下载地址为 %s，请确认镜像可用，或更换为其它镜像（如 %s）", host, recommendedMirror)
+	} else {
+		log.Printf("💡 国内网络可能无法访问官方下载源，可在 config.ini 的 [browser] download_host 中配置镜像，"+
+			"或设置环境变量 %s（如 %s）", downloadHostEnv, recommendedMirror)
+	}
+	log.Println("💡 也可以在 [browser] executable_path 中指定本机已安装的 Chrome/Chromium，跳过浏览器下载")
+}
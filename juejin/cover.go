@@ -0,0 +1,36 @@
+package juejin
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+)
+
+// UploadCover 在发布面板的"文章封面"中上传封面图
+func (p *Publisher) UploadCover(imagePath string) error {
+	absPath, err := filepath.Abs(imagePath)
+	if err != nil {
+		return fmt.Errorf("获取封面绝对路径失败: %v", err)
+	}
+
+	if err := p.openPublishPanel(); err != nil {
+		return err
+	}
+
+	coverInput := p.page.Locator(selectors.Get(Name, selectors.Cover)).First()
+	if err := common.WaitAttached(coverInput, 5*time.Second); err != nil {
+		return fmt.Errorf("未找到封面上传控件: %v", err)
+	}
+	if err := coverInput.SetInputFiles([]string{absPath}); err != nil {
+		return fmt.Errorf("上传封面失败: %v", err)
+	}
+
+	// 等待封面上传完成
+	time.Sleep(3 * time.Second)
+	log.Printf("[掘金] 🖼️ 封面已上传: %s", filepath.Base(absPath))
+	return nil
+}
@@ -0,0 +1,45 @@
+package juejin
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+)
+
+// ensureMarkdownParsed 确认正文在 bytemd 预览区中被解析为 Markdown（标题渲染成标题、代码块渲染成代码块）。
+// 粘贴的剪贴板带有富文本时，掘金会把它转换成转义后的 Markdown（如 \# 标题），预览中不再渲染；
+// 此时改用 CodeMirror 直接写入原始 Markdown 源码。预览区被关闭时无法判断，跳过检查
+func (p *Publisher) ensureMarkdownParsed(art *article.Article, content string) error {
+	stats := art.Stats()
+	previewSelector := selectors.Get(Name, selectors.Preview)
+	render, err := common.InspectMarkdownRender(p.page, previewSelector)
+	if err != nil {
+		return err
+	}
+	if !render.Found {
+		log.Printf("[掘金] 未找到预览区，跳过 Markdown 解析检查")
+		return nil
+	}
+	if render.Parsed(stats) {
+		return nil
+	}
+	log.Printf("[掘金] ⚠️ 正文未被解析为 Markdown（%s），改为直接写入 Markdown 源码", render.Describe(stats))
+
+	if err := p.SetContent(content); err != nil {
+		return err
+	}
+	// 等待预览区重新渲染
+	time.Sleep(time.Second)
+	if render, err = common.InspectMarkdownRender(p.page, previewSelector); err != nil {
+		return err
+	}
+	if !render.Parsed(stats) {
+		return fmt.Errorf("写入 Markdown 源码后预览仍未解析（%s）", render.Describe(stats))
+	}
+	log.Printf("[掘金] ✅ Markdown 已正确解析（%s）", render.Describe(stats))
+	return nil
+}
@@ -0,0 +1,70 @@
+package juejin
+
+import (
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+	"github.com/auto-blog/selectors"
+	"github.com/playwright-community/playwright-go"
+)
+
+// Name 平台名称
+const Name = "掘金"
+
+// ID 平台标识，用于配置段 [platform.juejin] 和 frontmatter 中的平台映射
+const ID = "juejin"
+
+// MaxLength 掘金文章正文的字数上限，未配置 max_length 时使用
+const MaxLength = 20000
+
+// Platform 掘金平台（实现 platform.Platform 接口）
+type Platform struct {
+	saveSession SaveSessionFunc
+	articles    []*article.Article
+	options     Options
+}
+
+// NewPlatform 创建掘金平台
+func NewPlatform(saveSession SaveSessionFunc, articles []*article.Article, options Options) *Platform {
+	return &Platform{
+		saveSession: saveSession,
+		articles:    articles,
+		options:     options,
+	}
+}
+
+// GetName 获取平台名称
+func (p *Platform) GetName() string {
+	return Name
+}
+
+// GetURL 获取平台URL
+func (p *Platform) GetURL() string {
+	return URL()
+}
+
+// CheckAndWaitForLogin 检查并等待登录
+func (p *Platform) CheckAndWaitForLogin(page playwright.Page) {
+	NewLoginChecker(URL(), p.saveSession, p.articles).CheckAndWaitForLogin(page)
+}
+
+// NewPublisher 创建文章发布器
+func (p *Platform) NewPublisher(page playwright.Page) platform.Publisher {
+	return NewPublisherWithOptions(page, p.options)
+}
+
+// WaitForEditor 等待掘金编辑器
+func (p *Platform) WaitForEditor(page playwright.Page) bool {
+	titleLocator := page.Locator(selectors.Get(Name, selectors.Title))
+	editorLocator := page.Locator(selectors.Get(Name, selectors.Editor))
+	
+	err := common.WaitVisible(titleLocator, 5*time.Second)
+	if err != nil {
+		return false
+	}
+	
+	err = common.WaitVisible(editorLocator, 5*time.Second)
+	return err == nil
+}
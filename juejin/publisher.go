@@ -8,12 +8,33 @@ import (
 
 	"github.com/auto-blog/article"
 	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
 	"github.com/playwright-community/playwright-go"
 )
 
+// Options 掘金发布设置
+type Options struct {
+	// InputMethod 正文输入方式：type 逐行打字（默认）；paste 在临时页面中复制带占位符的完整 Markdown，
+	// 一次性粘贴到 bytemd 编辑器，速度快且不会触发编辑器的自动补全
+	InputMethod common.InputMethod
+}
+
+// ParseInputMethod 解析掘金的正文输入方式，空字符串返回默认的打字方式
+func ParseInputMethod(value string) (common.InputMethod, error) {
+	switch method := common.InputMethod(strings.ToLower(strings.TrimSpace(value))); method {
+	case "":
+		return common.InputMethodType, nil
+	case common.InputMethodType, common.InputMethodPaste:
+		return method, nil
+	default:
+		return "", fmt.Errorf("未知的输入方式: %s（可选 type/paste）", value)
+	}
+}
+
 // Publisher 掘金文章发布器
 type Publisher struct {
-	page playwright.Page
+	page    playwright.Page
+	options Options
 }
 
 // NewPublisher 创建掘金文章发布器
@@ -23,9 +44,19 @@ func NewPublisher(page playwright.Page) *Publisher {
 	}
 }
 
+// NewPublisherWithOptions 创建带发布设置的掘金文章发布器
+func NewPublisherWithOptions(page playwright.Page, options Options) *Publisher {
+	return &Publisher{
+		page:    page,
+		options: options,
+	}
+}
+
 // PublishArticle 发布文章到掘金
 func (p *Publisher) PublishArticle(art *article.Article) error {
 	log.Printf("开始发布文章到掘金: %s", art.Title)
+	// 掘金编辑器不支持提示框语法（> [!NOTE]、:::tip），改写为普通引用块
+	art = art.WithQuotedAdmonitions()
 	
 	// 1. 填写标题
 	if err := p.fillTitle(art.Title); err != nil {
@@ -34,7 +65,7 @@ func (p *Publisher) PublishArticle(art *article.Article) error {
 		log.Println("✅ 标题填写完成")
 	}
 	
-	// 2. 填写正文
+	// 2. 填写正文（掘金 markdown 编辑器支持代码块 info string 中的额外参数，原样保留）
 	if err := p.fillContent(art); err != nil {
 		log.Printf("⚠️ 正文填写遇到问题: %v", err)
 	} else {
@@ -48,14 +79,11 @@ func (p *Publisher) PublishArticle(art *article.Article) error {
 // fillTitle 填写文章标题
 func (p *Publisher) fillTitle(title string) error {
 	// 等待标题输入框出现并可见
-	titleSelector := "input.title-input"
+	titleSelector := selectors.Get(Name, selectors.Title)
 	titleLocator := p.page.Locator(titleSelector)
 	
 	// 等待元素可见
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000), // 10秒超时
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(titleLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待标题输入框超时: %v", err)
 	}
 	
@@ -72,19 +100,33 @@ func (p *Publisher) fillTitle(title string) error {
 
 // fillContent 填写文章正文（使用统一方法）
 func (p *Publisher) fillContent(art *article.Article) error {
+	// 默认打字输入；配置为粘贴时经临时页面一次性粘贴完整 Markdown（bytemd 按纯文本接收，不需要解析对话框）
+	inputMethod := p.options.InputMethod
+	if inputMethod == "" {
+		inputMethod = common.InputMethodType
+	}
+
 	// 使用统一的富文本处理器
 	config := common.RichContentConfig{
 		PlatformName:        "掘金",
-		EditorSelector:      "div.CodeMirror-scroll", // CodeMirror编辑器
+		EditorSelector:      selectors.Get(Name, selectors.Editor), // CodeMirror编辑器
 		TitleSelector:       "",                     // 标题已在fillTitle中处理
 		UseMarkdownMode:     false,                  // 掘金不需要markdown解析对话框
 		ParseButtonCheck:    "",
-		InputMethod:         common.InputMethodType, // 掘金使用打字输入方式
+		InputMethod:         inputMethod,
 		SkipImageReplacement: true,                  // 跳过图片替换，统一在混合模式中处理
 	}
 	
 	handler := common.NewRichContentHandler(p.page, config)
-	return handler.FillContent(art)
+	if err := handler.FillContent(art); err != nil {
+		return err
+	}
+
+	// 粘贴的内容可能被转换成转义后的文本，检查预览区的标题等是否渲染，未解析时直接写入 Markdown 源码
+	if err := p.ensureMarkdownParsed(art, handler.PrepareMarkdownWithPlaceholders(art)); err != nil {
+		log.Printf("[掘金] ⚠️ %v", err)
+	}
+	return nil
 }
 
 // fillTextOnlyContent 填写纯文本内容（无图片）
@@ -93,16 +135,17 @@ func (p *Publisher) fillTextOnlyContent(content []string) error {
 	
 	// 使用JavaScript直接设置CodeMirror内容，避免缩进问题
 	jsCode := `
-		(function(content) {
+		(function([content, selector]) {
 			// 查找CodeMirror实例
-			const cmElement = document.querySelector('.CodeMirror');
+			const editor = document.querySelector(selector);
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				// 直接设置CodeMirror的值，避免缩进问题
 				cmElement.CodeMirror.setValue(content);
 				return true;
 			} else {
 				// 降级方案：直接设置到可编辑区域
-				const editableArea = document.querySelector('.CodeMirror-code');
+				const editableArea = editor && editor.querySelector('.CodeMirror-code');
 				if (editableArea) {
 					editableArea.textContent = content;
 					return true;
@@ -111,7 +154,7 @@ func (p *Publisher) fillTextOnlyContent(content []string) error {
 			return false;
 		})
 	`
-	_, err := p.page.Evaluate(jsCode, fullContent)
+	_, err := p.page.Evaluate(jsCode, []interface{}{fullContent, selectors.Get(Name, selectors.Editor)})
 	
 	if err != nil {
 		log.Printf("JavaScript设置失败，使用键盘输入: %v", err)
@@ -126,12 +169,52 @@ func (p *Publisher) fillTextOnlyContent(content []string) error {
 
 // fillContentWithImages 填写带图片的内容 - 使用通用图片处理器
 func (p *Publisher) fillContentWithImages(art *article.Article) error {
-	// 创建掘金的图片上传配置
-	config := common.ImageUploadConfig{
-		PlatformName: "掘金",
+	uploader := common.NewImageUploader(p.page, p.imageUploadConfig(), p)
+	return uploader.ProcessArticleWithImages(art)
+}
+
+// UploadImage 查找占位符并通过编辑器的上传控件插入图片
+func (p *Publisher) UploadImage(placeholder string, img article.Image) error {
+	uploader := common.NewImageUploader(p.page, p.imageUploadConfig(), p)
+	return uploader.UploadAtPlaceholder(placeholder, &img)
+}
+
+// ReplaceTextWithText 查找占位符并替换为文本（图床链接或跳过图片时的 alt 文本）
+func (p *Publisher) ReplaceTextWithText(placeholder, text string) error {
+	return common.ReplaceTextInMarkdownEditor(p.page, selectors.Get(Name, selectors.Editor), placeholder, text)
+}
+
+// imageUploadConfig 掘金的图片上传配置
+func (p *Publisher) imageUploadConfig() common.ImageUploadConfig {
+	return common.ImageUploadConfig{
+		PlatformName:   "掘金",
+		EditorSelector: selectors.Get(Name, selectors.Editor),
 		UploadButtonJs: `
 			(function() {
-				const uploadButton = document.querySelectorAll('div[class="bytemd-toolbar-icon bytemd-tippy"]')[5];
+				const icons = Array.from(document.querySelectorAll('.bytemd-toolbar .bytemd-toolbar-icon, div.bytemd-toolbar-icon'));
+				const label = (el) => [
+					el.getAttribute('bytemd-tippy-path'),
+					el.getAttribute('title'),
+					el.getAttribute('aria-label'),
+					el.dataset ? el.dataset.tippyContent : ''
+				].filter(Boolean).join(' ').toLowerCase();
+
+				// 1. 按 title / aria-label 等提示文本匹配
+				let uploadButton = icons.find(el => {
+					const text = label(el);
+					return text.includes('图片') || text.includes('image');
+				});
+
+				// 2. 按 SVG 图标特征匹配（bytemd 图片图标包含 picture 类名或 title）
+				if (!uploadButton) {
+					uploadButton = icons.find(el => {
+						const svg = el.querySelector('svg');
+						if (!svg) return false;
+						const svgText = ((svg.getAttribute('class') || '') + ' ' + (svg.querySelector('title') ? svg.querySelector('title').textContent : '')).toLowerCase();
+						return svgText.includes('image') || svgText.includes('picture') || svgText.includes('图片');
+					});
+				}
+
 				if (uploadButton) {
 					uploadButton.click();
 					return true;
@@ -139,26 +222,30 @@ func (p *Publisher) fillContentWithImages(art *article.Article) error {
 				return false;
 			})()
 		`,
-		ImageCheckJs: `
+		UploadDebugJs: `
 			(function() {
-				const images = document.querySelectorAll('.CodeMirror img, .bytemd-body img, .markdown-body img');
-				return images.length > 0;
+				return Array.from(document.querySelectorAll('.bytemd-toolbar .bytemd-toolbar-icon, div.bytemd-toolbar-icon')).map((el, i) => {
+					const svg = el.querySelector('svg');
+					return '#' + i +
+						' class=' + (el.className || '') +
+						' title=' + (el.getAttribute('title') || '') +
+						' aria-label=' + (el.getAttribute('aria-label') || '') +
+						' tippy-path=' + (el.getAttribute('bytemd-tippy-path') || '') +
+						' svg=' + (svg ? (svg.getAttribute('class') || 'svg') : '');
+				});
 			})()
 		`,
 		UploadTimeout: 15 * time.Second,
 		IntervalDelay: 2 * time.Second,
 	}
-	
-	// 使用通用图片上传器
-	uploader := common.NewImageUploader(p.page, config, p)
-	return uploader.ProcessArticleWithImages(art)
 }
 
 // SetContent 实现EditorHandler接口 - 设置编辑器内容
 func (p *Publisher) SetContent(content string) error {
 	jsCode := `
-		(function(content) {
-			const cmElement = document.querySelector('.CodeMirror');
+		(function([content, selector]) {
+			const editor = document.querySelector(selector);
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				cmElement.CodeMirror.setValue(content);
 				return true;
@@ -166,7 +253,7 @@ func (p *Publisher) SetContent(content string) error {
 			return false;
 		})
 	`
-	_, err := p.page.Evaluate(jsCode, content)
+	_, err := p.page.Evaluate(jsCode, []interface{}{content, selectors.Get(Name, selectors.Editor)})
 	if err != nil {
 		return fmt.Errorf("设置编辑器内容失败: %v", err)
 	}
@@ -176,8 +263,9 @@ func (p *Publisher) SetContent(content string) error {
 // FindAndSelectText 实现EditorHandler接口 - 查找并选中文本
 func (p *Publisher) FindAndSelectText(text string) error {
 	jsCode := `
-		(function(searchText) {
-			const cmElement = document.querySelector('.CodeMirror');
+		(function([searchText, selector]) {
+			const editor = document.querySelector(selector);
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				const cm = cmElement.CodeMirror;
 				const content = cm.getValue();
@@ -196,7 +284,7 @@ func (p *Publisher) FindAndSelectText(text string) error {
 			return false;
 		})
 	`
-	result, err := p.page.Evaluate(jsCode, text)
+	result, err := p.page.Evaluate(jsCode, []interface{}{text, selectors.Get(Name, selectors.Editor)})
 	if err != nil {
 		return fmt.Errorf("查找文本失败: %v", err)
 	}
@@ -214,9 +302,10 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 	log.Printf("[掘金] 🔍 开始替换占位符: %s", placeholder)
 	
 	// 1. 使用JavaScript查找并选中占位符
-	jsCode := fmt.Sprintf(`
-		(function(searchText) {
-			const cmElement = document.querySelector('.CodeMirror');
+	jsCode := `
+		(function([searchText, selector]) {
+			const editor = document.querySelector(selector);
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				const cm = cmElement.CodeMirror;
 				const content = cm.getValue();
@@ -233,10 +322,10 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 				}
 			}
 			return false;
-		})(%q)
-	`, placeholder)
+		})
+	`
 	
-	result, err := p.page.Evaluate(jsCode)
+	result, err := p.page.Evaluate(jsCode, []interface{}{placeholder, selectors.Get(Name, selectors.Editor)})
 	if err != nil {
 		return fmt.Errorf("查找占位符失败: %v", err)
 	}
@@ -258,7 +347,7 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 	}
 	
 	// 4. 粘贴图片到编辑器
-	if err := common.PasteImageToEditor(p.page); err != nil {
+	if err := common.PasteImageToEditor(p.page, selectors.Get(Name, selectors.Editor)); err != nil {
 		return fmt.Errorf("粘贴图片失败: %v", err)
 	}
 	
@@ -279,9 +368,10 @@ func (p *Publisher) waitForImageUploadComplete() error {
 	// 等待图片出现在编辑器中，检查是否有新的img标签
 	for i := 0; i < 10; i++ { // 最多等待10秒
 		result, err := p.page.Evaluate(`
-			(function() {
+			(function([selector, previewSelector]) {
 				// 检查CodeMirror编辑器中是否有图片
-				const cmElement = document.querySelector('.CodeMirror');
+				const editor = document.querySelector(selector);
+				const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 				if (cmElement && cmElement.CodeMirror) {
 					const content = cmElement.CodeMirror.getValue();
 					// 检查是否包含图片markdown语法或HTML img标签
@@ -293,14 +383,15 @@ func (p *Publisher) waitForImageUploadComplete() error {
 				}
 				
 				// 也检查预览区域或编辑器渲染区域是否有图片
-				const images = document.querySelectorAll('.CodeMirror img, .bytemd-body img, .markdown-body img, .bytemd-preview img');
-				if (images.length > 0) {
-					return { success: true, type: 'rendered', count: images.length };
+				const preview = document.querySelector(previewSelector);
+				const count = (editor ? editor.querySelectorAll('img').length : 0) + (preview ? preview.querySelectorAll('img').length : 0);
+				if (count > 0) {
+					return { success: true, type: 'rendered', count: count };
 				}
 				
 				return { success: false };
-			})()
-		`)
+			})
+		`, []interface{}{selectors.Get(Name, selectors.Editor), selectors.Get(Name, selectors.Preview)})
 		
 		if err != nil {
 			log.Printf("[掘金] 检查图片状态失败: %v", err)
@@ -321,22 +412,16 @@ func (p *Publisher) waitForImageUploadComplete() error {
 // WaitForEditor 等待编辑器加载完成
 func (p *Publisher) WaitForEditor() error {
 	// 等待标题输入框
-	titleSelector := "input.title-input"
+	titleSelector := selectors.Get(Name, selectors.Title)
 	titleLocator := p.page.Locator(titleSelector)
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(15000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(titleLocator, 15*time.Second); err != nil {
 		return fmt.Errorf("等待标题输入框超时: %v", err)
 	}
 	
 	// 等待CodeMirror编辑器
-	editorSelector := "div.CodeMirror-scroll"
+	editorSelector := selectors.Get(Name, selectors.Editor)
 	editorLocator := p.page.Locator(editorSelector)
-	if err := editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(15000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(editorLocator, 15*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器超时: %v", err)
 	}
 	
@@ -0,0 +1,93 @@
+package juejin
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// SchedulePublish 打开发布面板，开启定时发布并填入发布时间（不点击"确定并发布"）
+func (p *Publisher) SchedulePublish(publishAt time.Time) error {
+	// 1. 打开发布面板（面板中还需确认才会真正发布）
+	if err := p.openPublishPanel(); err != nil {
+		return err
+	}
+
+	// 2. 开启定时发布并填写时间
+	result, err := p.page.Evaluate(`
+		(publishAt) => {
+			const isVisible = (el) => el && el.offsetParent !== null;
+			const textOf = (el) => (el.innerText || el.textContent || '').trim();
+
+			const row = Array.from(document.querySelectorAll('.form-item, .item, div, label'))
+				.filter(el => isVisible(el) && textOf(el).includes('定时发布') && textOf(el).length < 40)
+				.sort((a, b) => textOf(a).length - textOf(b).length)[0];
+			if (!row) {
+				return { success: false, error: '发布面板中未找到定时发布选项' };
+			}
+
+			const toggle = row.querySelector('input[type="checkbox"], [role="switch"], .byte-switch, [class*="switch"]');
+			if (toggle) {
+				const checked = toggle.checked === true || toggle.getAttribute('aria-checked') === 'true' ||
+					/checked|active/.test(toggle.className || '');
+				if (!checked) toggle.click();
+			}
+
+			// 开关打开后会出现时间选择框
+			const panel = row.closest('.publish-popup, .panel, form') || document;
+			const input = Array.from(panel.querySelectorAll('input'))
+				.find(el => isVisible(el) && el.type !== 'checkbox' && /时间|日期|time|date/i.test((el.placeholder || '') + (el.className || '')));
+			if (!input) {
+				return { success: false, error: '未找到定时发布时间输入框' };
+			}
+
+			const setter = Object.getOwnPropertyDescriptor(HTMLInputElement.prototype, 'value').set;
+			input.focus();
+			setter.call(input, publishAt);
+			input.dispatchEvent(new Event('input', { bubbles: true }));
+			input.dispatchEvent(new Event('change', { bubbles: true }));
+			input.dispatchEvent(new KeyboardEvent('keydown', { key: 'Enter', bubbles: true }));
+			input.blur();
+
+			return { success: true, value: input.value };
+		}
+	`, publishAt.Format("2006-01-02 15:04"))
+	if err != nil {
+		return fmt.Errorf("设置定时发布失败: %v", err)
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("设置定时发布返回了无法识别的结果: %v", result)
+	}
+	if success, _ := resultMap["success"].(bool); !success {
+		errorMsg, _ := resultMap["error"].(string)
+		return fmt.Errorf("%s", errorMsg)
+	}
+
+	log.Printf("[掘金] ⏰ 定时发布时间已填写: %v", resultMap["value"])
+	return nil
+}
+
+// openPublishPanel 点击顶部"发布"按钮（修改已发布文章时为"更新"）打开发布面板，面板已打开时不再点击（再次点击会关闭面板）
+func (p *Publisher) openPublishPanel() error {
+	opened, err := p.page.Evaluate(`
+		(() => {
+			const isVisible = (el) => el && el.offsetParent !== null;
+			if (isVisible(document.querySelector('.publish-popup'))) return true;
+			const button = Array.from(document.querySelectorAll('.publish-popup button, header button, button'))
+				.find(el => isVisible(el) && ['发布', '更新'].includes((el.innerText || '').trim()));
+			if (!button) return false;
+			button.click();
+			return true;
+		})()
+	`)
+	if err != nil {
+		return fmt.Errorf("打开发布面板失败: %v", err)
+	}
+	if ok, _ := opened.(bool); !ok {
+		return fmt.Errorf("未找到发布按钮")
+	}
+	time.Sleep(1 * time.Second)
+	return nil
+}
@@ -0,0 +1,40 @@
+package juejin
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+	"github.com/playwright-community/playwright-go"
+)
+
+// Submit 草稿模式下等待编辑器自动保存草稿；发布模式下打开发布面板并点击"确定并发布"
+func (p *Publisher) Submit(mode platform.PublishMode) error {
+	if mode != platform.PublishModePublish {
+		// 掘金编辑器输入后自动保存到草稿箱，没有单独的保存按钮
+		if common.WaitForPageText(p.page, 10*time.Second, "保存成功", "已保存") == "" {
+			log.Printf("[掘金] ⚠️ 未检测到草稿保存提示，请在草稿箱中确认")
+			return nil
+		}
+		log.Printf("[掘金] 📝 已保存到草稿箱")
+		return nil
+	}
+
+	if err := p.openPublishPanel(); err != nil {
+		return err
+	}
+	if err := common.ClickButtonByText(p.page, ".publish-popup", "确定并发布"); err != nil {
+		return err
+	}
+
+	// 发布成功后跳转到发布成功页；缺少分类或标签时停留在发布面板
+	if err := p.page.WaitForURL("**/published**", playwright.PageWaitForURLOptions{
+		Timeout: common.TimeoutMs(15 * time.Second),
+	}); err != nil {
+		return fmt.Errorf("点击发布后未跳转到发布成功页，请检查分类和标签是否已选择: %v", err)
+	}
+	log.Printf("[掘金] 🎉 文章已发布")
+	return nil
+}
@@ -0,0 +1,18 @@
+package juejin
+
+import (
+	"fmt"
+
+	"github.com/auto-blog/common"
+)
+
+// SetSummary 在发布面板中填写摘要（掘金要求摘要为 50~100 字）
+func (p *Publisher) SetSummary(summary string) error {
+	if err := p.openPublishPanel(); err != nil {
+		return err
+	}
+	if err := common.FillLabeledTextarea(p.page, ".publish-popup", "摘要", summary); err != nil {
+		return fmt.Errorf("填写摘要失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package juejin
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+	"github.com/playwright-community/playwright-go"
+)
+
+var (
+	// draftURLRegex 草稿编辑页地址，已发布文章的编辑页也是草稿地址
+	draftURLRegex = regexp.MustCompile(`juejin\.cn/editor/drafts/\d+`)
+	// postURLRegex 文章页地址
+	postURLRegex = regexp.MustCompile(`juejin\.cn/post/\d+`)
+)
+
+// OpenForUpdate 打开已发布文章的编辑页，等待编辑器就绪后清空原正文（标题在填写时整体替换）。
+// 掘金文章页和编辑页的 ID 不同，传入文章页链接时从文章页的"编辑"入口进入编辑页
+func (p *Publisher) OpenForUpdate(articleURL string) error {
+	editURL := articleURL
+	if !draftURLRegex.MatchString(articleURL) {
+		if !postURLRegex.MatchString(articleURL) {
+			return fmt.Errorf("不是掘金文章链接: %s", articleURL)
+		}
+		var err error
+		if editURL, err = p.findEditURL(articleURL); err != nil {
+			return err
+		}
+	}
+
+	if _, err := common.HumanGoto(p.page, editURL); err != nil {
+		return fmt.Errorf("打开文章编辑页失败: %v", err)
+	}
+	p.page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+		State: playwright.LoadStateNetworkidle,
+	})
+	if err := common.WaitVisible(p.page.Locator(selectors.Get(Name, selectors.Title)), 15*time.Second); err != nil {
+		return fmt.Errorf("等待标题输入框超时: %v", err)
+	}
+	editorSelector := selectors.Get(Name, selectors.Editor)
+	if err := common.WaitVisible(p.page.Locator(editorSelector), 15*time.Second); err != nil {
+		return fmt.Errorf("等待编辑器超时: %v", err)
+	}
+	if err := common.ClearEditor(p.page, editorSelector); err != nil {
+		return fmt.Errorf("清空原正文失败: %v", err)
+	}
+	log.Printf("[掘金] ✏️ 已打开文章编辑页并清空原正文: %s", editURL)
+	return nil
+}
+
+// findEditURL 打开文章页，读取作者可见的"编辑"入口指向的编辑页地址
+func (p *Publisher) findEditURL(postURL string) (string, error) {
+	if _, err := common.HumanGoto(p.page, postURL); err != nil {
+		return "", fmt.Errorf("打开文章页失败: %v", err)
+	}
+	p.page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+		State: playwright.LoadStateNetworkidle,
+	})
+
+	editLink := p.page.Locator(`a[href*="/editor/drafts/"]`).First()
+	if err := common.WaitAttached(editLink, 10*time.Second); err != nil {
+		return "", fmt.Errorf("文章页中未找到编辑入口，请确认已登录文章作者的账号: %v", err)
+	}
+	href, err := editLink.GetAttribute("href")
+	if err != nil || href == "" {
+		return "", fmt.Errorf("读取编辑入口地址失败: %v", err)
+	}
+	if href[0] == '/' {
+		href = "https://juejin.cn" + href
+	}
+	return href, nil
+}
+
+// SubmitUpdate 打开发布面板并点击"确定并更新"，成功后跳转到发布成功页
+func (p *Publisher) SubmitUpdate() (string, error) {
+	if err := p.openPublishPanel(); err != nil {
+		return "", err
+	}
+	if err := common.ClickButtonByText(p.page, ".publish-popup", "确定并更新", "确定并发布"); err != nil {
+		return "", err
+	}
+	if err := p.page.WaitForURL("**/published**", playwright.PageWaitForURLOptions{
+		Timeout: common.TimeoutMs(15 * time.Second),
+	}); err != nil {
+		return "", fmt.Errorf("点击更新后未跳转到发布成功页: %v", err)
+	}
+	log.Printf("[掘金] 🎉 文章已更新")
+	return "", nil
+}
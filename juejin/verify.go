@@ -0,0 +1,21 @@
+package juejin
+
+import (
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+)
+
+// 掘金的草稿箱和创作者中心的文章列表（包含审核中的文章）
+const (
+	draftsURL   = "https://juejin.cn/drafts"
+	articlesURL = "https://juejin.cn/creator/content/article/essays?status=all"
+)
+
+// VerifySubmitted 提交后打开草稿箱或创作者中心的文章列表，确认文章确实出现且标题匹配
+func (p *Publisher) VerifySubmitted(title string, mode platform.PublishMode) (string, error) {
+	listURL := draftsURL
+	if mode == platform.PublishModePublish {
+		listURL = articlesURL
+	}
+	return common.VerifyArticleListed(p.page, Name, listURL, title)
+}
@@ -1,69 +1,199 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/auto-blog/article"
-	"github.com/auto-blog/browser"
 	"github.com/auto-blog/config"
-	"github.com/auto-blog/installer"
-	"github.com/auto-blog/session"
+	"github.com/auto-blog/selectors"
+	"github.com/auto-blog/task"
 )
 
+// version 程序版本，发布构建时通过 -ldflags "-X main.version=..." 注入
+var version = "dev"
+
+// command 子命令
+type command struct {
+	name        string
+	description string
+	run         func(args []string)
+}
+
+var commands = []command{
+	{"publish", "发布 articles 目录下的文章（默认命令），--task 发布任务文件中列出的文章", runPublish},
+	{"login", "打开启用的平台并等待登录，保存会话后退出", runLogin},
+	{"update", "修改已发布的文章（知乎、掘金）：打开编辑页，清空原内容后填入新内容并保存更新", runUpdate},
+	{"quick", "从剪贴板或网页（--url）即时构造文章并发布，不需要先保存为文件", runQuick},
+	{"list", "列出解析到的文章及发布状态", runList},
+	{"status", "查看正在进行（或最近一次）的发布进度", runStatus},
+	{"validate", "校验配置文件和文章", runValidate},
+	{"doctor", "打开启用的平台编辑页，检查关键选择器是否仍然有效", runDoctor},
+	{"version", "打印版本号", runVersion},
+}
+
 func main() {
-	// 加载配置
-	cfg, err := config.LoadConfig("config.ini")
-	if err != nil {
-		log.Fatalf("无法读取配置文件: %v", err)
+	// 第一个参数不是选项时视为子命令，没有子命令时等价于 publish
+	name, args := "publish", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
 	}
 
-	// 获取启用的平台
-	enabledPlatforms := cfg.GetEnabledPlatforms()
-	if len(enabledPlatforms) == 0 {
-		log.Println("没有启用任何平台")
-		return
+	for _, cmd := range commands {
+		if cmd.name == name {
+			cmd.run(args)
+			return
+		}
 	}
 
-	log.Printf("启用的平台: %d个", len(enabledPlatforms))
+	fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", name)
+	printUsage()
+	os.Exit(2)
+}
+
+// printUsage 打印子命令列表
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "用法: auto-blog [命令] [选项]")
+	fmt.Fprintln(os.Stderr, "\n命令:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintln(os.Stderr, "\n使用 auto-blog <命令> -h 查看命令的选项")
+}
 
-	// 解析articles目录下的所有文章
-	log.Println("正在解析articles目录下的文章...")
+// newFlagSet 创建子命令的参数解析器，所有子命令共用 --config 选项
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet("auto-blog "+name, flag.ExitOnError)
+	configPath := flags.String("config", "", "配置文件路径（默认读取环境变量 AUTO_BLOG_CONFIG，未设置则为 config.ini）")
+	return flags, configPath
+}
+
+// addFilterFlags 注册按标签筛选文章的参数，返回在 flags.Parse 之后读取筛选条件的函数
+func addFilterFlags(flags *flag.FlagSet) func() article.Filter {
+	include := flags.String("filter-tag", "", "只处理 frontmatter tags 中带有这些标签的文章（多个用逗号分隔，含任一即可）")
+	exclude := flags.String("exclude-tag", "", "排除 frontmatter tags 中带有这些标签的文章（多个用逗号分隔）")
+	return func() article.Filter {
+		return article.Filter{IncludeTags: splitFlagList(*include), ExcludeTags: splitFlagList(*exclude)}
+	}
+}
+
+// splitFlagList 把逗号分隔的参数值拆成列表，去掉空白和空项
+func splitFlagList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadConfig 加载配置：命令行参数 > 环境变量 > 默认 config.ini。
+// 默认的 config.ini 不存在时（通常是首次使用）生成带注释的默认配置模板，提示用户编辑后重新运行并正常退出；
+// 通过 --config 或 AUTO_BLOG_CONFIG 指定的文件不存在时报错（多半是路径写错），不生成模板
+func loadConfig(configPath string) (*config.Config, string, error) {
+	configFile := configPath
+	if configFile == "" {
+		configFile = os.Getenv("AUTO_BLOG_CONFIG")
+	}
+	explicit := configFile != ""
+	if !explicit {
+		configFile = "config.ini"
+	}
+	if _, err := os.Stat(configFile); os.IsNotExist(err) {
+		if explicit {
+			return nil, configFile, fmt.Errorf("指定的配置文件 %s 不存在，请检查 --config 参数或 AUTO_BLOG_CONFIG 环境变量", configFile)
+		}
+		if err := writeDefaultConfig(configFile); err != nil {
+			return nil, configFile, fmt.Errorf("配置文件 %s 不存在，生成默认配置失败: %v", configFile, err)
+		}
+		log.Printf("📝 配置文件 %s 不存在，已生成默认配置（所有平台默认关闭）", configFile)
+		log.Printf("👉 请编辑 %s，在 [publish] 中把要发布的平台改为 true 后重新运行", configFile)
+		os.Exit(0)
+	}
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return nil, configFile, fmt.Errorf("无法读取配置文件 %s: %v", configFile, err)
+	}
+	return cfg, configFile, nil
+}
+
+// emptyArticlesHint 没有找到文章时的引导提示
+const emptyArticlesHint = "articles 目录下没有找到 .md 文件，请把 .md 文章放进 articles 目录（正文第一行为标题，或在 frontmatter 中填写 title）后重新运行"
+
+// newParser 创建按配置规范化标题的文章解析器
+func newParser(cfg *config.Config) *article.Parser {
 	parser := article.NewParser("articles")
+	parser.SetTitlePunctuation(cfg.GetTitleTrailingPunctuation())
+	return parser
+}
+
+// loadArticles 解析 articles 目录下的所有文章并按配置排序
+func loadArticles(cfg *config.Config, parser *article.Parser) ([]*article.Article, error) {
+	parser.SetConcurrency(cfg.GetParseConcurrency())
 	articles, err := parser.ParseAllFiles()
 	if err != nil {
-		log.Fatalf("解析文章失败: %v", err)
-	}
-	
-	if len(articles) == 0 {
-		log.Println("⚠️ articles目录下没有找到.md文件")
-	} else {
-		log.Printf("✅ 成功解析 %d 篇文章:", len(articles))
-		for i, art := range articles {
-			log.Printf("  %d. %s (%d行)", i+1, art.Title, art.GetContentLineCount())
-		}
+		return nil, fmt.Errorf("解析文章失败: %v", err)
 	}
 
-	// 检查并安装 Playwright
-	if err := installer.EnsurePlaywrightInstalled(); err != nil {
-		log.Fatalf("安装 Playwright 失败: %v", err)
+	sortOrder, err := article.ParseSortOrder(cfg.GetSortOrder())
+	if err != nil {
+		return nil, fmt.Errorf("[publish] sort 配置错误: %v", err)
 	}
+	article.SortArticles(articles, sortOrder)
+	return articles, nil
+}
 
-	// 创建会话管理器
-	sessionManager, err := session.NewManager()
+// loadTaskArticles 解析任务文件中列出的文章，按任务文件的顺序返回，并用任务中的设置覆盖 frontmatter，
+// 覆盖后再按 filter 筛选（任务中设置的 tags 参与筛选）
+func loadTaskArticles(cfg *config.Config, parser *article.Parser, taskPath string, filter article.Filter) ([]*article.Article, error) {
+	t, err := task.Load(taskPath)
 	if err != nil {
-		log.Fatalf("无法创建会话管理器: %v", err)
+		return nil, err
+	}
+	articles := make([]*article.Article, 0, len(t.Articles))
+	for _, item := range t.Articles {
+		art, err := parser.ParseFile(item.Path)
+		if err != nil {
+			return nil, fmt.Errorf("解析文件 %s 失败: %v", item.Path, err)
+		}
+		item.Apply(art, cfg.GetTitleTrailingPunctuation())
+		articles = append(articles, art)
 	}
+	articles = filter.Apply(articles)
+	article.LinkSeries(articles)
+	return articles, nil
+}
 
-	// 创建浏览器管理器（带会话持久化和文章数据）
-	browserManager, err := browser.NewManager(sessionManager.GetUserDataDir(), articles)
+// loadSelectorOverrides 加载配置的选择器覆盖文件，失败时退出
+func loadSelectorOverrides(cfg *config.Config) {
+	selectorsFile := cfg.GetSelectorsFile()
+	if selectorsFile == "" {
+		return
+	}
+	count, err := selectors.LoadOverrides(selectorsFile)
 	if err != nil {
-		log.Fatalf("无法创建浏览器管理器: %v", err)
+		log.Fatalf("加载选择器配置失败: %v", err)
 	}
-	defer browserManager.Close()
+	log.Printf("已从 %s 加载 %d 个选择器覆盖", selectorsFile, count)
+}
 
-	// 打开所有平台
-	browserManager.OpenPlatforms(enabledPlatforms)
+// runVersion 打印版本号
+func runVersion(args []string) {
+	flags := flag.NewFlagSet("auto-blog version", flag.ExitOnError)
+	flags.Parse(args)
+	fmt.Printf("auto-blog %s\n", version)
+}
 
-	// 等待用户退出
-	browserManager.WaitForExit()
+// mustLoadConfig 加载配置，失败时退出
+func mustLoadConfig(configPath string) *config.Config {
+	cfg, configFile, err := loadConfig(configPath)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	log.Printf("使用配置文件: %s", configFile)
+	return cfg
 }
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"sync"
+	"time"
+)
+
+// Result 单篇文章在单个平台的发布结果
+type Result struct {
+	Title    string `json:"title"`           // 文章标题
+	Path     string `json:"path"`            // 文章路径
+	Platform string `json:"platform"`        // 平台名称
+	Success  bool   `json:"success"`         // 是否发布成功
+	URL      string `json:"url,omitempty"`   // 文章链接或输出路径（可能为空）
+	Error    string `json:"error,omitempty"` // 失败原因
+
+	// Unverified 已提交但未能确认文章存在（可能被审核拦截或静默失败），此时 Success 为 false，
+	// Error 为验证失败的原因
+	Unverified bool `json:"unverified,omitempty"`
+}
+
+// PublishReport 一轮发布的结果汇总。
+// 所有方法对 nil 接收者安全，未配置通知时直接传 nil 即可
+type PublishReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Results    []Result  `json:"results"`
+	mutex      sync.Mutex
+}
+
+// NewReport 创建发布报告，开始时间为当前时间
+func NewReport() *PublishReport {
+	return &PublishReport{StartedAt: time.Now()}
+}
+
+// Add 记录一条发布结果，同一文章在同一平台已有结果时（如失败后重试）以最新的结果为准
+func (r *PublishReport) Add(result Result) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i, existing := range r.Results {
+		if existing.Path == result.Path && existing.Platform == result.Platform {
+			r.Results[i] = result
+			return
+		}
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Reset 清空结果并重新开始计时（监听模式每批文章使用一份报告）
+func (r *PublishReport) Reset() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.StartedAt = time.Now()
+	r.FinishedAt = time.Time{}
+	r.Results = nil
+}
+
+// Finish 标记发布结束，返回结果的快照
+func (r *PublishReport) Finish() *PublishReport {
+	if r == nil {
+		return &PublishReport{}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.FinishedAt = time.Now()
+	return &PublishReport{
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		Results:    append([]Result(nil), r.Results...),
+	}
+}
+
+// Counts 返回成功、已提交但未确认成功和失败的数量
+func (r *PublishReport) Counts() (int, int, int) {
+	succeeded, unverified, failed := 0, 0, 0
+	for _, result := range r.Results {
+		switch {
+		case result.Success:
+			succeeded++
+		case result.Unverified:
+			unverified++
+		default:
+			failed++
+		}
+	}
+	return succeeded, unverified, failed
+}
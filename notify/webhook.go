@@ -0,0 +1,196 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Format Webhook 消息格式
+type Format string
+
+const (
+	FormatJSON     Format = "json"     // 原样发送 PublishReport 的 JSON
+	FormatWeCom    Format = "wecom"    // 企业微信群机器人（markdown 消息）
+	FormatDingTalk Format = "dingtalk" // 钉钉群机器人（markdown 消息）
+	FormatSlack    Format = "slack"    // Slack Incoming Webhook
+)
+
+// ParseFormat 解析消息格式，空字符串返回 json
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return FormatJSON, nil
+	case FormatJSON, FormatWeCom, FormatDingTalk, FormatSlack:
+		return format, nil
+	default:
+		return "", fmt.Errorf("未知的通知格式: %s（可选 json/wecom/dingtalk/slack）", value)
+	}
+}
+
+// Options Webhook 通知配置
+type Options struct {
+	URL     string        // Webhook 地址
+	Format  Format        // 消息格式
+	Timeout time.Duration // 请求超时
+}
+
+// Notifier 发布结果通知器
+type Notifier struct {
+	options Options
+	client  *http.Client
+}
+
+// NewNotifier 创建通知器，未配置 Webhook 地址时返回 nil（nil 通知器的 Send 不做任何事）
+func NewNotifier(options Options) (*Notifier, error) {
+	if options.URL == "" {
+		return nil, nil
+	}
+	format, err := ParseFormat(string(options.Format))
+	if err != nil {
+		return nil, err
+	}
+	options.Format = format
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Second
+	}
+	return &Notifier{
+		options: options,
+		client:  &http.Client{Timeout: options.Timeout},
+	}, nil
+}
+
+// Send 标记发布结束并将发布报告 POST 到 Webhook，没有结果时不发送。请求失败只记录日志
+func (n *Notifier) Send(report *PublishReport) {
+	if n == nil || report == nil {
+		return
+	}
+	report = report.Finish()
+	if len(report.Results) == 0 {
+		return
+	}
+
+	body, err := n.payload(report)
+	if err != nil {
+		log.Printf("⚠️ 生成发布通知失败: %v", err)
+		return
+	}
+
+	resp, err := n.client.Post(n.options.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("⚠️ 发送发布通知失败: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		log.Printf("⚠️ 发送发布通知失败: HTTP %d %s", resp.StatusCode, strings.TrimSpace(string(message)))
+		return
+	}
+	log.Printf("📨 已发送发布通知（%s）", n.options.Format)
+}
+
+// payload 按消息格式生成请求体
+func (n *Notifier) payload(report *PublishReport) ([]byte, error) {
+	succeeded, unverified, failed := report.Counts()
+	title := fmt.Sprintf("auto-blog 发布完成：%d 成功，%d 失败", succeeded, failed)
+	if unverified > 0 {
+		title += fmt.Sprintf("，%d 未确认", unverified)
+	}
+
+	switch n.options.Format {
+	case FormatWeCom:
+		return json.Marshal(map[string]interface{}{
+			"msgtype": "markdown",
+			"markdown": map[string]string{
+				"content": markdownMessage(title, report, func(text string) string {
+					return `<font color="warning">` + text + `</font>`
+				}),
+			},
+		})
+	case FormatDingTalk:
+		return json.Marshal(map[string]interface{}{
+			"msgtype": "markdown",
+			"markdown": map[string]string{
+				"title": title,
+				"text": markdownMessage(title, report, func(text string) string {
+					return `<font color="#FF0000">` + text + `</font>`
+				}),
+			},
+		})
+	case FormatSlack:
+		return json.Marshal(map[string]string{
+			"text": slackMessage(title, report),
+		})
+	default:
+		return json.Marshal(report)
+	}
+}
+
+// markdownMessage 生成企业微信/钉钉的 markdown 消息，失败的平台用 highlight 标红
+func markdownMessage(title string, report *PublishReport, highlight func(string) string) string {
+	var builder strings.Builder
+	builder.WriteString("### " + title + "\n")
+	for _, result := range report.Results {
+		if result.Success {
+			line := fmt.Sprintf("- ✅ %s《%s》", result.Platform, result.Title)
+			if result.URL != "" {
+				line += fmt.Sprintf(" [链接](%s)", result.URL)
+			}
+			builder.WriteString(line + "\n")
+			continue
+		}
+		if result.Unverified {
+			builder.WriteString("- " + highlight(fmt.Sprintf("⚠️ %s《%s》已提交但未确认成功：%s", result.Platform, result.Title, result.Error)) + "\n")
+			continue
+		}
+		builder.WriteString("- " + highlight(fmt.Sprintf("❌ %s《%s》：%s", result.Platform, result.Title, failureReason(result))) + "\n")
+	}
+	builder.WriteString(fmt.Sprintf("\n> 耗时 %s", duration(report)))
+	return builder.String()
+}
+
+// slackMessage 生成 Slack mrkdwn 消息，失败的平台加粗显示
+func slackMessage(title string, report *PublishReport) string {
+	var builder strings.Builder
+	builder.WriteString("*" + title + "*\n")
+	for _, result := range report.Results {
+		if result.Success {
+			line := fmt.Sprintf(":white_check_mark: %s《%s》", result.Platform, result.Title)
+			if result.URL != "" {
+				line += fmt.Sprintf(" <%s|链接>", result.URL)
+			}
+			builder.WriteString(line + "\n")
+			continue
+		}
+		if result.Unverified {
+			builder.WriteString(fmt.Sprintf(":warning: *%s《%s》已提交但未确认成功：%s*\n", result.Platform, result.Title, result.Error))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf(":x: *%s《%s》：%s*\n", result.Platform, result.Title, failureReason(result)))
+	}
+	builder.WriteString(fmt.Sprintf("_耗时 %s_", duration(report)))
+	return builder.String()
+}
+
+// failureReason 失败原因，未记录时使用通用描述
+func failureReason(result Result) string {
+	if result.Error == "" {
+		return "发布失败"
+	}
+	return result.Error
+}
+
+// duration 本轮发布耗时
+func duration(report *PublishReport) time.Duration {
+	if report.FinishedAt.IsZero() || report.StartedAt.IsZero() {
+		return 0
+	}
+	return report.FinishedAt.Sub(report.StartedAt).Round(time.Second)
+}
@@ -0,0 +1,42 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/auto-blog/article"
+)
+
+// ImageStrategy 图片处理策略
+type ImageStrategy string
+
+const (
+	ImageStrategyUpload    ImageStrategy = "upload"    // 通过编辑器的上传控件插入
+	ImageStrategyClipboard ImageStrategy = "clipboard" // 复制到剪贴板后粘贴（默认）
+	ImageStrategyImageHost ImageStrategy = "imagehost" // 先上传到图床，再插入图片链接
+	ImageStrategySkip      ImageStrategy = "skip"      // 不处理图片，用 alt 文本占位
+)
+
+// ParseImageStrategy 解析图片策略名称，空字符串返回默认的剪贴板策略
+func ParseImageStrategy(value string) (ImageStrategy, error) {
+	switch strategy := ImageStrategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case "":
+		return ImageStrategyClipboard, nil
+	case ImageStrategyUpload, ImageStrategyClipboard, ImageStrategyImageHost, ImageStrategySkip:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("未知的图片策略: %s（可选 upload/clipboard/imagehost/skip）", value)
+	}
+}
+
+// ImageUploader 支持通过上传控件插入图片的发布器
+type ImageUploader interface {
+	// UploadImage 将占位符替换为通过上传控件插入的图片
+	UploadImage(placeholder string, img article.Image) error
+}
+
+// TextReplacer 支持把占位符替换为文本的发布器（图床链接、跳过图片时的占位文本）
+type TextReplacer interface {
+	// ReplaceTextWithText 将占位符替换为文本
+	ReplaceTextWithText(placeholder, text string) error
+}
@@ -0,0 +1,27 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LengthStrategy 正文超过平台字数上限时的处理策略
+type LengthStrategy string
+
+const (
+	LengthStrategyWarn     LengthStrategy = "warn"     // 只给出警告，按原文提交
+	LengthStrategyTruncate LengthStrategy = "truncate" // 在句子边界截断并追加引流文字（默认）
+	LengthStrategyBlock    LengthStrategy = "block"    // 不在该平台发布
+)
+
+// ParseLengthStrategy 解析超长处理策略，空字符串返回默认的截断
+func ParseLengthStrategy(value string) (LengthStrategy, error) {
+	switch strategy := LengthStrategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case "":
+		return LengthStrategyTruncate, nil
+	case LengthStrategyWarn, LengthStrategyTruncate, LengthStrategyBlock:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("未知的超长处理策略: %s（可选 warn/truncate/block）", value)
+	}
+}
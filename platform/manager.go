@@ -1,11 +1,8 @@
 package platform
 
 import (
-	"github.com/auto-blog/article"
-	"github.com/auto-blog/cnblogs"
-	"github.com/auto-blog/juejin"
-	"github.com/auto-blog/segmentfault"
-	"github.com/auto-blog/zhihu"
+	"log"
+
 	"github.com/playwright-community/playwright-go"
 )
 
@@ -21,32 +18,44 @@ func NewManager() *Manager {
 	}
 }
 
+// Register 注册平台
+func (m *Manager) Register(p Platform) {
+	m.platforms[p.GetName()] = p
+}
+
+// Get 获取指定名称的平台
+func (m *Manager) Get(platformName string) (Platform, bool) {
+	p, ok := m.platforms[platformName]
+	return p, ok
+}
+
 // CheckAndWaitForLogin 检查指定平台的登录状态（异步执行）
-func (m *Manager) CheckAndWaitForLogin(platformName string, page playwright.Page, originalURL string, saveSession juejin.SaveSessionFunc, articles []*article.Article) {
+func (m *Manager) CheckAndWaitForLogin(platformName string, page playwright.Page) {
+	p, ok := m.platforms[platformName]
+	if !ok {
+		// 未注册的平台暂不检测
+		return
+	}
+
 	// 异步执行登录检测，确保不同平台间互不干扰
-	go func() {
-		// 根据平台名称调用相应的登录检测
-		switch platformName {
-		case "掘金":
-			checker := juejin.NewLoginChecker(originalURL, saveSession, articles)
-			checker.CheckAndWaitForLogin(page)
-		case "博客园":
-			// 需要将juejin.SaveSessionFunc转换为cnblogs.SaveSessionFunc
-			cnblogsChecker := cnblogs.NewLoginChecker(originalURL, cnblogs.SaveSessionFunc(saveSession))
-			cnblogsChecker.CheckAndWaitForLogin(page)
-		case "知乎":
-			// 需要将juejin.SaveSessionFunc转换为zhihu.SaveSessionFunc
-			zhihuChecker := zhihu.NewLoginChecker(originalURL, zhihu.SaveSessionFunc(saveSession), articles)
-			zhihuChecker.CheckAndWaitForLogin(page)
-		case "SegmentFault":
-			// 需要将juejin.SaveSessionFunc转换为segmentfault.SaveSessionFunc
-			sfChecker := segmentfault.NewLoginChecker(originalURL, segmentfault.SaveSessionFunc(saveSession), articles)
-			sfChecker.CheckAndWaitForLogin(page)
-		// 其他平台可以在这里添加
-		// case "CSDN":
-		//     csdn.CheckAndWaitForLogin(page, originalURL, saveSession)
-		default:
-			// 其他平台暂不检测
-		}
-	}()
-}
\ No newline at end of file
+	go p.CheckAndWaitForLogin(page)
+}
+
+// WaitForEditor 等待指定平台的编辑器就绪
+func (m *Manager) WaitForEditor(platformName string, page playwright.Page) bool {
+	p, ok := m.platforms[platformName]
+	if !ok {
+		log.Printf("暂不支持的平台: %s", platformName)
+		return false
+	}
+	return p.WaitForEditor(page)
+}
+
+// NewPublisher 为指定平台创建文章发布器
+func (m *Manager) NewPublisher(platformName string, page playwright.Page) (Publisher, bool) {
+	p, ok := m.platforms[platformName]
+	if !ok {
+		return nil, false
+	}
+	return p.NewPublisher(page), true
+}
@@ -1,9 +1,21 @@
 package platform
 
 import (
+	"time"
+
+	"github.com/auto-blog/article"
 	"github.com/playwright-community/playwright-go"
 )
 
+// Publisher 平台文章发布器接口
+type Publisher interface {
+	// PublishArticle 填写文章标题和正文
+	PublishArticle(art *article.Article) error
+
+	// ReplaceTextWithImage 将占位符替换为图片
+	ReplaceTextWithImage(placeholder string, img article.Image) error
+}
+
 // Platform 平台接口
 type Platform interface {
 	// GetName 获取平台名称
@@ -14,4 +26,55 @@ type Platform interface {
 	
 	// CheckAndWaitForLogin 检查并等待登录
 	CheckAndWaitForLogin(page playwright.Page)
-}
\ No newline at end of file
+
+	// WaitForEditor 等待编辑器就绪
+	WaitForEditor(page playwright.Page) bool
+
+	// NewPublisher 创建该平台的文章发布器
+	NewPublisher(page playwright.Page) Publisher
+}
+
+// SchedulePublisher 支持平台定时发布的发布器
+type SchedulePublisher interface {
+	// SchedulePublish 在发布设置中选择定时发布并填入发布时间
+	SchedulePublish(publishAt time.Time) error
+}
+
+// Submitter 内容和图片处理完后提交的发布器，按提交方式保存草稿或发布
+type Submitter interface {
+	// Submit 提交内容：draft 模式保存草稿，不点击最终的发布按钮
+	Submit(mode PublishMode) error
+}
+
+// Verifier 支持在提交后确认文章确实存在的发布器
+type Verifier interface {
+	// VerifySubmitted 打开文章页、草稿箱或个人文章列表，确认标题为 title 的文章存在，
+	// 返回找到的文章链接（可能为空）
+	VerifySubmitted(title string, mode PublishMode) (string, error)
+}
+
+// Updater 支持修改已发布文章的发布器：打开文章的编辑页并清空原内容，填写新内容后保存更新
+type Updater interface {
+	// OpenForUpdate 打开已发布文章（articleURL 为文章页或编辑页链接）的编辑器，等待就绪后清空原正文
+	OpenForUpdate(articleURL string) error
+	// SubmitUpdate 保存对已发布文章的修改，返回更新后的文章链接（可能为空）
+	SubmitUpdate() (string, error)
+}
+
+// CoverUploader 支持设置文章封面的发布器
+type CoverUploader interface {
+	// UploadCover 上传本地图片作为文章封面
+	UploadCover(imagePath string) error
+}
+
+// SummaryPublisher 支持填写文章摘要的发布器
+type SummaryPublisher interface {
+	// SetSummary 填写文章摘要
+	SetSummary(summary string) error
+}
+
+// VideoEmbedder 支持在正文中嵌入视频播放器的发布器，不支持的平台把视频转为带缩略图的链接
+type VideoEmbedder interface {
+	// VideoEmbedCode 返回视频在正文中的嵌入写法（如播放器 iframe），不支持该视频来源时返回空字符串
+	VideoEmbedCode(video article.Video) string
+}
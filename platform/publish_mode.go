@@ -0,0 +1,26 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PublishMode 内容填写完成后的提交方式
+type PublishMode string
+
+const (
+	PublishModeDraft   PublishMode = "draft"   // 保存到草稿箱，由用户审核后手动发布（默认）
+	PublishModePublish PublishMode = "publish" // 点击平台的发布按钮直接发布
+)
+
+// ParsePublishMode 解析提交方式，空字符串返回默认的草稿模式
+func ParsePublishMode(value string) (PublishMode, error) {
+	switch mode := PublishMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return PublishModeDraft, nil
+	case PublishModeDraft, PublishModePublish:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("未知的发布方式: %s（可选 draft/publish）", value)
+	}
+}
@@ -0,0 +1,36 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Visibility 文章的可见范围
+type Visibility string
+
+const (
+	VisibilityPublic    Visibility = "public"    // 所有人可见（默认）
+	VisibilityFollowers Visibility = "followers" // 仅关注者可见
+	VisibilityPrivate   Visibility = "private"   // 仅自己可见
+)
+
+// ParseVisibility 解析可见范围，空字符串返回默认的公开
+func ParseVisibility(value string) (Visibility, error) {
+	switch visibility := Visibility(strings.ToLower(strings.TrimSpace(value))); visibility {
+	case "":
+		return VisibilityPublic, nil
+	case VisibilityPublic, VisibilityFollowers, VisibilityPrivate:
+		return visibility, nil
+	default:
+		return "", fmt.Errorf("未知的可见范围: %s（可选 public/followers/private）", value)
+	}
+}
+
+// VisibilityPublisher 支持设置文章可见范围的发布器
+type VisibilityPublisher interface {
+	// SupportsVisibility 判断平台是否提供该可见范围
+	SupportsVisibility(visibility Visibility) bool
+
+	// SetVisibility 在发布设置中选择可见范围
+	SetVisibility(visibility Visibility) error
+}
@@ -0,0 +1,146 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const barWidth = 30 // 进度条宽度（字符数）
+
+// Bar 单行刷新的发布进度条，显示完成百分比和预估剩余时间。
+// 所有方法对 nil 接收者安全，关闭进度条时直接传 nil 即可
+type Bar struct {
+	out     io.Writer
+	total   int
+	done    int
+	start   time.Time
+	visible bool // 当前是否有进度条显示在最后一行
+	mutex   sync.Mutex
+}
+
+// NewBar 创建输出到 out 的进度条
+func NewBar(out io.Writer) *Bar {
+	return &Bar{out: out}
+}
+
+// IsTerminal 判断文件是否为终端，非终端（如重定向到文件）时不适合单行刷新
+func IsTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Start 开始新一轮进度统计
+func (b *Bar) Start(total int) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.total = total
+	b.done = 0
+	b.start = time.Now()
+	b.render()
+}
+
+// Advance 完成 n 个步骤
+func (b *Bar) Advance(n int) {
+	if b == nil || n <= 0 {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.done += n
+	if b.done > b.total {
+		b.done = b.total
+	}
+	b.render()
+}
+
+// Finish 结束本轮进度统计，保留最终进度行并换行
+func (b *Bar) Finish() {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.total == 0 {
+		return
+	}
+	b.done = b.total
+	b.render()
+	fmt.Fprintln(b.out)
+	b.visible = false
+	b.total = 0
+}
+
+// LogWriter 返回供日志使用的 Writer：写日志前先清除进度条，写完后重新绘制，
+// 避免日志和进度条混在同一行
+func (b *Bar) LogWriter(out io.Writer) io.Writer {
+	if b == nil {
+		return out
+	}
+	return &logWriter{bar: b, out: out}
+}
+
+type logWriter struct {
+	bar *Bar
+	out io.Writer
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.bar.mutex.Lock()
+	defer w.bar.mutex.Unlock()
+
+	w.bar.clear()
+	n, err := w.out.Write(p)
+	w.bar.render()
+	return n, err
+}
+
+// clear 清除当前显示的进度条
+func (b *Bar) clear() {
+	if b.visible {
+		fmt.Fprint(b.out, "\r\033[K")
+		b.visible = false
+	}
+}
+
+// render 绘制进度条，形如 [=========>          ] 45.0% (9/20) 剩余约 1m20s
+func (b *Bar) render() {
+	if b.total == 0 {
+		return
+	}
+
+	ratio := float64(b.done) / float64(b.total)
+	filled := int(ratio * barWidth)
+	bar := strings.Repeat("=", filled)
+	if filled < barWidth {
+		bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+
+	fmt.Fprintf(b.out, "\r\033[K[%s] %5.1f%% (%d/%d) %s", bar, ratio*100, b.done, b.total, b.remaining())
+	b.visible = true
+}
+
+// remaining 根据已用时间和完成比例预估剩余时间
+func (b *Bar) remaining() string {
+	if b.done == 0 {
+		return "剩余时间估算中..."
+	}
+	if b.done >= b.total {
+		return fmt.Sprintf("已完成，用时 %s", time.Since(b.start).Round(time.Second))
+	}
+	elapsed := time.Since(b.start)
+	left := time.Duration(float64(elapsed) / float64(b.done) * float64(b.total-b.done))
+	return fmt.Sprintf("剩余约 %s", left.Round(time.Second))
+}
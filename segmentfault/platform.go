@@ -0,0 +1,135 @@
+package segmentfault
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+	"github.com/auto-blog/selectors"
+	"github.com/playwright-community/playwright-go"
+)
+
+// Name 平台名称
+const Name = "SegmentFault"
+
+// ID 平台标识，用于配置段 [platform.segmentfault] 和 frontmatter 中的平台映射
+const ID = "segmentfault"
+
+// Platform SegmentFault平台（实现 platform.Platform 接口）
+type Platform struct {
+	saveSession SaveSessionFunc
+	articles    []*article.Article
+}
+
+// NewPlatform 创建SegmentFault平台
+func NewPlatform(saveSession SaveSessionFunc, articles []*article.Article) *Platform {
+	return &Platform{
+		saveSession: saveSession,
+		articles:    articles,
+	}
+}
+
+// GetName 获取平台名称
+func (p *Platform) GetName() string {
+	return Name
+}
+
+// GetURL 获取平台URL
+func (p *Platform) GetURL() string {
+	return URL()
+}
+
+// CheckAndWaitForLogin 检查并等待登录
+func (p *Platform) CheckAndWaitForLogin(page playwright.Page) {
+	NewLoginChecker(URL(), p.saveSession, p.articles).CheckAndWaitForLogin(page)
+}
+
+// NewPublisher 创建文章发布器
+func (p *Platform) NewPublisher(page playwright.Page) platform.Publisher {
+	return NewPublisher(page)
+}
+
+// WaitForEditor 等待SegmentFault编辑器
+func (p *Platform) WaitForEditor(page playwright.Page) bool {
+	currentURL := page.URL()
+	log.Printf("[SegmentFault] 当前页面URL: %s", currentURL)
+	
+	// 检查是否在登录页面，如果是则等待用户登录
+	if strings.Contains(currentURL, "segmentfault.com/user/login") {
+		log.Println("[SegmentFault] 🔐 检测到SegmentFault未登录，请在浏览器中完成登录")
+		
+		// 循环等待用户登录
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		
+		for {
+			select {
+			case <-ticker.C:
+				// 实时获取当前URL
+				currentURL = page.URL()
+				log.Printf("[SegmentFault] 检测URL变化: %s", currentURL)
+				
+				// 检查是否已经跳转离开登录页面
+				if !strings.Contains(currentURL, "segmentfault.com/user/login") {
+					log.Println("[SegmentFault] ✅ 检测到已离开登录页面")
+					
+					// 保存会话状态
+					if err := p.saveSession(); err != nil {
+						log.Printf("[SegmentFault] ⚠️ 保存会话失败: %v", err)
+					} else {
+						log.Println("[SegmentFault] 💾 会话状态已保存")
+					}
+					
+					// 跳出循环，继续执行编辑器检测
+					goto continueEditorCheck
+				}
+			}
+		}
+	}
+	
+continueEditorCheck:
+	// 重新获取当前URL（可能在登录后有变化）
+	currentURL = page.URL()
+	log.Printf("[SegmentFault] 继续检测编辑器，当前URL: %s", currentURL)
+	
+	// 检查是否在写作页面，如果不是则跳转
+	if !strings.Contains(currentURL, "segmentfault.com/write") {
+		log.Printf("[SegmentFault] 当前不在写作页面，跳转到: %s", URL())
+		
+		if _, err := page.Goto(URL()); err != nil {
+			log.Printf("[SegmentFault] ❌ 跳转到写作页面失败: %v", err)
+			return false
+		}
+		
+		// 等待页面加载
+		time.Sleep(2 * time.Second)
+		currentURL = page.URL()
+		log.Printf("[SegmentFault] 跳转后URL: %s", currentURL)
+	}
+	
+	log.Println("[SegmentFault] 开始等待编辑器元素...")
+	
+	titleLocator := page.Locator(selectors.Get(Name, selectors.Title))
+	editorLocator := page.Locator(selectors.Get(Name, selectors.Editor))
+	
+	log.Println("[SegmentFault] 等待标题输入框...")
+	err := common.WaitVisible(titleLocator, 10*time.Second)
+	if err != nil {
+		log.Printf("[SegmentFault] ❌ 等待标题输入框失败: %v", err)
+		return false
+	}
+	log.Println("[SegmentFault] ✅ 标题输入框已就绪")
+	
+	log.Println("[SegmentFault] 等待编辑器...")
+	err = common.WaitVisible(editorLocator, 10*time.Second)
+	if err != nil {
+		log.Printf("[SegmentFault] ❌ 等待编辑器失败: %v", err)
+		return false
+	}
+	
+	log.Println("[SegmentFault] ✅ 编辑器已就绪")
+	return true
+}
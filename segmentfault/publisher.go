@@ -8,6 +8,7 @@ import (
 
 	"github.com/auto-blog/article"
 	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
 	"github.com/playwright-community/playwright-go"
 )
 
@@ -26,6 +27,10 @@ func NewPublisher(page playwright.Page) *Publisher {
 // PublishArticle 发布文章到SegmentFault
 func (p *Publisher) PublishArticle(art *article.Article) error {
 	log.Printf("[SegmentFault] 开始发布文章: %s", art.Title)
+	// SegmentFault 编辑器不认识代码块的额外参数，只保留语言名
+	art = art.WithoutCodeParams()
+	// 提示框语法（> [!NOTE]、:::tip）改写为普通引用块
+	art = art.WithQuotedAdmonitions()
 
 	// 1. 填写标题
 	if err := p.fillTitle(art.Title); err != nil {
@@ -47,7 +52,7 @@ func (p *Publisher) PublishArticle(art *article.Article) error {
 
 	// 4. 替换图片占位符
 	for i, img := range art.Images {
-		placeholder := fmt.Sprintf("IMAGE_PLACEHOLDER_%d", i)
+		placeholder := article.PlaceholderFor(i)
 		if err := p.ReplaceTextWithImage(placeholder, img); err != nil {
 			log.Printf("[SegmentFault] ⚠️ 替换图片失败: %v", err)
 		} else {
@@ -61,13 +66,10 @@ func (p *Publisher) PublishArticle(art *article.Article) error {
 
 // fillTitle 填写文章标题
 func (p *Publisher) fillTitle(title string) error {
-	titleSelector := "input[placeholder*='标题']"
+	titleSelector := selectors.Get(Name, selectors.Title)
 	titleLocator := p.page.Locator(titleSelector)
 
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(titleLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待标题输入框超时: %v", err)
 	}
 
@@ -84,8 +86,9 @@ func (p *Publisher) activateEditor() error {
 	log.Printf("[SegmentFault] 触发编辑器的 mousedown 事件激活光标")
 	
 	mouseDownJS := `
-		(function() {
-			const el = document.querySelector('.CodeMirror-scroll');
+		(function(selector) {
+			const editor = document.querySelector(selector);
+			const el = editor && (editor.querySelector('.CodeMirror-scroll') || editor);
 			if (el) {
 				// 先绑定事件
 				el.addEventListener('mousedown', function (e) {
@@ -102,10 +105,10 @@ func (p *Publisher) activateEditor() error {
 				return true;
 			}
 			return false;
-		})()
+		})
 	`
 
-	result, err := p.page.Evaluate(mouseDownJS)
+	result, err := p.page.Evaluate(mouseDownJS, selectors.Get(Name, selectors.Editor))
 	if err != nil {
 		return fmt.Errorf("触发 mousedown 事件失败: %v", err)
 	}
@@ -126,8 +129,9 @@ func (p *Publisher) fillContent(content []string) error {
 
 	// 1. 尝试JavaScript设置
 	jsCode := `
-		(function(content) {
-			const cmElement = document.querySelector('.CodeMirror');
+		(function([content, selector]) {
+			const editor = document.querySelector(selector);
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				cmElement.CodeMirror.setValue(content);
 				cmElement.CodeMirror.focus();
@@ -137,7 +141,7 @@ func (p *Publisher) fillContent(content []string) error {
 		})
 	`
 
-	result, err := p.page.Evaluate(jsCode, fullContent)
+	result, err := p.page.Evaluate(jsCode, []interface{}{fullContent, selectors.Get(Name, selectors.Editor)})
 	if err == nil {
 		if resultMap, ok := result.(map[string]interface{}); ok {
 			if success, _ := resultMap["success"].(bool); success {
@@ -151,16 +155,10 @@ func (p *Publisher) fillContent(content []string) error {
 	// 2. JavaScript失败，使用键盘输入
 	log.Printf("[SegmentFault] JavaScript失败，使用键盘输入")
 	
-	// 清空编辑器
-	if err := p.page.Keyboard().Press("Control+a"); err != nil {
-		log.Printf("[SegmentFault] 全选失败: %v", err)
-	}
-	time.Sleep(200 * time.Millisecond)
-	
-	if err := p.page.Keyboard().Press("Delete"); err != nil {
-		log.Printf("[SegmentFault] 删除失败: %v", err)
+	// 清空编辑器并确认已清空
+	if err := common.ClearEditor(p.page, selectors.Get(Name, selectors.Editor)); err != nil {
+		return fmt.Errorf("清空编辑器失败: %v", err)
 	}
-	time.Sleep(200 * time.Millisecond)
 
 	// 输入内容
 	if err := p.page.Keyboard().Type(fullContent); err != nil {
@@ -191,7 +189,7 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 	}
 
 	// 4. 粘贴图片
-	if err := common.PasteImageToEditor(p.page); err != nil {
+	if err := common.PasteImageToEditor(p.page, selectors.Get(Name, selectors.Editor)); err != nil {
 		return fmt.Errorf("粘贴图片失败: %v", err)
 	}
 
@@ -206,8 +204,9 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 // findAndSelectText 查找并选中文本
 func (p *Publisher) findAndSelectText(text string) error {
 	jsCode := `
-		(function(searchText) {
-			const cmElement = document.querySelector('.CodeMirror');
+		(function([searchText, selector]) {
+			const editor = document.querySelector(selector);
+			const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 			if (cmElement && cmElement.CodeMirror) {
 				const cm = cmElement.CodeMirror;
 				const content = cm.getValue();
@@ -227,7 +226,7 @@ func (p *Publisher) findAndSelectText(text string) error {
 		})
 	`
 
-	result, err := p.page.Evaluate(jsCode, text)
+	result, err := p.page.Evaluate(jsCode, []interface{}{text, selectors.Get(Name, selectors.Editor)})
 	if err != nil {
 		return fmt.Errorf("查找文本失败: %v", err)
 	}
@@ -244,8 +243,9 @@ func (p *Publisher) findAndSelectText(text string) error {
 func (p *Publisher) waitForImageUpload() error {
 	for i := 0; i < 15; i++ {
 		result, err := p.page.Evaluate(`
-			(function() {
-				const cmElement = document.querySelector('.CodeMirror');
+			(function(selector) {
+				const editor = document.querySelector(selector);
+				const cmElement = editor && (editor.closest('.CodeMirror') || editor.querySelector('.CodeMirror'));
 				if (cmElement && cmElement.CodeMirror) {
 					const content = cmElement.CodeMirror.getValue();
 					const hasImageMd = /!\[.*?\]\(.*?\)/.test(content);
@@ -255,8 +255,8 @@ func (p *Publisher) waitForImageUpload() error {
 					}
 				}
 				return { success: false };
-			})()
-		`)
+			})
+		`, selectors.Get(Name, selectors.Editor))
 
 		if err == nil {
 			if resultMap, ok := result.(map[string]interface{}); ok {
@@ -277,23 +277,21 @@ func (p *Publisher) waitForImageUpload() error {
 // WaitForEditor 等待编辑器加载完成
 func (p *Publisher) WaitForEditor() error {
 	// 等待标题输入框
-	titleLocator := p.page.Locator("input[placeholder*='标题']")
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(15000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	titleLocator := p.page.Locator(selectors.Get(Name, selectors.Title))
+	if err := common.WaitVisible(titleLocator, 15*time.Second); err != nil {
 		return fmt.Errorf("等待标题输入框超时: %v", err)
 	}
 
 	// 等待编辑器
-	editorLocator := p.page.Locator(".CodeMirror")
-	if err := editorLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(15000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	editorLocator := p.page.Locator(selectors.Get(Name, selectors.Editor))
+	if err := common.WaitVisible(editorLocator, 15*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器超时: %v", err)
 	}
 
 	log.Println("[SegmentFault] ✅ 编辑器已加载完成")
 	return nil
-}
\ No newline at end of file
+}
+// ReplaceTextWithText 查找占位符并替换为文本（图床链接或跳过图片时的 alt 文本）
+func (p *Publisher) ReplaceTextWithText(placeholder, text string) error {
+	return common.ReplaceTextInMarkdownEditor(p.page, selectors.Get(Name, selectors.Editor), placeholder, text)
+}
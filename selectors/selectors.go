@@ -0,0 +1,135 @@
+package selectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// 选择器键名
+const (
+	Title  = "title"  // 标题输入框
+	Editor = "editor" // 正文编辑器
+	Cover  = "cover"  // 封面图上传的文件输入框
+
+	ImageButton   = "image_button"   // 工具栏的插入图片按钮（doctor 自检使用）
+	PublishButton = "publish_button" // 编辑页的发布按钮（doctor 自检使用）
+
+	AnswerButton = "answer_button" // 问题页的"写回答"按钮（知乎回答模式）
+	AnswerEditor = "answer_editor" // 回答编辑器（知乎回答模式）
+	AnswerSubmit = "answer_submit" // "发布回答"按钮（知乎回答模式）
+	PinEditor    = "pin_editor"    // 发想法弹窗中的编辑框（知乎同步想法）
+
+	MentionOption = "mention_option" // 正文中输入 @用户名、#话题 后候选下拉框的第一项
+
+	Preview      = "preview"       // Markdown 编辑器的预览区，用于检查粘贴的 Markdown 是否被解析（掘金）、图片是否显示（掘金、博客园）
+	ImportButton = "import_button" // 工具栏的导入按钮（知乎 Markdown 导入）
+	ImportOption = "import_option" // 导入菜单中的"导入文档"项，点击后弹出文件选择框（知乎 Markdown 导入）
+)
+
+// defaults 内嵌的默认选择器，按平台名 -> 键名组织
+var defaults = map[string]map[string]string{
+	"掘金": {
+		Title:  "input.title-input",
+		Editor: "div.CodeMirror-scroll",
+		Cover:  ".publish-popup .coverselector_container input[type='file']",
+
+		Preview: ".bytemd-preview .markdown-body",
+
+		ImageButton:   ".bytemd-toolbar-icon[bytemd-tippy-path*='图片'], .bytemd-toolbar-icon[title*='图片']",
+		PublishButton: "button:has-text('发布')",
+	},
+	"博客园": {
+		Title:  "#post-title",
+		Editor: "#md-editor",
+
+		Preview: ".markdown-body, .editor-preview",
+
+		PublishButton: "button:has-text('发布')",
+	},
+	"知乎": {
+		Title:        "textarea.Input",
+		Editor:       "div.Editable-content",
+		Cover:        ".WriteCover-wrapper input[type='file'], input.UploadPicture-input",
+		AnswerButton: "button:has-text('写回答')",
+		AnswerEditor: ".AnswerForm div.Editable-content",
+		AnswerSubmit: ".AnswerForm button:has-text('发布回答')",
+		PinEditor:    ".Modal div.public-DraftEditor-content",
+
+		MentionOption: ".Popover-content .Menu-item, .Popover-content [role='option']",
+
+		ImportButton: "button[aria-label='导入'], button:has-text('导入')",
+		ImportOption: ".Popover-content button:has-text('导入文档'), .Popover-content [role='menuitem']:has-text('导入文档')",
+
+		ImageButton:   "button[aria-label='图片']",
+		PublishButton: "button:has-text('发布')",
+	},
+	"SegmentFault": {
+		Title:  "input[placeholder*='标题']",
+		Editor: ".CodeMirror",
+
+		PublishButton: "button:has-text('发布')",
+	},
+	"头条号": {
+		Title:  ".editor-title textarea",
+		Editor: ".ProseMirror",
+
+		MentionOption: ".mention-list .mention-item, .topic-list .topic-item, [role='listbox'] [role='option']",
+
+		PublishButton: "button:has-text('发布')",
+	},
+	"百家号": {
+		Title:  "textarea[placeholder*='标题']",
+		Editor: "div[contenteditable='true']",
+
+		PublishButton: "button:has-text('发布')",
+	},
+}
+
+var (
+	overrides = make(map[string]map[string]string)
+	mutex     sync.RWMutex
+)
+
+// Get 获取指定平台的选择器，外部覆盖优先，其次为内嵌默认值
+func Get(platform, key string) string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if value, ok := overrides[platform][key]; ok && value != "" {
+		return value
+	}
+	return defaults[platform][key]
+}
+
+// LoadOverrides 从 JSON 文件加载选择器覆盖，格式为 {"知乎": {"title": "textarea.Input"}}
+func LoadOverrides(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("读取选择器配置失败: %v", err)
+	}
+
+	var loaded map[string]map[string]string
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return 0, fmt.Errorf("解析选择器配置失败: %v", err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	count := 0
+	for platform, values := range loaded {
+		if _, ok := defaults[platform]; !ok {
+			return 0, fmt.Errorf("选择器配置中存在未知平台: %s", platform)
+		}
+		if overrides[platform] == nil {
+			overrides[platform] = make(map[string]string)
+		}
+		for key, value := range values {
+			overrides[platform][key] = value
+			count++
+		}
+	}
+	return count, nil
+}
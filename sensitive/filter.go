@@ -0,0 +1,240 @@
+package sensitive
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/auto-blog/article"
+)
+
+// Strategy 命中敏感词时的处理策略
+type Strategy string
+
+const (
+	StrategyWarn    Strategy = "warn"    // 只警告
+	StrategyReplace Strategy = "replace" // 替换为 *
+	StrategyBlock   Strategy = "block"   // 阻止发布
+)
+
+// ParseStrategy 解析策略名称，未知值按警告处理
+func ParseStrategy(name string) Strategy {
+	switch Strategy(strings.ToLower(strings.TrimSpace(name))) {
+	case StrategyReplace:
+		return StrategyReplace
+	case StrategyBlock:
+		return StrategyBlock
+	default:
+		return StrategyWarn
+	}
+}
+
+// Hit 敏感词命中
+type Hit struct {
+	Word string // 命中的敏感词
+	Line int    // 所在行（0 表示标题，正文从 1 开始）
+}
+
+// String 命中的字符串表示
+func (h Hit) String() string {
+	if h.Line == 0 {
+		return fmt.Sprintf("标题包含敏感词「%s」", h.Word)
+	}
+	return fmt.Sprintf("正文第%d行包含敏感词「%s」", h.Line, h.Word)
+}
+
+// Filter 敏感词过滤器
+type Filter struct {
+	words    []string // 敏感词
+	allows   []string // 白名单词：敏感词出现在这些词内部时不算命中（用于中文分词边界）
+	strategy Strategy
+}
+
+// LoadFilter 从词表文件加载敏感词。
+// 每行一个词，# 开头为注释；以 ! 开头的是白名单词，
+// 例如敏感词「赌博」配合白名单「!反赌博」可避免误伤包含它的正常词语。
+func LoadFilter(path string, strategy Strategy) (*Filter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开敏感词表 %s: %v", path, err)
+	}
+	defer file.Close()
+
+	filter := &Filter{strategy: strategy}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if strings.HasPrefix(word, "!") {
+			if allow := strings.TrimSpace(word[1:]); allow != "" {
+				filter.allows = append(filter.allows, allow)
+			}
+			continue
+		}
+		filter.words = append(filter.words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取敏感词表失败: %v", err)
+	}
+	return filter, nil
+}
+
+// Strategy 返回处理策略
+func (f *Filter) Strategy() Strategy {
+	return f.strategy
+}
+
+// WordCount 返回敏感词数量
+func (f *Filter) WordCount() int {
+	return len(f.words)
+}
+
+// Scan 扫描文章标题和正文，返回所有命中
+func (f *Filter) Scan(art *article.Article) []Hit {
+	hits := make([]Hit, 0)
+	for _, word := range f.findIn(art.Title) {
+		hits = append(hits, Hit{Word: word, Line: 0})
+	}
+	for i, line := range art.Content {
+		for _, word := range f.findIn(line) {
+			hits = append(hits, Hit{Word: word, Line: i + 1})
+		}
+	}
+	return hits
+}
+
+// Apply 按策略处理文章，返回命中列表以及是否应阻止发布
+func (f *Filter) Apply(art *article.Article) ([]Hit, bool) {
+	hits := f.Scan(art)
+	if len(hits) == 0 {
+		return hits, false
+	}
+
+	switch f.strategy {
+	case StrategyBlock:
+		return hits, true
+	case StrategyReplace:
+		art.Title = f.mask(art.Title)
+		for i := range art.Content {
+			f.maskLine(art, i)
+		}
+	}
+	return hits, false
+}
+
+// findIn 返回文本中命中的敏感词（每个词只返回一次）
+func (f *Filter) findIn(text string) []string {
+	found := make([]string, 0)
+	for _, word := range f.words {
+		if len(f.matches(text, word)) > 0 {
+			found = append(found, word)
+		}
+	}
+	return found
+}
+
+// matches 返回敏感词在文本中所有有效命中的字节偏移
+func (f *Filter) matches(text, word string) []int {
+	offsets := make([]int, 0)
+	for start := 0; start <= len(text)-len(word); {
+		index := strings.Index(text[start:], word)
+		if index < 0 {
+			break
+		}
+		offset := start + index
+		if f.atBoundary(text, word, offset) && !f.insideAllowedWord(text, word, offset) {
+			offsets = append(offsets, offset)
+		}
+		start = offset + len(word)
+	}
+	return offsets
+}
+
+// atBoundary 英文/数字敏感词要求前后是单词边界，避免 "ass" 命中 "class"
+func (f *Filter) atBoundary(text, word string, offset int) bool {
+	if !isWordRune(firstRune(word)) {
+		return true
+	}
+	if offset > 0 && isWordRune(lastRune(text[:offset])) {
+		return false
+	}
+	if end := offset + len(word); end < len(text) && isWordRune(firstRune(text[end:])) {
+		return false
+	}
+	return true
+}
+
+// insideAllowedWord 判断命中位置是否落在白名单词内部（中文分词边界）
+func (f *Filter) insideAllowedWord(text, word string, offset int) bool {
+	for _, allow := range f.allows {
+		inner := strings.Index(allow, word)
+		if inner < 0 {
+			continue
+		}
+		start := offset - inner
+		if start >= 0 && start+len(allow) <= len(text) && text[start:start+len(allow)] == allow {
+			return true
+		}
+	}
+	return false
+}
+
+// mask 将文本中的敏感词替换为等长的 *
+func (f *Filter) mask(text string) string {
+	for _, word := range f.words {
+		offsets := f.matches(text, word)
+		if len(offsets) == 0 {
+			continue
+		}
+		var builder strings.Builder
+		last := 0
+		for _, offset := range offsets {
+			builder.WriteString(text[last:offset])
+			builder.WriteString(strings.Repeat("*", len([]rune(word))))
+			last = offset + len(word)
+		}
+		builder.WriteString(text[last:])
+		text = builder.String()
+	}
+	return text
+}
+
+// maskLine 将正文第 i 行的敏感词替换为等长的 *，通过 EditLine 同步调整图片占位符的位置，
+// 落在图片占位符内的命中不替换
+func (f *Filter) maskLine(art *article.Article, i int) {
+	for _, word := range f.words {
+		offsets := f.matches(art.Content[i], word)
+		if len(offsets) == 0 {
+			continue
+		}
+		edits := make([]article.LineEdit, 0, len(offsets))
+		for _, offset := range offsets {
+			edits = append(edits, article.LineEdit{Start: offset, End: offset + len(word), Text: strings.Repeat("*", len([]rune(word)))})
+		}
+		art.EditLine(i, edits)
+	}
+}
+
+// isWordRune 判断是否为英文字母、数字或下划线
+func isWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
+
+func firstRune(s string) rune {
+	for _, r := range s {
+		return r
+	}
+	return 0
+}
+
+func lastRune(s string) rune {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return 0
+	}
+	return runes[len(runes)-1]
+}
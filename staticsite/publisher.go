@@ -0,0 +1,186 @@
+package staticsite
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/article"
+	"gopkg.in/yaml.v3"
+)
+
+// Name 平台名称（用于发布历史）
+const Name = "静态博客"
+
+// ID 平台标识，用于 frontmatter 中的平台映射
+const ID = "staticsite"
+
+// Options 静态博客输出配置
+type Options struct {
+	Root       string // 博客根目录（Hugo/Hexo 站点目录）
+	ContentDir string // 文章输出子路径，相对于根目录，如 content/posts（Hugo）或 source/_posts（Hexo）
+	ImageDir   string // 图片输出子路径，相对于根目录，如 static/images（Hugo）或 source/images（Hexo）
+	ImageURL   string // 图片在站点中的访问路径前缀，如 /images
+}
+
+// DefaultOptions 返回 Hugo 目录结构下的默认配置
+func DefaultOptions() Options {
+	return Options{
+		ContentDir: "content/posts",
+		ImageDir:   "static/images",
+		ImageURL:   "/images",
+	}
+}
+
+// Publisher 静态博客发布器：把文章写成带 frontmatter 的 Markdown 文件，不需要浏览器
+type Publisher struct {
+	options Options
+}
+
+// frontMatter 输出文件的 frontmatter
+type frontMatter struct {
+	Title       string   `yaml:"title"`
+	Date        string   `yaml:"date"`
+	Description string   `yaml:"description,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+}
+
+// NewPublisher 创建静态博客发布器
+func NewPublisher(options Options) (*Publisher, error) {
+	if options.Root == "" {
+		return nil, fmt.Errorf("未配置静态博客根目录")
+	}
+	defaults := DefaultOptions()
+	if options.ContentDir == "" {
+		options.ContentDir = defaults.ContentDir
+	}
+	if options.ImageDir == "" {
+		options.ImageDir = defaults.ImageDir
+	}
+	if options.ImageURL == "" {
+		options.ImageURL = defaults.ImageURL
+	}
+	if info, err := os.Stat(options.Root); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("静态博客根目录不存在: %s", options.Root)
+	}
+	return &Publisher{options: options}, nil
+}
+
+// PublishArticle 输出文章到 content 目录，图片拷贝到 images 目录并重写路径，返回生成的文件路径。
+// firstPublished 为文章首次输出的时间（没有发布记录时为零值），frontmatter 未设置 date 时作为文章日期
+func (p *Publisher) PublishArticle(art *article.Article, firstPublished time.Time) (string, error) {
+	slug := slugFor(art)
+
+	// 拷贝本地图片并计算站点内路径，远程图片保持原链接
+	imageURLs := make([]string, len(art.Images))
+	fileNames := make(map[string]string) // 输出文件名 -> 源图片路径
+	for i, img := range art.Images {
+		if isRemote(img.RelativePath) {
+			imageURLs[i] = img.RelativePath
+			continue
+		}
+		fileName := uniqueFileName(fileNames, img.AbsolutePath, i)
+		target := filepath.Join(p.options.Root, p.options.ImageDir, slug, fileName)
+		if err := copyFile(img.AbsolutePath, target); err != nil {
+			return "", fmt.Errorf("拷贝图片 %s 失败: %v", img.RelativePath, err)
+		}
+		imageURLs[i] = path.Join(p.options.ImageURL, slug, fileName)
+	}
+
+	// 视频转为链接写法（Hugo 默认不渲染正文中的 HTML，不使用 iframe）
+	content := art.WithVideos(article.Video.LinkMarkdown).ContentWithPlaceholders(func(index int, img article.Image) string {
+		return fmt.Sprintf("![%s](%s)", img.AltText, imageURLs[index])
+	})
+
+	header, err := yaml.Marshal(frontMatter{
+		Title:       art.TitleFor(ID, Name),
+		Date:        postDate(art, firstPublished).Format(time.RFC3339),
+		Description: strings.TrimSpace(art.Meta.Description),
+		Tags:        art.Meta.Tags,
+	})
+	if err != nil {
+		return "", fmt.Errorf("生成 frontmatter 失败: %v", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("---\n")
+	builder.Write(header)
+	builder.WriteString("---\n\n")
+	builder.WriteString(strings.TrimLeft(strings.Join(content, "\n"), "\n"))
+	builder.WriteString("\n")
+
+	outputPath := filepath.Join(p.options.Root, p.options.ContentDir, slug+".md")
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return "", fmt.Errorf("创建文章目录失败: %v", err)
+	}
+	if err := os.WriteFile(outputPath, []byte(builder.String()), 0644); err != nil {
+		return "", fmt.Errorf("写入文章失败: %v", err)
+	}
+
+	log.Printf("[%s] ✅ 《%s》已输出到 %s（%d 张图片）", Name, art.Title, outputPath, len(art.Images))
+	return outputPath, nil
+}
+
+// postDate 文章日期：frontmatter 的 date 优先，其次为定时发布时间（Hugo/Hexo 默认不渲染未来日期的文章），
+// 再次为首次输出的时间，保证重新输出修改后的文章时日期不变；都没有时使用当前时间
+func postDate(art *article.Article, firstPublished time.Time) time.Time {
+	if date, ok := art.ArticleDate(); ok {
+		return date
+	}
+	if publishAt, ok := art.ScheduledTime(); ok {
+		return publishAt
+	}
+	if !firstPublished.IsZero() {
+		return firstPublished
+	}
+	return time.Now()
+}
+
+// uniqueFileName 返回图片在输出目录中的文件名：不同目录下的同名图片加上序号区分，同一张图片多次引用时复用同一个文件
+func uniqueFileName(used map[string]string, source string, index int) string {
+	fileName := filepath.Base(source)
+	if previous, ok := used[fileName]; ok && previous != source {
+		ext := filepath.Ext(fileName)
+		fileName = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(fileName, ext), index+1, ext)
+	}
+	used[fileName] = source
+	return fileName
+}
+
+// slugFor 使用文章文件名（不含扩展名）作为输出文件名和图片子目录
+func slugFor(art *article.Article) string {
+	base := filepath.Base(art.Path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// isRemote 判断图片是否为网络地址
+func isRemote(src string) bool {
+	return strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://")
+}
+
+// copyFile 拷贝文件，自动创建目标目录
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,253 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// State 发布任务的运行状态
+type State string
+
+const (
+	StateRunning  State = "running"  // 正在发布
+	StateFinished State = "finished" // 进程已退出
+)
+
+// 平台的最终结果
+const (
+	ResultSucceeded  = "succeeded"  // 发布成功
+	ResultUnverified = "unverified" // 已提交但未确认文章存在
+	ResultFailed     = "failed"     // 发布失败
+)
+
+// StepImages 替换图片步骤，此时 ImagesDone/ImagesTotal 有意义
+const StepImages = "替换图片"
+
+// Platform 文章在某个平台上的实时进度
+type Platform struct {
+	Name        string    `json:"name"`             // 平台名称
+	Step        string    `json:"step"`             // 当前步骤，如 等待编辑器、填写内容、替换图片、提交
+	ImagesDone  int       `json:"images_done"`      // 已替换的图片数
+	ImagesTotal int       `json:"images_total"`     // 图片总数
+	Result      string    `json:"result,omitempty"` // 结束后的结果，见 ResultSucceeded 等
+	Error       string    `json:"error,omitempty"`  // 失败或未确认的原因
+	UpdatedAt   time.Time `json:"updated_at"`       // 更新时间
+}
+
+// Article 一篇文章的发布进度
+type Article struct {
+	Path      string     `json:"path"`      // 文章文件路径
+	Title     string     `json:"title"`     // 文章标题
+	Platforms []Platform `json:"platforms"` // 各平台进度，按开始顺序排列
+}
+
+// Status 状态文件的内容
+type Status struct {
+	PID        int        `json:"pid"`                   // 发布进程号
+	WorkDir    string     `json:"work_dir"`              // 发布进程的工作目录
+	State      State      `json:"state"`                 // 运行状态
+	Total      int        `json:"total"`                 // 本轮待发布的文章数
+	Done       int        `json:"done"`                  // 已处理完的文章数
+	Current    string     `json:"current,omitempty"`     // 正在发布的文章路径
+	Articles   []Article  `json:"articles"`              // 已开始发布的文章
+	StartedAt  time.Time  `json:"started_at"`            // 开始时间
+	UpdatedAt  time.Time  `json:"updated_at"`            // 最后更新时间
+	FinishedAt *time.Time `json:"finished_at,omitempty"` // 进程退出时间
+}
+
+// Writer 在发布过程中把实时进度写入状态文件，供另一个终端查看（auto-blog status）。
+// 所有方法对 nil 接收者安全，不需要状态文件时直接传 nil 即可
+type Writer struct {
+	path   string
+	status Status
+	mutex  sync.Mutex
+}
+
+// DefaultPath 返回默认的状态文件路径
+func DefaultPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".auto-blog", "status.json"), nil
+}
+
+// NewWriter 创建写入 path 的状态文件，立即写入运行中状态
+func NewWriter(path string) (*Writer, error) {
+	workDir, _ := os.Getwd()
+	now := time.Now()
+	w := &Writer{
+		path: path,
+		status: Status{
+			PID:       os.Getpid(),
+			WorkDir:   workDir,
+			State:     StateRunning,
+			Articles:  make([]Article, 0),
+			StartedAt: now,
+			UpdatedAt: now,
+		},
+	}
+	if err := w.save(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Load 读取状态文件
+func Load(path string) (*Status, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var status Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("解析状态文件失败: %v", err)
+	}
+	return &status, nil
+}
+
+// Start 开始新一轮发布，total 为本轮待发布的文章数
+func (w *Writer) Start(total int) {
+	if w == nil {
+		return
+	}
+	w.update(func(s *Status) {
+		s.Total += total
+	})
+}
+
+// StartArticle 开始发布一篇文章
+func (w *Writer) StartArticle(path, title string) {
+	if w == nil {
+		return
+	}
+	w.update(func(s *Status) {
+		s.Current = path
+		if w.article(path) == nil {
+			s.Articles = append(s.Articles, Article{Path: path, Title: title, Platforms: make([]Platform, 0)})
+		}
+	})
+}
+
+// FinishArticle 一篇文章在所有平台上处理完
+func (w *Writer) FinishArticle(path string) {
+	if w == nil {
+		return
+	}
+	w.update(func(s *Status) {
+		s.Done++
+		if s.Current == path {
+			s.Current = ""
+		}
+	})
+}
+
+// SetStep 更新文章在平台上的当前步骤
+func (w *Writer) SetStep(path, platformName, step string) {
+	if w == nil {
+		return
+	}
+	w.update(func(s *Status) {
+		if p := w.platform(path, platformName); p != nil {
+			p.Step = step
+		}
+	})
+}
+
+// SetImages 更新文章在平台上的图片替换进度
+func (w *Writer) SetImages(path, platformName string, done, total int) {
+	if w == nil {
+		return
+	}
+	w.update(func(s *Status) {
+		if p := w.platform(path, platformName); p != nil {
+			p.Step = StepImages
+			p.ImagesDone = done
+			p.ImagesTotal = total
+		}
+	})
+}
+
+// SetResult 记录文章在平台上的最终结果，reason 为失败或未确认的原因
+func (w *Writer) SetResult(path, platformName, result, reason string) {
+	if w == nil {
+		return
+	}
+	w.update(func(s *Status) {
+		if p := w.platform(path, platformName); p != nil {
+			p.Result = result
+			p.Error = reason
+		}
+	})
+}
+
+// Close 标记进程已退出，可重复调用
+func (w *Writer) Close() {
+	if w == nil {
+		return
+	}
+	w.update(func(s *Status) {
+		if s.State == StateFinished {
+			return
+		}
+		now := time.Now()
+		s.State = StateFinished
+		s.Current = ""
+		s.FinishedAt = &now
+	})
+}
+
+// article 查找文章进度，调用方需持有锁
+func (w *Writer) article(path string) *Article {
+	for i := range w.status.Articles {
+		if w.status.Articles[i].Path == path {
+			return &w.status.Articles[i]
+		}
+	}
+	return nil
+}
+
+// platform 查找文章在平台上的进度，平台尚未记录时新增，文章尚未开始时返回 nil。调用方需持有锁
+func (w *Writer) platform(path, platformName string) *Platform {
+	art := w.article(path)
+	if art == nil {
+		return nil
+	}
+	for i := range art.Platforms {
+		if art.Platforms[i].Name == platformName {
+			art.Platforms[i].UpdatedAt = time.Now()
+			return &art.Platforms[i]
+		}
+	}
+	art.Platforms = append(art.Platforms, Platform{Name: platformName, UpdatedAt: time.Now()})
+	return &art.Platforms[len(art.Platforms)-1]
+}
+
+// update 修改状态并写入文件，写入失败不影响发布
+func (w *Writer) update(change func(s *Status)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	change(&w.status)
+	w.status.UpdatedAt = time.Now()
+	w.save()
+}
+
+// save 先写临时文件再重命名，读取方不会读到写了一半的文件
+func (w *Writer) save() error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(w.status, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := w.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, w.path)
+}
@@ -0,0 +1,130 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/auto-blog/article"
+	"gopkg.in/yaml.v3"
+)
+
+// Task 任务文件：用 JSON 或 YAML 描述一批文章的发布计划，每篇文章可以单独指定平台、标题、标签和定时发布时间。
+// 与 ini 配置并存：任务文件只决定发布哪些文章以及每篇文章的设置，账号、浏览器等仍读取 ini 配置
+//
+//	{
+//	  "articles": [
+//	    {"path": "articles/a.md", "platforms": ["juejin", "zhihu"], "publish_at": "2024-06-01 08:00"},
+//	    {"path": "articles/b.md", "title": "新标题", "titles": {"zhihu": "知乎标题"}, "tags": ["Go"]}
+//	  ]
+//	}
+type Task struct {
+	Articles []Article `json:"articles" yaml:"articles"` // 按顺序发布的文章
+}
+
+// Article 任务中的一篇文章，除 path 外都是可选的，设置后覆盖文章 frontmatter 中的同名字段
+type Article struct {
+	Path      string            `json:"path" yaml:"path"`                                 // 文章文件路径，相对路径基于任务文件所在目录
+	Title     string            `json:"title,omitempty" yaml:"title"`                     // 文章标题
+	Titles    map[string]string `json:"titles,omitempty" yaml:"titles"`                   // 各平台使用的标题（平台标识或名称 -> 标题），与 frontmatter 合并
+	Tags      []string          `json:"tags,omitempty" yaml:"tags"`                       // 文章标签
+	Platforms []string          `json:"platforms,omitempty" yaml:"platforms"`             // 发布的平台（平台标识或名称），未设置时按 frontmatter 和启用的平台
+	PublishAt string            `json:"publish_at,omitempty" yaml:"publish_at,omitempty"` // 定时发布时间，如 2024-06-01 08:00
+}
+
+// Load 读取任务文件，.yaml/.yml 按 YAML 解析，其它按 JSON 解析；未知字段视为错误，避免拼错的字段被悄悄忽略
+func Load(path string) (*Task, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取任务文件失败: %v", err)
+	}
+
+	var task Task
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&task)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&task)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析任务文件 %s 失败: %v", path, err)
+	}
+
+	if err := task.resolve(filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("任务文件 %s 有误: %v", path, err)
+	}
+	return &task, nil
+}
+
+// resolve 把相对路径转换为基于 dir 的路径，并校验每篇文章的设置
+func (t *Task) resolve(dir string) error {
+	if len(t.Articles) == 0 {
+		return fmt.Errorf("没有列出任何文章（articles）")
+	}
+	seen := make(map[string]bool)
+	for i := range t.Articles {
+		item := &t.Articles[i]
+		item.Path = strings.TrimSpace(item.Path)
+		if item.Path == "" {
+			return fmt.Errorf("第 %d 篇文章缺少 path", i+1)
+		}
+		if !filepath.IsAbs(item.Path) {
+			item.Path = filepath.Join(dir, item.Path)
+		}
+		if seen[item.Path] {
+			return fmt.Errorf("文章 %s 重复列出", item.Path)
+		}
+		seen[item.Path] = true
+		if item.PublishAt != "" {
+			if _, err := article.ParsePublishTime(item.PublishAt); err != nil {
+				return fmt.Errorf("文章 %s 的 publish_at: %v", item.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Apply 用任务中的设置覆盖已解析文章的 frontmatter，titlePunctuation 为标题末尾要去掉的标点
+func (a Article) Apply(art *article.Article, titlePunctuation string) {
+	if title := strings.TrimSpace(a.Title); title != "" {
+		art.Meta.Title = title
+		art.Title = article.NormalizeTitle(title, titlePunctuation)
+	}
+	if len(a.Titles) > 0 {
+		titles := make(map[string]string, len(art.Meta.Titles)+len(a.Titles))
+		for key, title := range art.Meta.Titles {
+			titles[key] = title
+		}
+		for key, title := range a.Titles {
+			titles[key] = title
+		}
+		art.Meta.Titles = titles
+	}
+	if len(a.Tags) > 0 {
+		art.Meta.Tags = trimList(a.Tags)
+	}
+	if len(a.Platforms) > 0 {
+		art.Meta.Platforms = trimList(a.Platforms)
+	}
+	if a.PublishAt != "" {
+		art.Meta.PublishAt = a.PublishAt
+	}
+}
+
+// trimList 去掉列表项的空白和空项
+func trimList(items []string) article.StringList {
+	list := make(article.StringList, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,31 @@
+package tasklog
+
+import (
+	"fmt"
+	"log"
+)
+
+// Logger 带 [平台][文章N] 前缀的日志记录器。并行发布时每个平台/文章的 goroutine 各用一个，
+// 交错输出的日志也能分辨归属。所有方法对 nil 接收者安全，此时不加前缀
+type Logger struct {
+	prefix string
+}
+
+// New 创建平台 platformName 上第 articleNumber 篇文章（从 1 开始）的日志记录器，
+// articleNumber 不大于 0 时只加平台前缀
+func New(platformName string, articleNumber int) *Logger {
+	prefix := fmt.Sprintf("[%s]", platformName)
+	if articleNumber > 0 {
+		prefix += fmt.Sprintf("[文章%d]", articleNumber)
+	}
+	return &Logger{prefix: prefix + " "}
+}
+
+// Printf 按 log.Printf 的格式输出一条带前缀的日志
+func (l *Logger) Printf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if l != nil {
+		message = l.prefix + message
+	}
+	log.Output(2, message)
+}
@@ -0,0 +1,92 @@
+package tasklog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Router 按平台分文件输出日志：日志行带有 [平台] 标记时（包括 Logger 的前缀和各平台发布器自己的日志），
+// 在正常输出之外追加到 <dir>/<平台>.log。所有方法对 nil 接收者安全，不分文件时直接传 nil 即可
+type Router struct {
+	dir       string
+	platforms []string
+	files     map[string]*os.File
+	mutex     sync.Mutex
+}
+
+// NewRouter 创建输出到 dir 目录的日志分流器，platforms 为需要单独成文件的平台名称
+func NewRouter(dir string, platforms []string) (*Router, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("创建日志目录失败: %v", err)
+	}
+	return &Router{
+		dir:       dir,
+		platforms: platforms,
+		files:     make(map[string]*os.File),
+	}, nil
+}
+
+// Writer 返回供日志使用的 Writer：日志照常写入 out，属于某个平台的日志行同时追加到该平台的日志文件
+func (r *Router) Writer(out io.Writer) io.Writer {
+	if r == nil {
+		return out
+	}
+	return &routeWriter{router: r, out: out}
+}
+
+// Close 关闭所有平台日志文件
+func (r *Router) Close() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for name, file := range r.files {
+		file.Close()
+		delete(r.files, name)
+	}
+}
+
+// route 将日志行追加到第一个匹配的平台日志文件，写文件失败时忽略（不影响正常输出）
+func (r *Router) route(p []byte) {
+	for _, name := range r.platforms {
+		if !bytes.Contains(p, []byte("["+name+"]")) {
+			continue
+		}
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		if file := r.fileFor(name); file != nil {
+			file.Write(p)
+		}
+		return
+	}
+}
+
+// fileFor 返回平台的日志文件，首次使用时以追加方式打开，打开失败时返回 nil
+func (r *Router) fileFor(name string) *os.File {
+	if file, ok := r.files[name]; ok {
+		return file
+	}
+	file, err := os.OpenFile(filepath.Join(r.dir, name+".log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		file = nil
+	}
+	r.files[name] = file
+	return file
+}
+
+type routeWriter struct {
+	router *Router
+	out    io.Writer
+}
+
+func (w *routeWriter) Write(p []byte) (int, error) {
+	n, err := w.out.Write(p)
+	w.router.route(p)
+	return n, err
+}
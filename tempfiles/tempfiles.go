@@ -0,0 +1,67 @@
+package tempfiles
+
+import (
+	"log"
+	"os"
+	"sync"
+)
+
+// registry 本次运行中登记的临时文件，程序退出时统一清理
+var registry = struct {
+	sync.Mutex
+	paths []string
+	seen  map[string]bool
+	keep  bool
+}{seen: make(map[string]bool)}
+
+// Register 登记一个临时文件或目录（生成的封面、加水印或压缩后的图片、下载的远程图片等），
+// 程序退出时由 Cleanup 删除。重复登记同一路径只记一次
+func Register(path string) {
+	if path == "" {
+		return
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if registry.seen[path] {
+		return
+	}
+	registry.seen[path] = true
+	registry.paths = append(registry.paths, path)
+}
+
+// SetKeep 设置退出时是否保留临时文件（[log] keep_temp_files），调试图片处理问题时开启
+func SetKeep(keep bool) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.keep = keep
+}
+
+// Cleanup 删除所有登记的临时文件并清空登记，可重复调用；保留模式下只列出文件路径
+func Cleanup() {
+	registry.Lock()
+	paths, keep := registry.paths, registry.keep
+	registry.paths = nil
+	registry.seen = make(map[string]bool)
+	registry.Unlock()
+
+	if len(paths) == 0 {
+		return
+	}
+	if keep {
+		log.Printf("🗂️ 已保留 %d 个临时文件（[log] keep_temp_files = true）:", len(paths))
+		for _, path := range paths {
+			log.Printf("   %s", path)
+		}
+		return
+	}
+
+	removed := 0
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil {
+			log.Printf("⚠️ 清理临时文件失败 %s: %v", path, err)
+			continue
+		}
+		removed++
+	}
+	log.Printf("🧹 已清理 %d 个临时文件", removed)
+}
@@ -0,0 +1,72 @@
+package toutiao
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// loginURLMarkers 未登录时跳转到的登录页地址特征
+var loginURLMarkers = []string{"/auth/page/login", "sso.toutiao.com"}
+
+// SaveSessionFunc 保存会话的回调函数类型
+type SaveSessionFunc func() error
+
+// LoginChecker 头条号登录检查器
+type LoginChecker struct {
+	originalURL string
+	saveSession SaveSessionFunc
+}
+
+// NewLoginChecker 创建登录检查器
+func NewLoginChecker(originalURL string, saveSession SaveSessionFunc) *LoginChecker {
+	return &LoginChecker{
+		originalURL: originalURL,
+		saveSession: saveSession,
+	}
+}
+
+// CheckAndWaitForLogin 检查并等待用户登录
+func (lc *LoginChecker) CheckAndWaitForLogin(page playwright.Page) {
+	if !IsLoginRequired(page) {
+		return
+	}
+	log.Println("🔐 检测到头条号未登录，请在浏览器中完成登录（头条号风控较严，建议扫码登录）")
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		if IsLoginRequired(page) {
+			continue
+		}
+		log.Println("✅ 头条号登录成功")
+
+		if lc.saveSession != nil {
+			if err := lc.saveSession(); err != nil {
+				log.Printf("⚠️ 登录成功后保存会话失败: %v", err)
+			} else {
+				log.Println("💾 登录成功，会话状态已保存")
+			}
+		}
+
+		// 登录后通常回到头条号首页，需跳回发布页
+		if !strings.Contains(page.URL(), "/graphic/publish") {
+			log.Printf("正在跳转回编辑页面: %s", lc.originalURL)
+			page.Goto(lc.originalURL)
+		}
+		return
+	}
+}
+
+// IsLoginRequired 检查是否需要登录
+func IsLoginRequired(page playwright.Page) bool {
+	currentURL := page.URL()
+	for _, marker := range loginURLMarkers {
+		if strings.Contains(currentURL, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package toutiao
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+	"github.com/playwright-community/playwright-go"
+)
+
+// typeDelay 逐字输入标题时每个字符的间隔（毫秒）。头条号风控较严，
+// 直接 Fill 写入的标题容易被识别为脚本操作
+const typeDelay = 80
+
+// Publisher 头条号文章发布器
+type Publisher struct {
+	page playwright.Page
+}
+
+// NewPublisher 创建头条号文章发布器
+func NewPublisher(page playwright.Page) *Publisher {
+	return &Publisher{
+		page: page,
+	}
+}
+
+// PublishArticle 发布文章到头条号
+func (p *Publisher) PublishArticle(art *article.Article) error {
+	log.Printf("开始发布文章到头条号: %s", art.Title)
+	// 头条号支持 @用户 和 #话题#，填写正文后再逐个触发提及/话题选择
+	art, mentions := art.WithMentionPlaceholders(article.MentionUser, article.MentionTopic)
+
+	// 1. 填写标题
+	if err := p.fillTitle(art.Title); err != nil {
+		log.Printf("⚠️ 标题填写遇到问题: %v", err)
+	} else {
+		log.Printf("✅ 标题填写完成")
+	}
+
+	// 2. 填写正文（图片先以占位符代替，之后统一替换），再把提及/话题占位符替换为头条号的 @用户 和 #话题#
+	if err := p.fillContent(art); err != nil {
+		log.Printf("⚠️ 正文填写遇到问题: %v", err)
+	} else {
+		log.Printf("✅ 正文填写完成")
+		common.ApplyMentions(p.page, Name, selectors.Get(Name, selectors.Editor), selectors.Get(Name, selectors.MentionOption), mentions)
+	}
+
+	log.Printf("🎉 文章《%s》发布操作完成", art.Title)
+	return nil
+}
+
+// fillTitle 模拟键盘逐字输入标题
+func (p *Publisher) fillTitle(title string) error {
+	titleLocator := p.page.Locator(selectors.Get(Name, selectors.Title)).First()
+	if err := common.WaitVisible(titleLocator, 10*time.Second); err != nil {
+		return fmt.Errorf("等待标题输入框超时: %v", err)
+	}
+	if err := common.HumanClick(titleLocator); err != nil {
+		return fmt.Errorf("点击标题输入框失败: %v", err)
+	}
+	if err := titleLocator.Fill(""); err != nil {
+		return fmt.Errorf("清空标题失败: %v", err)
+	}
+	if err := p.page.Keyboard().Type(title, playwright.KeyboardTypeOptions{Delay: playwright.Float(typeDelay)}); err != nil {
+		return fmt.Errorf("输入标题失败: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// fillContent 把正文转换为富文本后粘贴到编辑器
+func (p *Publisher) fillContent(art *article.Article) error {
+	config := common.RichContentConfig{
+		PlatformName:         Name,
+		EditorSelector:       selectors.Get(Name, selectors.Editor),
+		InputMethod:          common.InputMethodRichText, // 头条号编辑器不解析 Markdown，粘贴 HTML 富文本
+		SkipImageReplacement: true,                       // 图片保留占位符，在统一阶段替换
+	}
+
+	handler := common.NewRichContentHandler(p.page, config)
+	return handler.FillContent(art)
+}
+
+// ReplaceTextWithImage 查找占位符并替换为图片（剪贴板粘贴方式）
+func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image) error {
+	return common.ReplacePlaceholderWithImage(p.page, Name, selectors.Get(Name, selectors.Editor), placeholder, img.AbsolutePath)
+}
+
+// ReplaceTextWithText 查找占位符并替换为文本（图床链接或跳过图片时的 alt 文本）
+func (p *Publisher) ReplaceTextWithText(placeholder, text string) error {
+	return common.ReplaceTextInRichEditor(p.page, selectors.Get(Name, selectors.Editor), placeholder, text)
+}
@@ -0,0 +1,35 @@
+package toutiao
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+)
+
+// Submit 草稿模式下等待头条号自动保存草稿，发布模式下点击"预览并发布"并确认
+func (p *Publisher) Submit(mode platform.PublishMode) error {
+	if mode != platform.PublishModePublish {
+		// 头条号编辑页没有单独的保存按钮，内容变化后自动保存到草稿箱
+		if common.WaitForPageText(p.page, 15*time.Second, "草稿已保存", "已保存") == "" {
+			return fmt.Errorf("未看到草稿已保存提示，可能保存失败")
+		}
+		log.Printf("[头条号] 📝 已保存到草稿箱")
+		return nil
+	}
+
+	if err := common.ClickButtonByText(p.page, "", "预览并发布", "发布"); err != nil {
+		return err
+	}
+	// 部分账号会弹出发布确认，没有时忽略
+	time.Sleep(2 * time.Second)
+	common.ClickButtonByText(p.page, "", "确认发布", "确定发布")
+
+	if common.WaitForPageText(p.page, 15*time.Second, "发布成功", "已发布") == "" {
+		return fmt.Errorf("点击发布后未看到发布成功提示，可能发布失败")
+	}
+	log.Printf("[头条号] 🎉 文章已发布")
+	return nil
+}
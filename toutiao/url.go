@@ -0,0 +1,6 @@
+package toutiao
+
+// URL 头条号图文发布页URL
+func URL() string {
+	return "https://mp.toutiao.com/profile_v4/graphic/publish"
+}
@@ -0,0 +1,21 @@
+package toutiao
+
+import (
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+)
+
+// 头条号后台的草稿箱和作品管理列表（包含审核中的文章）
+const (
+	draftsURL   = "https://mp.toutiao.com/profile_v4/manage/draft"
+	articlesURL = "https://mp.toutiao.com/profile_v4/manage/content/all"
+)
+
+// VerifySubmitted 提交后打开草稿箱或作品管理列表，确认文章确实出现且标题匹配
+func (p *Publisher) VerifySubmitted(title string, mode platform.PublishMode) (string, error) {
+	listURL := draftsURL
+	if mode == platform.PublishModePublish {
+		listURL = articlesURL
+	}
+	return common.VerifyArticleListed(p.page, Name, listURL, title)
+}
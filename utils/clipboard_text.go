@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ReadClipboardText 读取系统剪贴板中的文本
+func ReadClipboardText() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("pbpaste")
+	case "windows":
+		cmd = exec.Command("powershell", "-command", "Get-Clipboard -Raw")
+	case "linux":
+		cmd = exec.Command("xclip", "-selection", "clipboard", "-o")
+	default:
+		return "", fmt.Errorf("不支持的操作系统: %s", runtime.GOOS)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("读取剪贴板失败: %v", err)
+	}
+	// Windows 剪贴板使用 \r\n 换行
+	return strings.ReplaceAll(string(output), "\r\n", "\n"), nil
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// OpenInBrowser 用系统默认浏览器打开本地文件或网址
+func OpenInBrowser(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	case "linux":
+		cmd = exec.Command("xdg-open", target)
+	default:
+		return fmt.Errorf("不支持的操作系统: %s", runtime.GOOS)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("打开浏览器失败: %v", err)
+	}
+	// 不等待浏览器退出，回收子进程即可
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+// orientedImageQuality 旋转后重新编码 JPEG 的质量
+const orientedImageQuality = 95
+
+// readOrientation 读取 JPEG 的 EXIF 方向标记，没有标记时返回 1（正常方向）
+func readOrientation(path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 1
+	}
+	defer file.Close()
+
+	x, err := exif.Decode(file)
+	if err != nil {
+		return 1
+	}
+	tag, err := x.Get(exif.Orientation)
+	if err != nil {
+		return 1
+	}
+	orientation, err := tag.Int(0)
+	if err != nil || orientation < 1 || orientation > 8 {
+		return 1
+	}
+	return orientation
+}
+
+// FixOrientation 按 EXIF 方向标记旋转图片像素，并输出不带方向标记的新图片到 cacheDir。
+// 图片无需校正时返回原路径和 false。
+func FixOrientation(imagePath, cacheDir string) (string, bool, error) {
+	ext := strings.ToLower(filepath.Ext(imagePath))
+	if ext != ".jpg" && ext != ".jpeg" {
+		return imagePath, false, nil
+	}
+
+	orientation := readOrientation(imagePath)
+	if orientation == 1 {
+		return imagePath, false, nil
+	}
+
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return imagePath, false, fmt.Errorf("打开图片失败: %v", err)
+	}
+	defer file.Close()
+
+	src, err := jpeg.Decode(file)
+	if err != nil {
+		return imagePath, false, fmt.Errorf("解码图片失败: %v", err)
+	}
+
+	oriented := applyOrientation(src, orientation)
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return imagePath, false, fmt.Errorf("创建缓存目录失败: %v", err)
+	}
+
+	// 缓存文件名包含原路径哈希，保留原文件名便于平台显示
+	sum := sha256.Sum256([]byte(imagePath))
+	outputPath := filepath.Join(cacheDir, hex.EncodeToString(sum[:])[:12]+"_"+filepath.Base(imagePath))
+	output, err := os.Create(outputPath)
+	if err != nil {
+		return imagePath, false, fmt.Errorf("创建校正后的图片失败: %v", err)
+	}
+	defer output.Close()
+
+	// 重新编码不会写入 EXIF，方向标记随之清除
+	if err := jpeg.Encode(output, oriented, &jpeg.Options{Quality: orientedImageQuality}); err != nil {
+		return imagePath, false, fmt.Errorf("编码图片失败: %v", err)
+	}
+
+	return outputPath, true, nil
+}
+
+// applyOrientation 按 EXIF 方向值（1-8）变换图片
+func applyOrientation(src image.Image, orientation int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// 5-8 需要交换宽高
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	// 先统一转成 RGBA，逐像素拷贝更快
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 水平翻转
+				dx, dy = width-1-x, y
+			case 3: // 旋转180度
+				dx, dy = width-1-x, height-1-y
+			case 4: // 垂直翻转
+				dx, dy = x, height-1-y
+			case 5: // 沿左上-右下对角线翻转
+				dx, dy = y, x
+			case 6: // 顺时针旋转90度
+				dx, dy = height-1-y, x
+			case 7: // 沿右上-左下对角线翻转
+				dx, dy = height-1-y, width-1-x
+			case 8: // 逆时针旋转90度
+				dx, dy = y, width-1-x
+			default:
+				dx, dy = x, y
+			}
+			dst.SetRGBA(dx, dy, rgba.RGBAAt(x, y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	shrinkMaxDimension = 4096 // 压缩后图片长边的上限（像素）
+	shrinkQuality      = 85   // 压缩时 JPEG 的编码质量
+	shrinkScaleStep    = 0.75 // 压缩后仍超限时每次缩小的比例
+	shrinkMaxAttempts  = 8    // 缩小尺寸的最多次数
+)
+
+// ShrinkImage 将超过 maxBytes 的图片缩小尺寸并重新编码为 JPEG，输出到 cacheDir。
+// 图片未超限或为 GIF（重新编码会丢失动画）时返回原路径和 false；多次缩小后仍超限时返回错误
+func ShrinkImage(imagePath, cacheDir string, maxBytes int64) (string, bool, error) {
+	info, err := os.Stat(imagePath)
+	if err != nil {
+		return imagePath, false, fmt.Errorf("读取图片信息失败: %v", err)
+	}
+	if info.Size() <= maxBytes || strings.ToLower(filepath.Ext(imagePath)) == ".gif" {
+		return imagePath, false, nil
+	}
+
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return imagePath, false, fmt.Errorf("打开图片失败: %v", err)
+	}
+	src, _, err := image.Decode(file)
+	file.Close()
+	if err != nil {
+		return imagePath, false, fmt.Errorf("解码图片失败: %v", err)
+	}
+
+	bounds := src.Bounds()
+	longest := bounds.Dx()
+	if bounds.Dy() > longest {
+		longest = bounds.Dy()
+	}
+	scale := 1.0
+	if longest > shrinkMaxDimension {
+		scale = float64(shrinkMaxDimension) / float64(longest)
+	}
+
+	var encoded bytes.Buffer
+	for attempt := 0; attempt < shrinkMaxAttempts; attempt++ {
+		dst := image.NewRGBA(image.Rect(0, 0, scaledSize(bounds.Dx(), scale), scaledSize(bounds.Dy(), scale)))
+		// JPEG 不支持透明，透明区域铺白底
+		draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+		encoded.Reset()
+		if err := jpeg.Encode(&encoded, dst, &jpeg.Options{Quality: shrinkQuality}); err != nil {
+			return imagePath, false, fmt.Errorf("编码图片失败: %v", err)
+		}
+		if int64(encoded.Len()) <= maxBytes {
+			break
+		}
+		scale *= shrinkScaleStep
+	}
+	if int64(encoded.Len()) > maxBytes {
+		return imagePath, false, fmt.Errorf("压缩后图片仍有 %d bytes，超过上限 %d bytes", encoded.Len(), maxBytes)
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return imagePath, false, fmt.Errorf("创建缓存目录失败: %v", err)
+	}
+	// 缓存文件名包含原路径哈希，保留原文件名便于平台显示
+	sum := sha256.Sum256([]byte(imagePath))
+	base := strings.TrimSuffix(filepath.Base(imagePath), filepath.Ext(imagePath))
+	outputPath := filepath.Join(cacheDir, hex.EncodeToString(sum[:])[:12]+"_"+base+"_shrunk.jpg")
+	if err := os.WriteFile(outputPath, encoded.Bytes(), 0644); err != nil {
+		return imagePath, false, fmt.Errorf("保存压缩后的图片失败: %v", err)
+	}
+	return outputPath, true, nil
+}
+
+// scaledSize 按比例缩放后的边长，至少为 1 像素
+func scaledSize(size int, scale float64) int {
+	if scaled := int(float64(size)*scale + 0.5); scaled > 1 {
+		return scaled
+	}
+	return 1
+}
@@ -0,0 +1,159 @@
+package watcher
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher 文章目录监听器
+type Watcher struct {
+	dir         string
+	stableDelay time.Duration
+	fsWatcher   *fsnotify.Watcher
+	timers      map[string]*time.Timer
+	sizes       map[string]int64
+	mutex       sync.Mutex
+}
+
+// NewWatcher 创建目录监听器，监听 dir 及其所有子目录，stableDelay 为文件停止写入多久后视为写入完成
+func NewWatcher(dir string, stableDelay time.Duration) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("创建文件监听器失败: %v", err)
+	}
+
+	if err := addTree(fsWatcher, dir, nil); err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+
+	return &Watcher{
+		dir:         dir,
+		stableDelay: stableDelay,
+		fsWatcher:   fsWatcher,
+		timers:      make(map[string]*time.Timer),
+		sizes:       make(map[string]int64),
+	}, nil
+}
+
+// Watch 开始监听，.md 文件新增或修改并写入完成后调用 onChange（阻塞直到 Close）
+func (w *Watcher) Watch(onChange func(path string)) {
+	log.Printf("👀 开始监听目录: %s", w.dir)
+
+	for {
+		select {
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					w.watchNewDir(event.Name, onChange)
+					continue
+				}
+			}
+			if !strings.HasSuffix(strings.ToLower(event.Name), ".md") {
+				continue
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename) == 0 {
+				continue
+			}
+			w.schedule(event.Name, onChange)
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("⚠️ 文件监听出错: %v", err)
+		}
+	}
+}
+
+// watchNewDir 开始监听新建（或移入）的目录及其子目录，目录中已有的文章按新增处理
+func (w *Watcher) watchNewDir(dir string, onChange func(path string)) {
+	err := addTree(w.fsWatcher, dir, func(path string) {
+		w.schedule(path, onChange)
+	})
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+}
+
+// addTree 监听 root 及其下的所有子目录（fsnotify 不会递归监听），onFile 不为 nil 时对遍历到的 .md 文件调用
+func addTree(fsWatcher *fsnotify.Watcher, root string, onFile func(path string)) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			if onFile != nil && strings.HasSuffix(strings.ToLower(info.Name()), ".md") {
+				onFile(path)
+			}
+			return nil
+		}
+		if err := fsWatcher.Add(path); err != nil {
+			return fmt.Errorf("监听目录 %s 失败: %v", path, err)
+		}
+		return nil
+	})
+}
+
+// schedule 文件有变化时重置计时器，等待文件稳定
+func (w *Watcher) schedule(path string, onChange func(path string)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if timer, ok := w.timers[path]; ok {
+		timer.Stop()
+	}
+	w.timers[path] = time.AfterFunc(w.stableDelay, func() {
+		w.checkStable(path, onChange)
+	})
+}
+
+// checkStable 检查文件大小是否稳定，稳定则触发回调，否则继续等待
+func (w *Watcher) checkStable(path string, onChange func(path string)) {
+	info, err := os.Stat(path)
+	if err != nil {
+		// 文件可能已被删除或重命名
+		w.mutex.Lock()
+		delete(w.timers, path)
+		delete(w.sizes, path)
+		w.mutex.Unlock()
+		return
+	}
+
+	w.mutex.Lock()
+	lastSize, seen := w.sizes[path]
+	w.sizes[path] = info.Size()
+	if !seen || lastSize != info.Size() {
+		// 大小仍在变化，再等一个周期确认
+		w.timers[path] = time.AfterFunc(w.stableDelay, func() {
+			w.checkStable(path, onChange)
+		})
+		w.mutex.Unlock()
+		return
+	}
+	delete(w.timers, path)
+	delete(w.sizes, path)
+	w.mutex.Unlock()
+
+	log.Printf("📝 检测到文章变更: %s", filepath.Base(path))
+	onChange(path)
+}
+
+// Close 停止监听
+func (w *Watcher) Close() error {
+	w.mutex.Lock()
+	for _, timer := range w.timers {
+		timer.Stop()
+	}
+	w.mutex.Unlock()
+	return w.fsWatcher.Close()
+}
@@ -0,0 +1,267 @@
+package watermark
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/auto-blog/cover"
+	"github.com/auto-blog/tempfiles"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+	_ "golang.org/x/image/webp"
+)
+
+// Position 水印位置
+type Position string
+
+const (
+	TopLeft     Position = "top-left"
+	TopRight    Position = "top-right"
+	BottomLeft  Position = "bottom-left"
+	BottomRight Position = "bottom-right"
+	Center      Position = "center"
+)
+
+// maxMarkRatio 图片水印最大宽度占原图宽度的比例，超过时等比缩小
+const maxMarkRatio = 0.25
+
+// Options 水印配置，图片水印优先于文字水印
+type Options struct {
+	Text     string   // 水印文字，如昵称或站点地址
+	Image    string   // 水印图片路径（建议使用透明背景的 PNG）
+	Position Position // 水印位置：四角或居中
+	Opacity  float64  // 不透明度（0~1）
+	FontFile string   // 文字水印字体，留空时尝试系统中文字体
+	FontSize float64  // 文字水印字号
+	Color    string   // 文字水印颜色
+	Margin   int      // 水印与图片边缘的距离（像素）
+}
+
+// DefaultOptions 返回默认的水印配置
+func DefaultOptions() Options {
+	return Options{
+		Position: BottomRight,
+		Opacity:  0.5,
+		FontSize: 24,
+		Color:    "#ffffff",
+		Margin:   16,
+	}
+}
+
+// Watermarker 水印处理器：在图片上叠加文字或图片水印并输出到临时文件
+type Watermarker struct {
+	options   Options
+	face      font.Face
+	textColor color.NRGBA
+	mark      image.Image
+	outputDir string
+}
+
+// NewWatermarker 创建水印处理器，未配置的项使用默认值
+func NewWatermarker(options Options) (*Watermarker, error) {
+	defaults := DefaultOptions()
+	if options.Text == "" && options.Image == "" {
+		return nil, fmt.Errorf("未配置水印文字或水印图片")
+	}
+	if options.Position == "" {
+		options.Position = defaults.Position
+	}
+	switch options.Position {
+	case TopLeft, TopRight, BottomLeft, BottomRight, Center:
+	default:
+		return nil, fmt.Errorf("未知的水印位置: %s（可选 top-left/top-right/bottom-left/bottom-right/center）", options.Position)
+	}
+	if options.Opacity <= 0 || options.Opacity > 1 {
+		return nil, fmt.Errorf("水印不透明度应在 0~1 之间: %v", options.Opacity)
+	}
+	if options.FontSize <= 0 {
+		options.FontSize = defaults.FontSize
+	}
+	if options.Color == "" {
+		options.Color = defaults.Color
+	}
+	if options.Margin < 0 {
+		options.Margin = defaults.Margin
+	}
+
+	watermarker := &Watermarker{
+		options:   options,
+		outputDir: filepath.Join(os.TempDir(), "auto-blog-watermark"),
+	}
+
+	if options.Image != "" {
+		mark, err := decodeFile(options.Image)
+		if err != nil {
+			return nil, fmt.Errorf("读取水印图片失败: %v", err)
+		}
+		watermarker.mark = mark
+		return watermarker, nil
+	}
+
+	textColor, err := cover.ParseColor(options.Color)
+	if err != nil {
+		return nil, fmt.Errorf("水印颜色配置错误: %v", err)
+	}
+	watermarker.textColor = color.NRGBA{R: textColor.R, G: textColor.G, B: textColor.B, A: uint8(options.Opacity * 255)}
+	if watermarker.face, err = cover.LoadFace(options.FontFile, options.FontSize); err != nil {
+		return nil, err
+	}
+	return watermarker, nil
+}
+
+// Apply 给图片加水印并返回临时文件路径，相同图片和配置只处理一次。
+// GIF 动图加水印会丢失动画，原样返回；图片小于水印时也原样返回
+func (w *Watermarker) Apply(imagePath string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(imagePath))
+	if ext == ".gif" {
+		return imagePath, nil
+	}
+
+	info, err := os.Stat(imagePath)
+	if err != nil {
+		return imagePath, err
+	}
+	if err := os.MkdirAll(w.outputDir, 0755); err != nil {
+		return imagePath, fmt.Errorf("创建水印目录失败: %v", err)
+	}
+
+	isJPEG := ext == ".jpg" || ext == ".jpeg"
+	outputExt := ".png"
+	if isJPEG {
+		outputExt = ".jpg"
+	}
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d|%d|%+v", imagePath, info.Size(), info.ModTime().UnixNano(), w.options)))
+	outputPath := filepath.Join(w.outputDir, hex.EncodeToString(sum[:8])+outputExt)
+	if _, err := os.Stat(outputPath); err == nil {
+		tempfiles.Register(outputPath)
+		return outputPath, nil
+	}
+
+	source, err := decodeFile(imagePath)
+	if err != nil {
+		return imagePath, fmt.Errorf("解码图片失败: %v", err)
+	}
+	canvas := image.NewRGBA(source.Bounds())
+	draw.Draw(canvas, canvas.Bounds(), source, source.Bounds().Min, draw.Src)
+
+	var drawn bool
+	if w.mark != nil {
+		drawn = w.drawImage(canvas)
+	} else {
+		drawn = w.drawText(canvas)
+	}
+	if !drawn {
+		return imagePath, nil
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return imagePath, fmt.Errorf("创建水印图片失败: %v", err)
+	}
+	defer file.Close()
+
+	if isJPEG {
+		err = jpeg.Encode(file, canvas, &jpeg.Options{Quality: 92})
+	} else {
+		err = png.Encode(file, canvas)
+	}
+	if err != nil {
+		os.Remove(outputPath)
+		return imagePath, fmt.Errorf("写入水印图片失败: %v", err)
+	}
+	tempfiles.Register(outputPath)
+	return outputPath, nil
+}
+
+// drawText 绘制文字水印，图片放不下水印时返回 false
+func (w *Watermarker) drawText(canvas *image.RGBA) bool {
+	metrics := w.face.Metrics()
+	width := font.MeasureString(w.face, w.options.Text).Ceil()
+	height := (metrics.Ascent + metrics.Descent).Ceil()
+
+	origin, ok := w.placement(canvas.Bounds(), width, height)
+	if !ok {
+		return false
+	}
+
+	drawer := &font.Drawer{
+		Dst:  canvas,
+		Src:  image.NewUniform(w.textColor),
+		Face: w.face,
+		Dot:  fixed.P(origin.X, origin.Y+metrics.Ascent.Ceil()),
+	}
+	drawer.DrawString(w.options.Text)
+	return true
+}
+
+// drawImage 绘制图片水印，水印过大时等比缩小到原图宽度的 maxMarkRatio，图片放不下水印时返回 false
+func (w *Watermarker) drawImage(canvas *image.RGBA) bool {
+	mark := w.mark
+	markBounds := mark.Bounds()
+	if maxWidth := int(float64(canvas.Bounds().Dx()) * maxMarkRatio); markBounds.Dx() > maxWidth && maxWidth > 0 {
+		height := markBounds.Dy() * maxWidth / markBounds.Dx()
+		scaled := image.NewRGBA(image.Rect(0, 0, maxWidth, height))
+		xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), mark, markBounds, draw.Over, nil)
+		mark = scaled
+		markBounds = scaled.Bounds()
+	}
+
+	origin, ok := w.placement(canvas.Bounds(), markBounds.Dx(), markBounds.Dy())
+	if !ok {
+		return false
+	}
+
+	target := image.Rectangle{Min: origin, Max: origin.Add(markBounds.Size())}
+	opacity := image.NewUniform(color.Alpha{A: uint8(w.options.Opacity * 255)})
+	draw.DrawMask(canvas, target, mark, markBounds.Min, opacity, image.Point{}, draw.Over)
+	return true
+}
+
+// placement 按配置的位置计算水印左上角坐标，图片放不下水印时返回 false
+func (w *Watermarker) placement(bounds image.Rectangle, width, height int) (image.Point, bool) {
+	margin := w.options.Margin
+	if width+2*margin > bounds.Dx() || height+2*margin > bounds.Dy() {
+		return image.Point{}, false
+	}
+
+	left := bounds.Min.X + margin
+	right := bounds.Max.X - margin - width
+	top := bounds.Min.Y + margin
+	bottom := bounds.Max.Y - margin - height
+
+	switch w.options.Position {
+	case TopLeft:
+		return image.Pt(left, top), true
+	case TopRight:
+		return image.Pt(right, top), true
+	case BottomLeft:
+		return image.Pt(left, bottom), true
+	case Center:
+		return image.Pt(bounds.Min.X+(bounds.Dx()-width)/2, bounds.Min.Y+(bounds.Dy()-height)/2), true
+	default:
+		return image.Pt(right, bottom), true
+	}
+}
+
+// decodeFile 读取并解码图片文件（支持 PNG、JPEG、GIF、WebP）
+func decodeFile(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoded, _, err := image.Decode(file)
+	return decoded, err
+}
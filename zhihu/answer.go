@@ -0,0 +1,83 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+	"github.com/playwright-community/playwright-go"
+)
+
+// questionFor 查找文章配置的问题链接，配置了问题的文章以回答形式发布
+func (p *Publisher) questionFor(art *article.Article) (string, bool) {
+	questionURL, ok := p.options.Answers[filepath.Base(art.Path)]
+	return questionURL, ok && questionURL != ""
+}
+
+// publishAnswer 打开问题页面的回答编辑器并填写正文（回答没有标题）
+func (p *Publisher) publishAnswer(art *article.Article, questionURL string) error {
+	log.Printf("[知乎] 开始以回答形式发布《%s》: %s", art.Title, questionURL)
+
+	if _, err := common.HumanGoto(p.page, questionURL); err != nil {
+		return fmt.Errorf("打开问题页面失败: %v", err)
+	}
+	p.page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+		State: playwright.LoadStateNetworkidle,
+	})
+
+	if err := p.openAnswerEditor(); err != nil {
+		return err
+	}
+	p.answerMode = true
+
+	if err := p.fillContent(art); err != nil {
+		return fmt.Errorf("填写回答正文失败: %v", err)
+	}
+
+	log.Printf("[知乎] ✅ 回答正文填写完成，图片处理完后提交")
+	return nil
+}
+
+// openAnswerEditor 点击"写回答"并等待回答编辑器出现
+func (p *Publisher) openAnswerEditor() error {
+	editorLocator := p.page.Locator(selectors.Get(Name, selectors.AnswerEditor)).First()
+	if visible, _ := editorLocator.IsVisible(); visible {
+		return nil
+	}
+
+	buttonLocator := p.page.Locator(selectors.Get(Name, selectors.AnswerButton)).First()
+	if err := buttonLocator.Click(playwright.LocatorClickOptions{
+		Timeout: common.TimeoutMs(10 * time.Second),
+	}); err != nil {
+		return fmt.Errorf("点击写回答按钮失败: %v", err)
+	}
+
+	if err := common.WaitVisible(editorLocator, 10*time.Second); err != nil {
+		return fmt.Errorf("等待回答编辑器超时: %v", err)
+	}
+	return nil
+}
+
+// submitAnswer 点击"发布回答"并确认回答编辑器已关闭
+func (p *Publisher) submitAnswer() error {
+	submitLocator := p.page.Locator(selectors.Get(Name, selectors.AnswerSubmit)).First()
+	if err := submitLocator.Click(playwright.LocatorClickOptions{
+		Timeout: common.TimeoutMs(10 * time.Second),
+	}); err != nil {
+		return fmt.Errorf("点击发布回答按钮失败: %v", err)
+	}
+
+	// 提交成功后回答编辑器会消失
+	time.Sleep(2 * time.Second)
+	if visible, _ := submitLocator.IsVisible(); visible {
+		return fmt.Errorf("发布回答后编辑器仍未关闭，可能提交失败")
+	}
+
+	log.Printf("[知乎] 🎉 回答已发布: %s", strings.TrimSpace(p.page.URL()))
+	return nil
+}
@@ -0,0 +1,112 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/playwright-community/playwright-go"
+)
+
+// columnSearchTimeout 等待专栏搜索结果加载的最长时间
+const columnSearchTimeout = 10 * time.Second
+
+// joinColumn 在发布设置中选择"发布到专栏"并搜索加入指定专栏。
+// 找不到专栏时恢复为不加入专栏，文章照常发布
+func (p *Publisher) joinColumn(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	// 1. 展开发布设置，选择"发布到专栏"，打开专栏下拉框
+	result, err := p.page.Evaluate(`
+		() => {
+			const isVisible = (el) => el && el.offsetParent !== null;
+			const textOf = (el) => (el.innerText || el.textContent || '').trim();
+			const findByText = (pattern) => Array.from(document.querySelectorAll('label, button, div[role="button"], div[role="radio"], span, li'))
+				.filter(el => isVisible(el) && pattern.test(textOf(el)) && textOf(el).length < 20)
+				.sort((a, b) => textOf(a).length - textOf(b).length)[0];
+
+			if (!findByText(/专栏/)) {
+				const expander = findByText(/^发布设置$/);
+				if (expander) expander.click();
+			}
+
+			const option = findByText(/^(发布到|投稿至|收录到)专栏$/);
+			if (!option) {
+				return { success: false, error: '未找到专栏设置项' };
+			}
+			const radio = option.querySelector('input[type="radio"], input[type="checkbox"], [role="radio"]') || option;
+			if (radio.checked !== true && radio.getAttribute('aria-checked') !== 'true') {
+				radio.click();
+			}
+
+			// 专栏选择框：点击后才会加载专栏列表
+			const trigger = findByText(/^(选择专栏|请选择专栏)$/) ||
+				Array.from(document.querySelectorAll('[role="combobox"], .Select-button, button[class*="Select"]')).find(isVisible);
+			if (trigger) trigger.click();
+			return { success: true };
+		}
+	`)
+	if err != nil {
+		return fmt.Errorf("打开专栏设置失败: %v", err)
+	}
+	if err := evaluateError(result); err != nil {
+		return err
+	}
+
+	// 2. 有搜索框时输入专栏名，搜索结果异步加载
+	search := p.page.Locator(`input[placeholder*="专栏"], input[placeholder*="搜索"]`).First()
+	if visible, _ := search.IsVisible(); visible {
+		if err := search.Fill(name); err != nil {
+			log.Printf("[知乎] ⚠️ 输入专栏名失败: %v", err)
+		}
+	}
+
+	// 3. 等待下拉列表中出现同名专栏并选中
+	deadline := time.Now().Add(columnSearchTimeout)
+	for time.Now().Before(deadline) {
+		selected, err := p.page.Evaluate(`
+			(name) => {
+				const isVisible = (el) => el && el.offsetParent !== null;
+				const textOf = (el) => (el.innerText || el.textContent || '').trim();
+				const item = Array.from(document.querySelectorAll('[role="option"], .Select-option, .Menu-item, li, button'))
+					.filter(el => isVisible(el) && textOf(el) === name)[0];
+				if (!item) return false;
+				item.click();
+				return true;
+			}
+		`, name)
+		if err != nil {
+			return fmt.Errorf("选择专栏失败: %v", err)
+		}
+		if ok, _ := selected.(bool); ok {
+			log.Printf("[知乎] [发布设置] 已加入专栏: %s", name)
+			return nil
+		}
+		p.page.WaitForTimeout(500)
+	}
+
+	// 4. 找不到专栏：收起下拉框并恢复为不发布到专栏
+	p.page.Keyboard().Press("Escape")
+	p.page.GetByText("不发布到专栏", playwright.PageGetByTextOptions{Exact: playwright.Bool(true)}).First().Click(playwright.LocatorClickOptions{
+		Timeout: common.TimeoutMs(2 * time.Second),
+	})
+	return fmt.Errorf("未找到专栏「%s」，不加入专栏", name)
+}
+
+// evaluateError 解析页面脚本返回的 { success, error } 结果
+func evaluateError(result interface{}) error {
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("页面脚本返回了无法识别的结果: %v", result)
+	}
+	if success, _ := resultMap["success"].(bool); !success {
+		errorMsg, _ := resultMap["error"].(string)
+		return fmt.Errorf("%s", errorMsg)
+	}
+	return nil
+}
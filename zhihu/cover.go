@@ -0,0 +1,36 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+)
+
+// UploadCover 通过标题上方的"添加封面"上传控件设置文章封面（回答没有封面，直接跳过）
+func (p *Publisher) UploadCover(imagePath string) error {
+	if p.answerMode {
+		return nil
+	}
+
+	absPath, err := filepath.Abs(imagePath)
+	if err != nil {
+		return fmt.Errorf("获取封面绝对路径失败: %v", err)
+	}
+
+	coverInput := p.page.Locator(selectors.Get(Name, selectors.Cover)).First()
+	if err := common.WaitAttached(coverInput, 5*time.Second); err != nil {
+		return fmt.Errorf("未找到封面上传控件: %v", err)
+	}
+	if err := coverInput.SetInputFiles([]string{absPath}); err != nil {
+		return fmt.Errorf("上传封面失败: %v", err)
+	}
+
+	// 等待封面上传完成
+	time.Sleep(3 * time.Second)
+	log.Printf("[知乎] 🖼️ 封面已上传: %s", filepath.Base(absPath))
+	return nil
+}
@@ -0,0 +1,105 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+	"github.com/auto-blog/tempfiles"
+)
+
+// ensureMarkdownParsed 确认粘贴的 Markdown 已被知乎解析（标题渲染成标题、代码块渲染成代码块）。
+// 知乎有时不弹出解析提示，内容以纯文本呈现：先主动点击"解析"入口，仍未解析时清空编辑器，
+// 把 content 写入临时 .md 文件后用知乎的"导入文档"功能导入
+func (p *Publisher) ensureMarkdownParsed(art *article.Article, content string) error {
+	stats := art.Stats()
+	render, err := common.InspectMarkdownRender(p.page, p.editorSelector())
+	if err != nil {
+		return err
+	}
+	if !render.Found || render.Parsed(stats) {
+		return nil
+	}
+	log.Printf("[知乎] ⚠️ 粘贴的 Markdown 未被解析（%s），尝试触发解析", render.Describe(stats))
+
+	if err := p.waitAndClickMarkdownParseButtonNew(); err != nil {
+		log.Printf("[知乎] ⚠️ 未能触发 Markdown 解析: %v", err)
+	} else if render, err = common.InspectMarkdownRender(p.page, p.editorSelector()); err == nil && render.Parsed(stats) {
+		log.Printf("[知乎] ✅ 已触发 Markdown 解析（%s）", render.Describe(stats))
+		return nil
+	}
+
+	// 回答编辑器没有导入功能
+	if p.answerMode {
+		return fmt.Errorf("Markdown 仍未被解析（%s），回答编辑器不支持导入", render.Describe(stats))
+	}
+	log.Printf("[知乎] 📥 改用知乎的文档导入功能导入 Markdown")
+	if err := p.importMarkdown(content); err != nil {
+		return fmt.Errorf("导入 Markdown 失败: %v", err)
+	}
+	if render, err = common.InspectMarkdownRender(p.page, p.editorSelector()); err != nil {
+		return err
+	}
+	if !render.Parsed(stats) {
+		return fmt.Errorf("导入后 Markdown 仍未被解析（%s）", render.Describe(stats))
+	}
+	log.Printf("[知乎] ✅ Markdown 导入完成（%s）", render.Describe(stats))
+	return nil
+}
+
+// importMarkdown 清空编辑器后通过工具栏的"导入 → 导入文档"导入 Markdown 文件
+func (p *Publisher) importMarkdown(content string) error {
+	file, err := os.CreateTemp("", "auto-blog-*.md")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %v", err)
+	}
+	tempfiles.Register(file.Name())
+	_, err = file.WriteString(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("写入临时文件失败: %v", err)
+	}
+
+	if err := common.ClearEditor(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("清空编辑器失败: %v", err)
+	}
+
+	importButton := p.page.Locator(selectors.Get(Name, selectors.ImportButton)).First()
+	if err := common.WaitVisible(importButton, 5*time.Second); err != nil {
+		return fmt.Errorf("未找到导入按钮: %v", err)
+	}
+	if err := common.HumanClick(importButton); err != nil {
+		return fmt.Errorf("点击导入按钮失败: %v", err)
+	}
+
+	importOption := p.page.Locator(selectors.Get(Name, selectors.ImportOption)).First()
+	if err := common.WaitVisible(importOption, 5*time.Second); err != nil {
+		return fmt.Errorf("未找到导入文档选项: %v", err)
+	}
+	fileChooser, err := p.page.ExpectFileChooser(func() error {
+		return common.HumanClick(importOption)
+	})
+	if err != nil {
+		return fmt.Errorf("文件选择器失败: %v", err)
+	}
+	if err := fileChooser.SetFiles([]string{file.Name()}); err != nil {
+		return fmt.Errorf("选择文件失败: %v", err)
+	}
+
+	// 导入较长的文章需要一些时间，等待编辑器出现内容
+	deadline := time.Now().Add(common.Timeout(15 * time.Second))
+	for time.Now().Before(deadline) {
+		time.Sleep(time.Second)
+		if text, err := common.EditorText(p.page, p.editorSelector()); err == nil && len(text) > 0 {
+			time.Sleep(time.Second)
+			return nil
+		}
+	}
+	return fmt.Errorf("等待导入内容超时")
+}
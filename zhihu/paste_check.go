@@ -0,0 +1,120 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/auto-blog/common"
+)
+
+const (
+	pasteCompleteRatio = 0.7 // 编辑器内容达到期望长度的比例视为粘贴完整（Markdown 标记解析后会变短）
+	pasteRetryAttempts = 2   // 粘贴不完整时的重试次数，仍不完整则降级为键盘输入
+	typeChunkSize      = 200 // 键盘输入时每段的最大字符数
+)
+
+// expectedContentLength 计算内容进入编辑器后的期望长度。
+// 编辑器 textContent 不包含换行，且按 UTF-16 计数，这里按字符数统计并忽略换行
+func expectedContentLength(content string) int {
+	count := 0
+	for _, r := range content {
+		if r == '\n' || r == '\r' {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// checkPasteCompleteness 对比编辑器当前内容长度与期望长度，返回实际长度和完整度
+func (p *Publisher) checkPasteCompleteness(expected int) (int, float64, error) {
+	actual, err := p.getCurrentContentLength()
+	if err != nil {
+		return 0, 0, err
+	}
+	if expected == 0 {
+		return actual, 1, nil
+	}
+	return actual, float64(actual) / float64(expected), nil
+}
+
+// ensureContentComplete 校验粘贴结果，不完整时调用 retry 重新粘贴，
+// 多次重试仍不完整则清空编辑器并分段键盘输入补齐
+func (p *Publisher) ensureContentComplete(content string, retry func() error) error {
+	expected := expectedContentLength(content)
+
+	for attempt := 0; ; attempt++ {
+		time.Sleep(1000 * time.Millisecond)
+
+		actual, ratio, err := p.checkPasteCompleteness(expected)
+		if err != nil {
+			log.Printf("[知乎] ⚠️ 无法校验粘贴结果: %v", err)
+			return nil
+		}
+		if ratio >= pasteCompleteRatio {
+			log.Printf("[知乎] ✅ 内容校验通过，长度: %d (期望: %d)，完整度: %.1f%%", actual, expected, ratio*100)
+			return nil
+		}
+
+		log.Printf("[知乎] ⚠️ 内容不完整，长度: %d (期望: %d)，完整度: %.1f%%", actual, expected, ratio*100)
+		if attempt >= pasteRetryAttempts || retry == nil {
+			break
+		}
+
+		log.Printf("[知乎] 🔄 第 %d 次重新粘贴...", attempt+1)
+		if err := retry(); err != nil {
+			log.Printf("[知乎] ⚠️ 重新粘贴失败: %v", err)
+		}
+	}
+
+	log.Printf("[知乎] ⌨️ 粘贴多次仍不完整，降级为分段键盘输入")
+	if err := p.typeContentInChunks(content); err != nil {
+		return fmt.Errorf("键盘输入补齐失败: %v", err)
+	}
+
+	actual, ratio, err := p.checkPasteCompleteness(expected)
+	if err != nil {
+		log.Printf("[知乎] ⚠️ 无法校验键盘输入结果: %v", err)
+		return nil
+	}
+	if ratio < pasteCompleteRatio {
+		return fmt.Errorf("内容仍不完整，长度: %d (期望: %d)", actual, expected)
+	}
+	log.Printf("[知乎] ✅ 键盘输入补齐完成，完整度: %.1f%%", ratio*100)
+	return nil
+}
+
+// typeContentInChunks 清空编辑器后逐行分段键盘输入，避免一次输入过长内容被截断
+func (p *Publisher) typeContentInChunks(content string) error {
+	// 清空已粘贴的残缺内容
+	if err := common.ClearEditor(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("清空编辑器失败: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRightFunc(content, unicode.IsSpace), "\n")
+	for i, line := range lines {
+		for len(line) > 0 {
+			chunk := line
+			if utf8.RuneCountInString(chunk) > typeChunkSize {
+				chunk = string([]rune(chunk)[:typeChunkSize])
+			}
+			if err := p.page.Keyboard().Type(chunk); err != nil {
+				return fmt.Errorf("第 %d 行键盘输入失败: %v", i+1, err)
+			}
+			line = line[len(chunk):]
+			time.Sleep(50 * time.Millisecond)
+		}
+		if i < len(lines)-1 {
+			if err := p.page.Keyboard().Press("Enter"); err != nil {
+				return fmt.Errorf("第 %d 行换行失败: %v", i+1, err)
+			}
+		}
+	}
+
+	time.Sleep(1000 * time.Millisecond)
+	return nil
+}
@@ -0,0 +1,163 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/article"
+	"github.com/auto-blog/common"
+)
+
+// defaultPasteChunkSize 默认的分块粘贴阈值（字符数），超过时按段落分块粘贴
+const defaultPasteChunkSize = 10000
+
+// pasteChunkSize 分块粘贴的阈值和每块的目标字符数，未配置时使用默认值，负数表示不分块
+func (p *Publisher) pasteChunkSize() int {
+	if p.options.PasteChunkSize == 0 {
+		return defaultPasteChunkSize
+	}
+	return p.options.PasteChunkSize
+}
+
+// shouldPasteInChunks 判断内容是否超过分块粘贴的阈值
+func (p *Publisher) shouldPasteInChunks(content string) bool {
+	size := p.pasteChunkSize()
+	return size > 0 && expectedContentLength(content) > size
+}
+
+// splitPasteChunks 把内容按段落切成每块约 size 个字符的若干块。只在代码块之外的空行处切分，
+// 图片占位符和代码块不会被块边界拆开；没有合适的切分点时单块可能超过 size
+func splitPasteChunks(content string, size int) []string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	var chunks []string
+	var current []string
+	length := 0
+	var opening article.CodeFence
+	inCodeBlock := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence, ok := article.ParseCodeFence(trimmed); ok {
+			if !inCodeBlock {
+				inCodeBlock = true
+				opening = fence
+			} else if fence.Closes(opening) {
+				inCodeBlock = false
+			}
+		}
+
+		current = append(current, line)
+		length += expectedContentLength(line)
+		if !inCodeBlock && trimmed == "" && length >= size {
+			chunks = append(chunks, strings.Join(current, "\n")+"\n")
+			current = nil
+			length = 0
+		}
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, "\n")+"\n")
+	}
+	return chunks
+}
+
+// pasteInChunks 把超长内容分块，逐块经临时页复制后追加粘贴到编辑器末尾，
+// 每块粘贴后校验编辑器内容长度与已粘贴块的累计期望长度，避免一次粘贴过长内容丢失后半部分或卡死
+func (p *Publisher) pasteInChunks(content string) error {
+	chunks := splitPasteChunks(content, p.pasteChunkSize())
+	log.Printf("[知乎] ✂️ 正文共 %d 字，超过 %d 字，分 %d 块粘贴", expectedContentLength(content), p.pasteChunkSize(), len(chunks))
+
+	expected := 0
+	for i, chunk := range chunks {
+		expected += expectedContentLength(chunk)
+		if err := p.pasteChunk(chunk, i == 0); err != nil {
+			return fmt.Errorf("第 %d/%d 块粘贴失败: %v", i+1, len(chunks), err)
+		}
+
+		time.Sleep(1000 * time.Millisecond)
+		actual, ratio, err := p.checkPasteCompleteness(expected)
+		if err != nil {
+			log.Printf("[知乎] ⚠️ 无法校验第 %d/%d 块的粘贴结果: %v", i+1, len(chunks), err)
+			continue
+		}
+		if ratio < pasteCompleteRatio {
+			return fmt.Errorf("第 %d/%d 块粘贴后内容不完整，长度: %d (期望: %d)", i+1, len(chunks), actual, expected)
+		}
+		log.Printf("[知乎] ✅ 第 %d/%d 块已粘贴，累计长度: %d (期望: %d)", i+1, len(chunks), actual, expected)
+	}
+	return nil
+}
+
+// pasteChunk 经临时页复制一块内容，第一块替换编辑器中的已有内容，之后的块另起一段追加到末尾
+func (p *Publisher) pasteChunk(chunk string, first bool) error {
+	tempPage, err := p.createAndLoadTempPage(chunk)
+	if err != nil {
+		return fmt.Errorf("创建临时页面失败: %v", err)
+	}
+	defer common.CloseTempPage(tempPage)
+
+	time.Sleep(1 * time.Second)
+	if err := p.selectAndCopyContent(tempPage); err != nil {
+		return fmt.Errorf("复制内容失败: %v", err)
+	}
+	common.CloseTempPage(tempPage)
+
+	if first {
+		return p.pasteToZhihuEditor()
+	}
+	return p.appendToZhihuEditor()
+}
+
+// appendToZhihuEditor 把光标移到编辑器末尾，另起一段后粘贴剪贴板内容
+func (p *Publisher) appendToZhihuEditor() error {
+	if err := p.page.BringToFront(); err != nil {
+		log.Printf("[知乎] ⚠️ 切换到知乎页面失败: %v", err)
+	}
+	if err := p.ensureCursorAtEnd(); err != nil {
+		return fmt.Errorf("定位光标失败: %v", err)
+	}
+	if err := common.EnsureEditorFocus(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("粘贴前确认焦点失败: %v", err)
+	}
+	if err := p.page.Keyboard().Press("Enter"); err != nil {
+		return fmt.Errorf("换行失败: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	if err := p.page.Keyboard().Press("Meta+v"); err != nil {
+		if err := p.page.Keyboard().Press("Control+v"); err != nil {
+			return fmt.Errorf("粘贴失败: %v", err)
+		}
+	}
+	time.Sleep(2 * time.Second)
+
+	if err := p.handleMarkdownParseDialog(); err != nil {
+		log.Printf("[知乎] ⚠️ 处理Markdown解析对话框失败: %v", err)
+	}
+	return nil
+}
+
+// fillContentInChunks 分块粘贴正文，分块失败时清空编辑器并降级为分段键盘输入
+func (p *Publisher) fillContentInChunks(content string) error {
+	err := p.pasteInChunks(content)
+	if err == nil {
+		return nil
+	}
+	log.Printf("[知乎] ⚠️ 分块粘贴失败: %v，降级为分段键盘输入", err)
+	if err := p.typeContentInChunks(content); err != nil {
+		return fmt.Errorf("键盘输入补齐失败: %v", err)
+	}
+
+	expected := expectedContentLength(content)
+	actual, ratio, err := p.checkPasteCompleteness(expected)
+	if err != nil {
+		log.Printf("[知乎] ⚠️ 无法校验键盘输入结果: %v", err)
+		return nil
+	}
+	if ratio < pasteCompleteRatio {
+		return fmt.Errorf("内容仍不完整，长度: %d (期望: %d)", actual, expected)
+	}
+	log.Printf("[知乎] ✅ 键盘输入补齐完成，完整度: %.1f%%", ratio*100)
+	return nil
+}
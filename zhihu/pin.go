@@ -0,0 +1,74 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
+	"github.com/playwright-community/playwright-go"
+)
+
+// pinPageURL 发想法的入口页面（知乎首页）
+const pinPageURL = "https://www.zhihu.com/"
+
+// DefaultPinTemplate 默认的想法文案模板，{title} 和 {url} 分别替换为文章标题和链接
+const DefaultPinTemplate = "新文章《{title}》已发布，欢迎阅读交流：{url}"
+
+// articleURLRegex 专栏文章页地址（分组为文章 ID），定时发布时点击发布后不会跳转到文章页
+var articleURLRegex = regexp.MustCompile(`zhuanlan\.zhihu\.com/p/(\d+)`)
+
+// pinText 按模板生成想法文案，模板中没有 {url} 时把链接附在末尾
+func pinText(template, title, url string) string {
+	if strings.TrimSpace(template) == "" {
+		template = DefaultPinTemplate
+	}
+	if !strings.Contains(template, "{url}") {
+		template += " {url}"
+	}
+	return strings.NewReplacer("{title}", title, "{url}", url).Replace(template)
+}
+
+// sharePin 在新标签页打开知乎首页的"发想法"，填入带文章链接的文案并发布
+func (p *Publisher) sharePin(url string) error {
+	page, err := p.page.Context().NewPage()
+	if err != nil {
+		return fmt.Errorf("打开新标签页失败: %v", err)
+	}
+	defer common.CloseTempPage(page)
+
+	if _, err := common.HumanGoto(page, pinPageURL); err != nil {
+		return fmt.Errorf("打开知乎首页失败: %v", err)
+	}
+	if common.WaitForPageText(page, 10*time.Second, "发想法") == "" {
+		return fmt.Errorf("未找到发想法入口")
+	}
+	if err := common.ClickButtonByText(page, "", "发想法"); err != nil {
+		return err
+	}
+
+	editor := page.Locator(selectors.Get(Name, selectors.PinEditor)).First()
+	if err := common.WaitVisible(editor, 10*time.Second); err != nil {
+		return fmt.Errorf("想法编辑框未出现: %v", err)
+	}
+	if err := common.HumanClick(editor); err != nil {
+		return fmt.Errorf("点击想法编辑框失败: %v", err)
+	}
+	if err := page.Keyboard().InsertText(pinText(p.options.PinTemplate, p.title, url)); err != nil {
+		return fmt.Errorf("填写想法文案失败: %v", err)
+	}
+	// 等待知乎把链接解析为卡片
+	time.Sleep(2 * time.Second)
+
+	if err := common.ClickButtonByText(page, ".Modal", "发布"); err != nil {
+		return err
+	}
+	if err := common.WaitForElement(editor, playwright.WaitForSelectorStateHidden, 10*time.Second); err != nil {
+		return fmt.Errorf("点击发布后想法编辑框未关闭，可能发布失败: %v", err)
+	}
+	log.Printf("[知乎] 💬 已发布想法: %s", url)
+	return nil
+}
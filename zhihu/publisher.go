@@ -3,20 +3,37 @@ package zhihu
 import (
 	"encoding/base64"
 	"fmt"
+	"html"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/auto-blog/article"
 	"github.com/auto-blog/common"
+	"github.com/auto-blog/selectors"
 	"github.com/playwright-community/playwright-go"
 )
 
+// Options 知乎发布设置
+type Options struct {
+	EnableReward   bool              // 是否开启赞赏
+	Column         string            // 文章加入的专栏名称，为空时不加入专栏
+	FillTitle      bool              // 用 Fill 一次性填写标题（快），默认逐字键盘输入（拟人但慢）
+	Answers        map[string]string // 以回答形式发布的文章：文章文件名 -> 问题链接
+	SharePin       bool              // 文章发布成功后发一条带文章链接的想法
+	PinTemplate    string            // 想法文案模板，{title} 和 {url} 分别替换为文章标题和链接，为空时使用默认模板
+	PasteChunkSize int               // 正文超过该字符数时按段落分块粘贴，0 使用默认值，负数不分块
+}
+
 // Publisher 知乎文章发布器
 type Publisher struct {
-	page playwright.Page
+	page       playwright.Page
+	options    Options
+	answerMode bool   // 当前文章以回答形式发布
+	title      string // 当前文章的标题（发想法时使用）
 }
 
 // NewPublisher 创建知乎文章发布器
@@ -26,9 +43,39 @@ func NewPublisher(page playwright.Page) *Publisher {
 	}
 }
 
+// NewPublisherWithOptions 创建带发布设置的知乎文章发布器
+func NewPublisherWithOptions(page playwright.Page, options Options) *Publisher {
+	return &Publisher{
+		page:    page,
+		options: options,
+	}
+}
+
+// editorSelector 当前使用的正文编辑器选择器（回答模式使用回答编辑器）
+func (p *Publisher) editorSelector() string {
+	if p.answerMode {
+		return selectors.Get(Name, selectors.AnswerEditor)
+	}
+	return selectors.Get(Name, selectors.Editor)
+}
+
 // PublishArticle 发布文章到知乎
 func (p *Publisher) PublishArticle(art *article.Article) error {
+	p.answerMode = false
+	// 知乎解析 markdown 时会把代码块的额外参数当成语言名的一部分，只保留语言名
+	art = art.WithoutCodeParams()
+	// 知乎的 Markdown 导入只识别单行的 $$...$$ 公式，行内公式和多行公式块统一改写
+	art = art.WithDoubleDollarMath()
+	// 知乎不支持提示框语法（> [!NOTE]、:::tip），改写为普通引用块
+	art = art.WithQuotedAdmonitions()
+	if questionURL, ok := p.questionFor(art); ok {
+		return p.publishAnswer(art, questionURL)
+	}
+
 	log.Printf("开始发布文章到知乎: %s", art.Title)
+	p.title = art.Title
+	// 知乎文章支持 @用户，填写正文后再逐个触发提及选择；#话题# 保留为纯文本
+	art, mentions := art.WithMentionPlaceholders(article.MentionUser)
 
 	// 1. 填写标题
 	if err := p.fillTitle(art.Title); err != nil {
@@ -37,34 +84,120 @@ func (p *Publisher) PublishArticle(art *article.Article) error {
 		log.Printf("✅ 标题填写完成")
 	}
 
-	// 2. 填写正文
+	// 2. 填写正文，再把提及占位符替换为知乎的 @用户
 	if err := p.fillContent(art); err != nil {
 		log.Printf("⚠️ 正文填写遇到问题: %v", err)
 	} else {
 		log.Printf("✅ 正文填写完成")
+		common.ApplyMentions(p.page, Name, p.editorSelector(), selectors.Get(Name, selectors.MentionOption), mentions)
+	}
+
+	// 3. 发布设置：原创声明（frontmatter original）和赞赏开关（配置）
+	if err := p.applyPublishSettings(art.Meta.Original, p.options.EnableReward); err != nil {
+		log.Printf("[知乎] ⚠️ 发布设置遇到问题: %v", err)
+	}
+
+	// 4. 加入配置的专栏，找不到时不加入专栏
+	if err := p.joinColumn(p.options.Column); err != nil {
+		log.Printf("[知乎] ⚠️ 加入专栏遇到问题: %v", err)
 	}
 
 	log.Printf("🎉 文章《%s》发布操作完成", art.Title)
 	return nil
 }
 
+// applyPublishSettings 展开发布设置并设置原创声明和赞赏开关
+func (p *Publisher) applyPublishSettings(original, enableReward bool) error {
+	result, err := p.page.Evaluate(`
+		(settings) => {
+			const logs = [];
+			const isVisible = (el) => el && el.offsetParent !== null;
+			const textOf = (el) => (el.innerText || el.textContent || '').trim();
+
+			// 1. 展开发布设置（只点击"发布设置"，不点击真正的"发布"按钮）
+			const expander = Array.from(document.querySelectorAll('button, div[role="button"], span'))
+				.find(el => isVisible(el) && textOf(el) === '发布设置');
+			if (expander) {
+				expander.click();
+				logs.push('已展开发布设置');
+			}
+
+			// 判断开关/复选框当前是否选中
+			const isChecked = (el) => {
+				if (el.matches('input[type="checkbox"]')) return el.checked;
+				if (el.getAttribute('aria-checked')) return el.getAttribute('aria-checked') === 'true';
+				return /checked|is-active|Switch--on/i.test(el.className || '');
+			};
+
+			// 在包含指定文字的设置项里找到开关并设置为目标状态
+			const setToggle = (keyword, target) => {
+				const rows = Array.from(document.querySelectorAll('label, div, li'))
+					.filter(el => isVisible(el) && textOf(el).includes(keyword) && textOf(el).length < 60);
+				for (const row of rows) {
+					const toggle = row.querySelector('input[type="checkbox"], [role="switch"], [role="checkbox"], .Switch, button[class*="Switch"]');
+					if (!toggle) continue;
+					if (isChecked(toggle) !== target) {
+						toggle.click();
+						logs.push(keyword + ' -> ' + (target ? '开启' : '关闭'));
+					} else {
+						logs.push(keyword + ' 已是' + (target ? '开启' : '关闭') + '状态');
+					}
+					return true;
+				}
+				logs.push('未找到设置项: ' + keyword);
+				return false;
+			};
+
+			// 2. 原创声明：只在需要声明时勾选，避免误改用户已有选择
+			if (settings.original) {
+				setToggle('原创', true);
+			}
+
+			// 3. 赞赏开关
+			setToggle('赞赏', settings.enableReward);
+
+			return logs;
+		}
+	`, map[string]interface{}{
+		"original":     original,
+		"enableReward": enableReward,
+	})
+	if err != nil {
+		return fmt.Errorf("设置发布选项失败: %v", err)
+	}
+
+	if logs, ok := result.([]interface{}); ok {
+		for _, item := range logs {
+			log.Printf("[知乎] [发布设置] %v", item)
+		}
+	}
+	return nil
+}
+
 // fillTitle 填写文章标题
 func (p *Publisher) fillTitle(title string) error {
 	log.Printf("[知乎] 开始填写标题: %s", title)
 
 	// 等待标题输入框出现并可见
-	titleLocator := p.page.Locator("textarea.Input")
+	titleLocator := p.page.Locator(selectors.Get(Name, selectors.Title))
 
 	// 等待元素可见
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000), // 10秒超时
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(titleLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待标题输入框超时: %v", err)
 	}
 
-	// 点击标题输入框，然后用键盘输入，不要用Fill
-	if err := titleLocator.Click(); err != nil {
+	// 配置了 Fill 时先直接填写，标题框内容与期望不一致再降级为键盘输入
+	if p.options.FillTitle {
+		err := p.fillTitleDirectly(titleLocator, title)
+		if err == nil {
+			log.Printf("[知乎] ✅ 标题填写完成: %s", title)
+			return nil
+		}
+		log.Printf("[知乎] ⚠️ Fill 填写标题失败，改用键盘输入: %v", err)
+	}
+
+	// 点击标题输入框，然后用键盘输入（默认方式，Fill 偶尔不会触发编辑器的输入事件）
+	if err := common.HumanClick(titleLocator); err != nil {
 		return fmt.Errorf("点击标题输入框失败: %v", err)
 	}
 
@@ -76,8 +209,8 @@ func (p *Publisher) fillTitle(title string) error {
 		return fmt.Errorf("选择标题内容失败: %v", err)
 	}
 
-	// 键盘输入标题
-	if err := p.page.Keyboard().Type(title); err != nil {
+	// 键盘逐字输入标题，字间隔随机抖动
+	if err := common.HumanType(p.page, title); err != nil {
 		return fmt.Errorf("输入标题失败: %v", err)
 	}
 
@@ -89,6 +222,26 @@ func (p *Publisher) fillTitle(title string) error {
 	return nil
 }
 
+// fillTitleDirectly 用 Fill 填写标题，并校验标题框的实际内容
+func (p *Publisher) fillTitleDirectly(titleLocator playwright.Locator, title string) error {
+	if err := titleLocator.Fill(title); err != nil {
+		return err
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	// 标题框是 textarea 时读取输入值，是可编辑 div 时读取文本
+	actual, err := titleLocator.InputValue()
+	if err != nil {
+		if actual, err = titleLocator.InnerText(); err != nil {
+			return fmt.Errorf("读取标题框内容失败: %v", err)
+		}
+	}
+	if strings.TrimSpace(actual) != strings.TrimSpace(title) {
+		return fmt.Errorf("标题框内容与期望不一致: %q", actual)
+	}
+	return nil
+}
+
 // fillContentWithRichText 实验性方法：直接粘贴富文本（HTML + 图片）
 func (p *Publisher) fillContentWithRichText(art *article.Article) error {
 	log.Printf("[知乎] 🧪 实验：使用富文本方式填写内容")
@@ -100,7 +253,7 @@ func (p *Publisher) fillContentWithRichText(art *article.Article) error {
 	}
 	
 	// 获取编辑器元素
-	editableLocator := p.page.Locator("div.Editable-content")
+	editableLocator := p.page.Locator(p.editorSelector())
 	if err := editableLocator.Click(); err != nil {
 		return fmt.Errorf("点击编辑器失败: %v", err)
 	}
@@ -108,13 +261,13 @@ func (p *Publisher) fillContentWithRichText(art *article.Article) error {
 	
 	// 使用JavaScript直接插入富文本内容到编辑器（不使用剪贴板）
 	result, err := p.page.Evaluate(fmt.Sprintf(`
-		(function() {
+		(function(selector) {
 			try {
 				const htmlContent = %q;
 				console.log('准备直接插入富文本内容，长度:', htmlContent.length);
 				
 				// 找到知乎编辑器
-				const editor = document.querySelector('div.Editable-content');
+				const editor = document.querySelector(selector);
 				if (!editor) {
 					return { success: false, error: '找不到编辑器元素' };
 				}
@@ -138,8 +291,8 @@ func (p *Publisher) fillContentWithRichText(art *article.Article) error {
 				console.error('直接插入内容失败:', e);
 				return { success: false, error: e.message };
 			}
-		})()
-	`, richContent))
+		})
+	`, richContent), p.editorSelector())
 	
 	if err != nil {
 		return fmt.Errorf("JavaScript富文本粘贴失败: %v", err)
@@ -162,7 +315,7 @@ func (p *Publisher) fillContentWithMixedMode(art *article.Article) error {
 	log.Printf("[知乎] 🧪 实验：混合模式（markdown文本 + HTML图片）")
 	
 	// 获取编辑器元素并设置焦点
-	editableLocator := p.page.Locator("div.Editable-content")
+	editableLocator := p.page.Locator(p.editorSelector())
 	if err := editableLocator.Click(); err != nil {
 		return fmt.Errorf("点击编辑器失败: %v", err)
 	}
@@ -214,13 +367,9 @@ func (p *Publisher) fillContentWithMixedMode(art *article.Article) error {
 
 	// 切换回知乎页面并粘贴
 	log.Printf("[知乎] 切换回知乎页面...")
-	if err := p.page.BringToFront(); err != nil {
-		log.Printf("[知乎] ⚠️ 切换页面失败: %v", err)
-	}
-
-	// 重新点击编辑器确保焦点
-	if err := editableLocator.Click(); err != nil {
-		log.Printf("[知乎] ⚠️ 重新点击编辑器失败: %v", err)
+	// 确认知乎页面在前台且编辑器获得焦点，避免粘贴到其它平台的页面
+	if err := common.EnsureEditorFocus(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("粘贴前确认焦点失败: %v", err)
 	}
 	time.Sleep(500 * time.Millisecond)
 
@@ -290,50 +439,48 @@ func (p *Publisher) prepareMixedContent(art *article.Article) (string, error) {
 `)
 
 	// 添加标题
-	htmlBuilder.WriteString(fmt.Sprintf("<h1>%s</h1>\n", art.Title))
+	htmlBuilder.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(art.Title)))
+
+	// 嵌入图片：读取图片并转换为base64的img标签，读取失败时保留markdown格式
+	imageHTML := func(index int, img article.Image) string {
+		imageData, err := os.ReadFile(img.AbsolutePath)
+		if err != nil {
+			log.Printf("[知乎] ⚠️ 读取图片失败: %s, %v", img.AbsolutePath, err)
+			return fmt.Sprintf("![%s](%s)", html.EscapeString(img.AltText), html.EscapeString(img.AbsolutePath))
+		}
+
+		// 检测图片格式
+		var mimeType string
+		if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".png") {
+			mimeType = "image/png"
+		} else if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpg") ||
+			strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpeg") {
+			mimeType = "image/jpeg"
+		} else {
+			mimeType = "image/png"
+		}
+
+		base64Data := base64.StdEncoding.EncodeToString(imageData)
+		dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
+		log.Printf("[知乎] 🖼️ 混合内容中嵌入图片: %s (%d bytes)", img.AltText, len(imageData))
+		return fmt.Sprintf(`<img src="%s" alt="%s"%s />`, dataURL, html.EscapeString(img.AltText), img.SizeAttributes())
+	}
 
 	// 处理内容
 	for i, line := range art.Content {
-		// 检查是否是图片行
-		isImageLine := false
-		for _, img := range art.Images {
-			if img.LineIndex == i {
-				// 读取图片并转换为base64
-				imageData, err := os.ReadFile(img.AbsolutePath)
-				if err != nil {
-					log.Printf("[知乎] ⚠️ 读取图片失败: %s, %v", img.AbsolutePath, err)
-					// 如果读取失败，保留markdown格式
-					htmlBuilder.WriteString(fmt.Sprintf("<p>![%s](%s)</p>\n", img.AltText, img.AbsolutePath))
-				} else {
-					// 检测图片格式
-					var mimeType string
-					if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".png") {
-						mimeType = "image/png"
-					} else if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpg") || 
-							strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpeg") {
-						mimeType = "image/jpeg"
-					} else {
-						mimeType = "image/png"
-					}
-					
-					// 转换为base64并生成img标签
-					base64Data := base64.StdEncoding.EncodeToString(imageData)
-					dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
-					
-					htmlBuilder.WriteString(fmt.Sprintf(`<img src="%s" alt="%s" />`, dataURL, img.AltText))
-					htmlBuilder.WriteString("\n")
-					
-					log.Printf("[知乎] 🖼️ 混合内容中嵌入图片: %s (%d bytes)", img.AltText, len(imageData))
-				}
-				isImageLine = true
-				break
+		// 只有图片的行每张图片独立输出
+		if art.IsImageOnlyLine(i) {
+			for _, index := range art.ImagesOnLine(i) {
+				htmlBuilder.WriteString(imageHTML(index, art.Images[index]))
+				htmlBuilder.WriteString("\n")
 			}
+			continue
 		}
-		
-		if !isImageLine && strings.TrimSpace(line) != "" {
-			// 普通文本行，保持原始markdown格式
+
+		if strings.TrimSpace(line) != "" {
+			// 普通文本行，保持原始markdown格式（转义后作为普通文本显示），行内图片保持在文字中的位置
 			htmlBuilder.WriteString("<p>")
-			htmlBuilder.WriteString(line)
+			htmlBuilder.WriteString(art.RenderLine(i, html.EscapeString, imageHTML))
 			htmlBuilder.WriteString("</p>\n")
 		}
 	}
@@ -355,7 +502,7 @@ func (p *Publisher) fillContent(art *article.Article) error {
 	// 使用统一的富文本处理器
 	config := common.RichContentConfig{
 		PlatformName:        "知乎",
-		EditorSelector:      "div.Editable-content",
+		EditorSelector:      p.editorSelector(),
 		TitleSelector:       "",                        // 标题已在fillTitle中处理
 		UseMarkdownMode:     true,                      // 知乎需要markdown解析
 		ParseButtonCheck:    "",
@@ -366,8 +513,73 @@ func (p *Publisher) fillContent(art *article.Article) error {
 	handler := common.NewRichContentHandler(p.page, config)
 	
 	// 在混合模式下，只填写带占位符的内容，不进行图片替换
-	// 图片替换将在统一的串行替换阶段进行
-	return handler.FillContent(art)
+	// 图片替换将在统一的串行替换阶段进行；超长内容按段落分块粘贴
+	content := handler.PrepareMarkdownWithPlaceholders(art)
+	if p.shouldPasteInChunks(content) {
+		if err := p.fillContentInChunks(content); err != nil {
+			return err
+		}
+	} else if err := handler.FillContent(art); err != nil {
+		return err
+	}
+
+	// 知乎有时不识别粘贴的 Markdown（不弹解析提示），检查标题等是否渲染，未解析时主动触发解析或改用导入
+	if err := p.ensureMarkdownParsed(art, content); err != nil {
+		log.Printf("[知乎] ⚠️ %v", err)
+	}
+
+	// 粘贴的富文本可能带有临时页的字体、颜色等内联样式，清理后再保存草稿
+	if err := p.cleanPastedStyles(); err != nil {
+		log.Printf("[知乎] ⚠️ %v", err)
+	}
+
+	// JS 写入的内容可能不触发知乎的自动保存，主动触发一次
+	if err := p.triggerDraftSave(); err != nil {
+		log.Printf("[知乎] ⚠️ 触发草稿保存失败: %v", err)
+	}
+	return nil
+}
+
+// triggerDraftSave 模拟一次真实输入并让编辑器失焦，促使知乎把内容存入草稿
+func (p *Publisher) triggerDraftSave() error {
+	if err := p.ensureCursorAtEnd(); err != nil {
+		return fmt.Errorf("定位光标失败: %v", err)
+	}
+
+	// 敲一个空格再删掉，产生真实的输入事件
+	if err := p.page.Keyboard().Type(" "); err != nil {
+		return fmt.Errorf("输入空格失败: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if err := p.page.Keyboard().Press("Backspace"); err != nil {
+		return fmt.Errorf("删除空格失败: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	// 让编辑器失焦
+	if _, err := p.page.Evaluate(`
+		(function(selector) {
+			const editor = document.querySelector(selector);
+			if (editor) {
+				editor.blur();
+			}
+			if (document.activeElement && document.activeElement.blur) {
+				document.activeElement.blur();
+			}
+		})
+	`, p.editorSelector()); err != nil {
+		return fmt.Errorf("编辑器失焦失败: %v", err)
+	}
+
+	// 等待"草稿已保存"之类的提示出现
+	saved := p.page.GetByText(regexp.MustCompile(`(草稿)?已保存|保存成功`)).First()
+	if err := common.WaitVisible(saved, 8*time.Second); err != nil {
+		log.Printf("[知乎] ⚠️ 未检测到草稿保存提示，内容可能尚未存入草稿")
+		return nil
+	}
+
+	log.Printf("[知乎] 💾 草稿已保存")
+	return nil
 }
 
 // fillContentWithUnifiedFlowAndImages 使用统一流程但保留知乎的图片替换逻辑
@@ -382,17 +594,17 @@ func (p *Publisher) fillContentWithUnifiedFlowAndImages(handler *common.RichCont
 	if err != nil {
 		return fmt.Errorf("创建临时页面失败: %v", err)
 	}
+	defer common.CloseTempPage(tempPage)
 	log.Printf("[知乎] ✅ Step 2: 临时窗口已创建并加载内容")
 	
 	time.Sleep(2 * time.Second)
 	
 	if err := handler.SelectAndCopyContent(tempPage); err != nil {
-		tempPage.Close()
 		return fmt.Errorf("复制内容失败: %v", err)
 	}
 	log.Printf("[知乎] ✅ Step 3: 内容已复制到剪贴板")
 	
-	tempPage.Close()
+	common.CloseTempPage(tempPage)
 	log.Printf("[知乎] 📄 临时页面已关闭")
 	
 	if err := handler.PasteToEditor(); err != nil {
@@ -400,6 +612,11 @@ func (p *Publisher) fillContentWithUnifiedFlowAndImages(handler *common.RichCont
 	}
 	log.Printf("[知乎] ✅ Step 4: 内容已粘贴到知乎编辑器")
 	
+	// 校验粘贴完整度，剪贴板内容仍在，不完整时重新粘贴
+	if err := p.ensureContentComplete(markdownWithPlaceholders, handler.PasteToEditor); err != nil {
+		return fmt.Errorf("粘贴内容不完整: %v", err)
+	}
+	
 	// Step 5: 使用知乎专门的图片替换方法
 	log.Printf("[知乎] 🖼️ 开始替换 %d 个图片占位符", len(art.Images))
 	if err := p.replacePlaceholdersWithImages(art); err != nil {
@@ -425,11 +642,21 @@ func (p *Publisher) fillContentWithUnifiedFlow(art *article.Article) error {
 	markdownWithPlaceholders := p.prepareMarkdownWithPlaceholders(art)
 	log.Printf("[知乎] ✅ Step 1: 生成带占位符的Markdown内容，长度: %d", len(markdownWithPlaceholders))
 	
+	// 超长内容一次粘贴可能丢失后半部分或卡死，按段落分块粘贴（Step 2-4 逐块进行）
+	if p.shouldPasteInChunks(markdownWithPlaceholders) {
+		if err := p.fillContentInChunks(markdownWithPlaceholders); err != nil {
+			return fmt.Errorf("分块粘贴内容失败: %v", err)
+		}
+		log.Printf("[知乎] ✅ Step 4: 内容已分块粘贴到知乎编辑器")
+		return p.finishUnifiedFlow(art)
+	}
+	
 	// Step 2: 创建临时窗口并加载内容
 	tempPage, err := p.createAndLoadTempPage(markdownWithPlaceholders)
 	if err != nil {
 		return fmt.Errorf("创建临时页面失败: %v", err)
 	}
+	defer common.CloseTempPage(tempPage)
 	log.Printf("[知乎] ✅ Step 2: 临时窗口已创建并加载内容")
 	
 	// 保持窗口打开一段时间让内容渲染
@@ -437,13 +664,12 @@ func (p *Publisher) fillContentWithUnifiedFlow(art *article.Article) error {
 	
 	// Step 3: 在临时窗口中全选并复制内容
 	if err := p.selectAndCopyContent(tempPage); err != nil {
-		tempPage.Close()
 		return fmt.Errorf("复制内容失败: %v", err)
 	}
 	log.Printf("[知乎] ✅ Step 3: 内容已复制到剪贴板")
 	
 	// 关闭临时页面
-	tempPage.Close()
+	common.CloseTempPage(tempPage)
 	log.Printf("[知乎] 📄 临时页面已关闭")
 	
 	// Step 4: 切换回知乎页面并粘贴内容
@@ -452,6 +678,16 @@ func (p *Publisher) fillContentWithUnifiedFlow(art *article.Article) error {
 	}
 	log.Printf("[知乎] ✅ Step 4: 内容已粘贴到知乎编辑器")
 	
+	// 校验粘贴完整度，剪贴板内容仍在，不完整时重新粘贴
+	if err := p.ensureContentComplete(markdownWithPlaceholders, p.pasteToZhihuEditor); err != nil {
+		return fmt.Errorf("粘贴内容不完整: %v", err)
+	}
+	
+	return p.finishUnifiedFlow(art)
+}
+
+// finishUnifiedFlow 统一流程的最后一步：替换占位符为实际图片
+func (p *Publisher) finishUnifiedFlow(art *article.Article) error {
 	// Step 5: 替换占位符为实际图片
 	if len(art.Images) > 0 {
 		log.Printf("[知乎] 🖼️ 开始替换 %d 个图片占位符", len(art.Images))
@@ -469,30 +705,11 @@ func (p *Publisher) fillContentWithUnifiedFlow(art *article.Article) error {
 
 // prepareMarkdownWithPlaceholders 准备带占位符的Markdown内容
 func (p *Publisher) prepareMarkdownWithPlaceholders(art *article.Article) string {
-	var content strings.Builder
-	imageIndex := 0
-	
-	for i, line := range art.Content {
-		// 检查是否是图片行
-		isImageLine := false
-		for _, img := range art.Images {
-			if img.LineIndex == i {
-				// 使用明显的占位符格式，便于后续查找和替换
-				placeholder := fmt.Sprintf("\n[IMAGE_PLACEHOLDER_%d_%s]\n", imageIndex, img.AltText)
-				content.WriteString(placeholder)
-				imageIndex++
-				isImageLine = true
-				break
-			}
-		}
-		
-		if !isImageLine {
-			content.WriteString(line)
-			content.WriteString("\n")
-		}
-	}
-	
-	return content.String()
+	// 使用明显的占位符格式，便于后续查找和替换；占位符按图片真实顺序编号
+	lines := art.ContentWithPlaceholders(func(index int, img article.Image) string {
+		return "\n" + article.LabeledPlaceholderFor(index, img.AltText) + "\n"
+	})
+	return strings.Join(lines, "\n") + "\n"
 }
 
 // createAndLoadTempPage 创建临时页面并加载内容
@@ -537,7 +754,7 @@ func (p *Publisher) createAndLoadTempPage(content string) (playwright.Page, erro
 			</script>
 		</body>
 		</html>
-	`, strings.ReplaceAll(content, "\n", "<br>"))
+	`, strings.ReplaceAll(html.EscapeString(content), "\n", "<br>"))
 	
 	if err := tempPage.SetContent(htmlContent); err != nil {
 		tempPage.Close()
@@ -600,26 +817,26 @@ func (p *Publisher) pasteToZhihuEditor() error {
 	}
 	
 	// 获取编辑器元素
-	editableLocator := p.page.Locator("div.Editable-content").First()
+	editableLocator := p.page.Locator(p.editorSelector()).First()
 	
 	// 等待编辑器出现
-	if err := editableLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(editableLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器超时: %v", err)
 	}
 	
-	// 点击编辑器获取焦点
+	// 点击编辑器获取焦点，并确认焦点确实在知乎编辑器内
 	if err := editableLocator.Click(); err != nil {
 		return fmt.Errorf("点击编辑器失败: %v", err)
 	}
+	if err := common.EnsureEditorFocus(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("粘贴前确认焦点失败: %v", err)
+	}
 	
 	time.Sleep(500 * time.Millisecond)
 	
-	// 清空现有内容（如果有）
-	if err := p.page.Keyboard().Press("Meta+a"); err != nil {
-		p.page.Keyboard().Press("Control+a")
+	// 清空现有内容并确认已清空，避免新内容追加到旧内容后面
+	if err := common.ClearEditor(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("清空编辑器失败: %v", err)
 	}
 	
 	time.Sleep(300 * time.Millisecond)
@@ -680,7 +897,7 @@ func (p *Publisher) handleMarkdownParseDialog() error {
 // replacePlaceholdersWithImages 替换占位符为实际图片
 func (p *Publisher) replacePlaceholdersWithImages(art *article.Article) error {
 	for i, img := range art.Images {
-		placeholder := fmt.Sprintf("[IMAGE_PLACEHOLDER_%d_%s]", i, img.AltText)
+		placeholder := article.LabeledPlaceholderFor(i, img.AltText)
 		log.Printf("[知乎] 🔍 查找并替换占位符: %s", placeholder)
 		
 		// 方法1: 使用JavaScript直接查找和替换
@@ -701,14 +918,14 @@ func (p *Publisher) replacePlaceholdersWithImages(art *article.Article) error {
 	return nil
 }
 
-// replaceTextWithImage 使用JavaScript查找并替换文本为图片
-func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image) error {
+// selectPlaceholder 使用JavaScript在编辑器中查找并选中占位符
+func (p *Publisher) selectPlaceholder(placeholder string) error {
 	// 使用更精确的查找方法
 	result, err := p.page.Evaluate(fmt.Sprintf(`
-		(function() {
+		(function(selector) {
 			try {
 				const placeholder = %q;
-				const editor = document.querySelector('div.Editable-content');
+				const editor = document.querySelector(selector);
 				if (!editor) {
 					return { success: false, error: '找不到编辑器' };
 				}
@@ -807,8 +1024,8 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 			} catch (e) {
 				return { success: false, error: e.message };
 			}
-		})()
-	`, placeholder))
+		})
+	`, placeholder), p.editorSelector())
 	
 	if err != nil {
 		return fmt.Errorf("JavaScript执行失败: %v", err)
@@ -832,6 +1049,51 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 	// 等待一下确保选择稳定
 	time.Sleep(500 * time.Millisecond)
 	
+	return nil
+}
+
+// ReplaceTextWithText 查找占位符并替换为文本（图床链接或跳过图片时的 alt 文本）
+func (p *Publisher) ReplaceTextWithText(placeholder, text string) error {
+	if err := p.selectPlaceholder(placeholder); err != nil {
+		return err
+	}
+	if err := p.page.Keyboard().InsertText(text); err != nil {
+		return fmt.Errorf("输入替换文本失败: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	return nil
+}
+
+// UploadImage 查找占位符并通过图片弹窗的上传控件插入图片
+func (p *Publisher) UploadImage(placeholder string, img article.Image) error {
+	if err := p.selectPlaceholder(placeholder); err != nil {
+		return err
+	}
+	if err := p.page.Keyboard().Press("Delete"); err != nil {
+		return fmt.Errorf("删除占位符失败: %v", err)
+	}
+	if err := p.clickZhihuImageButton(); err != nil {
+		return fmt.Errorf("打开图片弹窗失败: %v", err)
+	}
+	if err := p.uploadZhihuFile(img.AbsolutePath); err != nil {
+		return fmt.Errorf("设置图片文件失败: %v", err)
+	}
+	if err := p.WaitForInsertImageButton(); err != nil {
+		return fmt.Errorf("插入图片失败: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+	if err := p.removeResidualPlaceholder(placeholder); err != nil {
+		log.Printf("[知乎] ⚠️ %v", err)
+	}
+	return nil
+}
+
+// ReplaceTextWithImage 查找占位符并替换为图片（剪贴板粘贴方式）
+func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image) error {
+	if err := p.selectPlaceholder(placeholder); err != nil {
+		return err
+	}
+	
 	log.Printf("[知乎] ✅ 找到占位符，先删除占位符")
 	
 	// 2. 删除选中的占位符
@@ -845,7 +1107,7 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 	}
 	
 	// 4. 粘贴图片到编辑器
-	if err := common.PasteImageToEditor(p.page); err != nil {
+	if err := common.PasteImageToEditor(p.page, p.editorSelector()); err != nil {
 		return fmt.Errorf("粘贴图片失败: %v", err)
 	}
 	
@@ -855,6 +1117,11 @@ func (p *Publisher) ReplaceTextWithImage(placeholder string, img article.Image)
 		// 不算致命错误，继续执行
 	}
 	
+	// 5. 清理残留的占位符片段
+	if err := p.removeResidualPlaceholder(placeholder); err != nil {
+		log.Printf("[知乎] ⚠️ %v", err)
+	}
+	
 	return nil
 }
 
@@ -863,7 +1130,7 @@ func (p *Publisher) findAndReplaceWithKeyboard(placeholder string, img article.I
 	log.Printf("[知乎] 使用键盘方法查找和替换占位符")
 	
 	// 先点击编辑器确保焦点在编辑器内
-	editableLocator := p.page.Locator("div.Editable-content").First()
+	editableLocator := p.page.Locator(p.editorSelector()).First()
 	if err := editableLocator.Click(); err != nil {
 		return fmt.Errorf("点击编辑器失败: %v", err)
 	}
@@ -999,7 +1266,7 @@ func (p *Publisher) fillContentWithPlaceholders(art *article.Article) error {
 			// 替换为占位符
 			if imageIndex < len(art.Images) {
 				img := art.Images[imageIndex]
-				placeholder := fmt.Sprintf("[IMAGE_PLACEHOLDER_%d_%s]", imageIndex, strings.ReplaceAll(img.AltText, " ", "_"))
+				placeholder := article.LabeledPlaceholderFor(imageIndex, img.AltText)
 				contentWithPlaceholders = append(contentWithPlaceholders, placeholder)
 				log.Printf("[知乎] 图片行替换为占位符: %s", placeholder)
 				imageIndex++
@@ -1097,7 +1364,8 @@ func (p *Publisher) copyPlaceholderContentViaPage(contentWithPlaceholders []stri
 	if err != nil {
 		return fmt.Errorf("创建临时页面失败: %v", err)
 	}
-	// 不要用defer，手动控制关闭时机
+	// 正常流程中手动控制关闭时机，defer 只兜底出错或 panic 的情况
+	defer common.CloseTempPage(tempPage)
 	
 	// 创建简单的HTML页面，包含一个编辑框
 	htmlContent := `<!DOCTYPE html>
@@ -1148,7 +1416,7 @@ func (p *Publisher) copyPlaceholderContentViaPage(contentWithPlaceholders []stri
 	
 	// 切换回知乎页面
 	log.Printf("[知乎] 切换回知乎页面...")
-	if err := p.page.BringToFront(); err != nil {
+	if err := common.EnsureEditorFocus(p.page, p.editorSelector()); err != nil {
 		return fmt.Errorf("切换回知乎页面失败: %v", err)
 	}
 	
@@ -1161,7 +1429,7 @@ func (p *Publisher) copyPlaceholderContentViaPage(contentWithPlaceholders []stri
 	log.Printf("[知乎] ✅ 占位符内容已粘贴到编辑器")
 	
 	// 现在可以关闭临时页面了
-	tempPage.Close()
+	common.CloseTempPage(tempPage)
 	
 	// 等待内容稳定
 	time.Sleep(2 * time.Second)
@@ -1174,12 +1442,9 @@ func (p *Publisher) fillContentSafely(art *article.Article) error {
 	log.Printf("[知乎] 使用新页面复制粘贴方法填写内容")
 
 	// 1. 等待并点击编辑器，确保焦点正确
-	editableLocator := p.page.Locator("div.Editable-content").First()
+	editableLocator := p.page.Locator(p.editorSelector()).First()
 
-	if err := editableLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(editableLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器超时: %v", err)
 	}
 
@@ -1256,7 +1521,8 @@ func (p *Publisher) fillContentSafely(art *article.Article) error {
 				// 将markdown转换为富文本，并真正加载本地图片
 				function convertMarkdownToRich(markdown) {
 					const richDiv = document.getElementById('richContent');
-					let html = markdown;
+					// 先转义正文中的 &、<、>，避免被当作标签解析
+					let html = markdown.replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;');
 					
 					// 用于存储待处理的图片
 					const imagePromises = [];
@@ -1486,13 +1752,9 @@ func (p *Publisher) fillContentSafely(art *article.Article) error {
 
 	// 4. 切换回知乎页面并粘贴
 	log.Printf("[知乎] 切换回知乎页面...")
-	if err := p.page.BringToFront(); err != nil {
-		log.Printf("[知乎] ⚠️ 切换页面失败: %v", err)
-	}
-
-	// 重新点击编辑器确保焦点
-	if err := editableLocator.Click(); err != nil {
-		log.Printf("[知乎] ⚠️ 重新点击编辑器失败: %v", err)
+	// 确认知乎页面在前台且编辑器获得焦点，避免粘贴到其它平台的页面
+	if err := common.EnsureEditorFocus(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("粘贴前确认焦点失败: %v", err)
 	}
 	time.Sleep(500 * time.Millisecond)
 
@@ -1548,49 +1810,46 @@ func (p *Publisher) prepareRichContent(art *article.Article) (string, error) {
 	htmlContent.WriteString("<div>")
 	
 	// 添加标题
-	htmlContent.WriteString(fmt.Sprintf("<h1>%s</h1>", art.Title))
+	htmlContent.WriteString(fmt.Sprintf("<h1>%s</h1>", html.EscapeString(art.Title)))
 	
+	// 嵌入图片：读取图片并转换为base64的img标签，读取失败时用文本代替
+	imageHTML := func(index int, img article.Image) string {
+		imageData, err := os.ReadFile(img.AbsolutePath)
+		if err != nil {
+			log.Printf("[知乎] ⚠️ 读取图片失败: %s, %v", img.AbsolutePath, err)
+			return fmt.Sprintf("[图片：%s]", html.EscapeString(img.AltText))
+		}
+
+		// 检测图片格式
+		var mimeType string
+		if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".png") {
+			mimeType = "image/png"
+		} else if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpg") ||
+			strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpeg") {
+			mimeType = "image/jpeg"
+		} else {
+			mimeType = "image/png"
+		}
+
+		base64Data := base64.StdEncoding.EncodeToString(imageData)
+		dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
+		log.Printf("[知乎] 🖼️ 嵌入图片: %s (%d bytes)", img.AltText, len(imageData))
+		return fmt.Sprintf(`<img src="%s" alt="%s"%s style="max-width:100%%;" />`, dataURL, html.EscapeString(img.AltText), img.SizeAttributes())
+	}
+
 	// 处理内容行
 	for i, line := range art.Content {
-		// 检查是否是图片行
-		isImageLine := false
-		for _, img := range art.Images {
-			if img.LineIndex == i {
-				// 读取图片并转换为base64
-				imageData, err := os.ReadFile(img.AbsolutePath)
-				if err != nil {
-					log.Printf("[知乎] ⚠️ 读取图片失败: %s, %v", img.AbsolutePath, err)
-					// 如果图片读取失败，用文本代替
-					htmlContent.WriteString(fmt.Sprintf("<p>[图片：%s]</p>", img.AltText))
-				} else {
-					// 检测图片格式
-					var mimeType string
-					if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".png") {
-						mimeType = "image/png"
-					} else if strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpg") || 
-							strings.HasSuffix(strings.ToLower(img.AbsolutePath), ".jpeg") {
-						mimeType = "image/jpeg"
-					} else {
-						mimeType = "image/png"
-					}
-					
-					// 转换为base64并嵌入HTML
-					base64Data := base64.StdEncoding.EncodeToString(imageData)
-					dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
-					
-					htmlContent.WriteString(fmt.Sprintf(`<img src="%s" alt="%s" style="max-width:100%%;" />`, 
-						dataURL, img.AltText))
-					
-					log.Printf("[知乎] 🖼️ 嵌入图片: %s (%d bytes)", img.AltText, len(imageData))
-				}
-				isImageLine = true
-				break
+		// 只有图片的行每张图片独立输出
+		if art.IsImageOnlyLine(i) {
+			for _, index := range art.ImagesOnLine(i) {
+				htmlContent.WriteString(imageHTML(index, art.Images[index]))
 			}
+			continue
 		}
-		
-		if !isImageLine && strings.TrimSpace(line) != "" {
-			// 处理普通文本行，转换markdown标记为HTML
-			htmlLine := line
+
+		if strings.TrimSpace(line) != "" {
+			// 处理普通文本行：先转义 <、>、& 等字符，再转换markdown标记为HTML；行内图片保持在文字中的位置
+			htmlLine := art.RenderLine(i, html.EscapeString, imageHTML)
 			
 			// 简单的markdown转HTML处理
 			// 标题
@@ -1624,21 +1883,18 @@ func (p *Publisher) prepareMarkdownContent(art *article.Article) string {
 	// 添加一个明确的标题标记来触发markdown检测
 	content.WriteString("# " + art.Title + "\n\n")
 
-	for i, line := range art.Content {
-		// 检查是否是图片行
-		isImageLine := false
-		for j, img := range art.Images {
-			if img.LineIndex == i {
-				// 使用特殊占位符，稍后替换为真实图片
-				placeholder := fmt.Sprintf("[IMAGE_PLACEHOLDER_%d_%s]", j, strings.ReplaceAll(img.AltText, " ", "_"))
-				content.WriteString(placeholder + "\n")
-				isImageLine = true
-				log.Printf("[知乎] 添加图片占位符: %s -> %s (路径: %s)", placeholder, img.AltText, img.AbsolutePath)
-				break
-			}
-		}
-
-		if !isImageLine {
+	// 使用特殊占位符，稍后替换为真实图片
+	lines := art.ContentWithPlaceholders(func(index int, img article.Image) string {
+		placeholder := article.LabeledPlaceholderFor(index, img.AltText)
+		log.Printf("[知乎] 添加图片占位符: %s -> %s (路径: %s)", placeholder, img.AltText, img.AbsolutePath)
+		return placeholder
+	})
+
+	for i, line := range lines {
+		// 图片行直接输出（占位符已就位）
+		if len(art.ImagesOnLine(i)) > 0 {
+			content.WriteString(line + "\n")
+		} else {
 			// 检查是否可能是标题行，如果是就添加markdown标记
 			trimmed := strings.TrimSpace(line)
 			if len(trimmed) > 0 && !strings.HasPrefix(trimmed, "#") {
@@ -1718,10 +1974,9 @@ func (p *Publisher) typeContentToEditor(content string) error {
 	log.Printf("[知乎] 开始键盘输入内容...")
 
 	// 清空现有内容
-	if err := p.page.Keyboard().Press("Control+a"); err != nil {
-		log.Printf("[知乎] ⚠️ 选中所有内容失败: %v", err)
+	if err := common.ClearEditor(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("清空编辑器失败: %v", err)
 	}
-	time.Sleep(200 * time.Millisecond)
 
 	// 使用键盘输入内容
 	if err := p.page.Keyboard().Type(content); err != nil {
@@ -1739,7 +1994,7 @@ func (p *Publisher) typeContentToEditor(content string) error {
 // pasteContentToEditor 将内容粘贴到编辑器（已废弃，保留以防需要）
 func (p *Publisher) pasteContentToEditor(content string) error {
 	// 首先点击编辑器获取焦点和光标选中
-	editableLocator := p.page.Locator("div.Editable-content").First()
+	editableLocator := p.page.Locator(p.editorSelector()).First()
 
 	log.Printf("[知乎] 点击编辑器获取焦点...")
 	if err := editableLocator.Click(); err != nil {
@@ -1893,9 +2148,9 @@ func (p *Publisher) pasteContentToEditor(content string) error {
 	// 尝试多种粘贴方法
 	log.Printf("[知乎] 尝试方法A: JavaScript粘贴事件...")
 	pasteResult, err := p.page.Evaluate(`
-		(function() {
+		(function(selector) {
 			try {
-				const editor = document.querySelector('div.Editable-content');
+				const editor = document.querySelector(selector);
 				if (!editor) return { success: false, error: '编辑器未找到' };
 				
 				editor.focus();
@@ -1917,8 +2172,8 @@ func (p *Publisher) pasteContentToEditor(content string) error {
 			} catch (e) {
 				return { success: false, error: e.message, method: 'ClipboardEvent' };
 			}
-		})()
-	`)
+		})
+	`, p.editorSelector())
 
 	if err != nil {
 		log.Printf("[知乎] ⚠️ JavaScript粘贴事件失败: %v", err)
@@ -1937,132 +2192,18 @@ func (p *Publisher) pasteContentToEditor(content string) error {
 
 	log.Printf("[知乎] Ctrl+V命令已发送")
 
-	// 等待并检查第一次粘贴结果
-	time.Sleep(1000 * time.Millisecond)
-
-	// 验证第一次粘贴
-	firstLength, err := p.getCurrentContentLength()
-	if err != nil {
-		log.Printf("[知乎] ⚠️ 第一次粘贴验证失败: %v", err)
-	} else {
-		log.Printf("[知乎] 第一次粘贴后内容长度: %d (期望: %d)", firstLength, len(content))
-
-		// 如果内容明显不完整，尝试其他粘贴方法
-		if firstLength < len(content)/10 {
-			log.Printf("[知乎] 第一次粘贴不完整，尝试方法C: JavaScript读取剪贴板并设置...")
-
-			// 直接使用已经复制好的内容，绕过剪贴板读取问题
-			log.Printf("[知乎] 使用已知内容直接设置到编辑器...")
-			jsResult, err := p.page.Evaluate(`
-				(function(content) {
-					try {
-						const editor = document.querySelector('div.Editable-content');
-						if (!editor) return { success: false, error: '编辑器未找到' };
-						
-						// 聚焦编辑器
-						editor.focus();
-						editor.click();
-						
-						// 选中所有现有内容
-						const range = document.createRange();
-						const selection = window.getSelection();
-						range.selectNodeContents(editor);
-						selection.removeAllRanges();
-						selection.addRange(range);
-						
-						// 使用execCommand insertText，这更像真实的粘贴
-						const insertSuccess = document.execCommand('insertText', false, content);
-						
-						// 如果insertText失败，降级到textContent
-						if (!insertSuccess) {
-							editor.textContent = content;
-						}
-						
-						// 触发粘贴相关事件，让知乎认为这是真实的粘贴操作
-						const pasteEvent = new Event('paste', { bubbles: true });
-						const inputEvent = new Event('input', { bubbles: true });
-						const changeEvent = new Event('change', { bubbles: true });
-						
-						editor.dispatchEvent(pasteEvent);
-						editor.dispatchEvent(inputEvent);
-						editor.dispatchEvent(changeEvent);
-						
-						// 设置光标位置到末尾
-						const range = document.createRange();
-						const selection = window.getSelection();
-						range.selectNodeContents(editor);
-						range.collapse(false);
-						selection.removeAllRanges();
-						selection.addRange(range);
-						
-						return {
-							success: true,
-							method: 'direct-set',
-							contentLength: editor.textContent.length,
-							preview: editor.textContent.substring(0, 100)
-						};
-					} catch (e) {
-						return { success: false, error: e.message };
-					}
-				})
-			`, content)
-
-			if err != nil {
-				log.Printf("[知乎] ⚠️ JavaScript读取剪贴板失败: %v", err)
-
-				// 最后的备用方案：重新粘贴
-				log.Printf("[知乎] 尝试最后的Ctrl+V方法...")
-				if err := editableLocator.Click(); err == nil {
-					time.Sleep(500 * time.Millisecond)
-					if err := p.page.Keyboard().Press("Control+v"); err != nil {
-						log.Printf("[知乎] ⚠️ 最后的粘贴也失败: %v", err)
-					}
-				}
-			} else {
-				log.Printf("[知乎] JavaScript读取剪贴板结果: %v", jsResult)
-			}
-		}
-	}
-
-	// 等待操作完成
-	time.Sleep(1000 * time.Millisecond)
-
-	// 最终验证内容
-	finalLength, err := p.getCurrentContentLength()
-	if err != nil {
-		log.Printf("[知乎] ⚠️ 无法验证最终结果: %v", err)
-	} else {
-		log.Printf("[知乎] 最终内容长度: %d (期望: %d)", finalLength, len(content))
-
-		if finalLength == 0 {
-			log.Printf("[知乎] ❌ 所有方法都失败，编辑器内容为空")
-			// 最后尝试：使用键盘直接输入一小部分内容作为测试
-			log.Printf("[知乎] 尝试最后的键盘输入方法...")
-			if err := editableLocator.Click(); err == nil {
-				time.Sleep(500 * time.Millisecond)
-				// 只输入前100个字符作为测试
-				shortContent := content
-				if len(shortContent) > 100 {
-					shortContent = shortContent[:100] + "..."
-				}
-				if err := p.page.Keyboard().Type(shortContent); err != nil {
-					log.Printf("[知乎] ⚠️ 键盘输入也失败: %v", err)
-				} else {
-					log.Printf("[知乎] ✅ 键盘输入完成，内容长度: %d", len(shortContent))
-				}
-			}
-		} else if finalLength < len(content)/2 {
-			log.Printf("[知乎] ⚠️ 内容不完整，只有预期的 %.1f%%", float64(finalLength)/float64(len(content))*100)
-		} else {
-			log.Printf("[知乎] ✅ 内容验证通过，完整度: %.1f%%", float64(finalLength)/float64(len(content))*100)
-		}
+	// 校验粘贴完整度，不完整时直接设置内容重试，仍不完整则降级为键盘输入
+	if err := p.ensureContentComplete(content, func() error {
+		return p.setEditorContentDirectly(content)
+	}); err != nil {
+		log.Printf("[知乎] ⚠️ %v", err)
 	}
 
 	// 触发额外的事件来确保知乎检测到内容变化
 	log.Printf("[知乎] 触发编辑器事件以确保内容被检测...")
 	_, err = p.page.Evaluate(`
-		(function() {
-			const editor = document.querySelector('div.Editable-content');
+		(function(selector) {
+			const editor = document.querySelector(selector);
 			if (editor) {
 				// 触发多种事件确保知乎检测到内容变化
 				editor.dispatchEvent(new Event('input', { bubbles: true }));
@@ -2076,8 +2217,8 @@ func (p *Publisher) pasteContentToEditor(content string) error {
 				return true;
 			}
 			return false;
-		})()
-	`)
+		})
+	`, p.editorSelector())
 	if err != nil {
 		log.Printf("[知乎] ⚠️ 触发编辑器事件失败: %v", err)
 	}
@@ -2090,6 +2231,67 @@ func (p *Publisher) pasteContentToEditor(content string) error {
 	return nil
 }
 
+// setEditorContentDirectly 使用已知内容直接设置到编辑器，绕过剪贴板读取问题
+func (p *Publisher) setEditorContentDirectly(content string) error {
+	result, err := p.page.Evaluate(`
+		(function([content, selector]) {
+			try {
+				const editor = document.querySelector(selector);
+				if (!editor) return { success: false, error: '编辑器未找到' };
+				
+				// 聚焦编辑器
+				editor.focus();
+				editor.click();
+				
+				// 选中所有现有内容
+				const range = document.createRange();
+				const selection = window.getSelection();
+				range.selectNodeContents(editor);
+				selection.removeAllRanges();
+				selection.addRange(range);
+				
+				// 使用execCommand insertText，这更像真实的粘贴
+				const insertSuccess = document.execCommand('insertText', false, content);
+				
+				// 如果insertText失败，降级到textContent
+				if (!insertSuccess) {
+					editor.textContent = content;
+				}
+				
+				// 触发粘贴相关事件，让知乎认为这是真实的粘贴操作
+				const pasteEvent = new Event('paste', { bubbles: true });
+				const inputEvent = new Event('input', { bubbles: true });
+				const changeEvent = new Event('change', { bubbles: true });
+				
+				editor.dispatchEvent(pasteEvent);
+				editor.dispatchEvent(inputEvent);
+				editor.dispatchEvent(changeEvent);
+				
+				// 设置光标位置到末尾
+				const endRange = document.createRange();
+				endRange.selectNodeContents(editor);
+				endRange.collapse(false);
+				selection.removeAllRanges();
+				selection.addRange(endRange);
+				
+				return {
+					success: true,
+					method: 'direct-set',
+					contentLength: editor.textContent.length,
+					preview: editor.textContent.substring(0, 100)
+				};
+			} catch (e) {
+				return { success: false, error: e.message };
+			}
+		})
+	`, []interface{}{content, p.editorSelector()})
+	if err != nil {
+		return err
+	}
+	log.Printf("[知乎] 直接设置内容结果: %v", result)
+	return nil
+}
+
 // waitAndClickMarkdownParseButton 等待并点击markdown解析按钮
 func (p *Publisher) waitAndClickMarkdownParseButton() error {
 	// 直接调用新版本的函数
@@ -2097,106 +2299,90 @@ func (p *Publisher) waitAndClickMarkdownParseButton() error {
 }
 
 // waitAndClickMarkdownParseButtonNew 新版本的等待并点击markdown解析按钮
+// 检测顺序：1. 文本包含"解析"的按钮 2. 解析提示弹层中的确认按钮 3. 兜底：button.Button--link 数量判断
 func (p *Publisher) waitAndClickMarkdownParseButtonNew() error {
 	log.Printf("[知乎] ⏳ 等待markdown解析确认按钮出现...")
 	
 	// 首先等待一下，给知乎时间检测内容
 	time.Sleep(2 * time.Second)
 	
-	// 使用新的方法：通过 button.Button--link 的数量判断是否出现解析按钮
 	maxWaitTime := 15 * time.Second
 	startTime := time.Now()
 	checkInterval := 1 * time.Second
-	
-	log.Printf("[知乎] 开始监控 button.Button--link 数量变化...")
+	checkCount := 0
 	
 	for time.Since(startTime) < maxWaitTime {
-		// 检查 button.Button--link 的数量
-		buttonCount, err := p.page.Evaluate(`
+		checkCount++
+		
+		clickResult, err := p.page.Evaluate(`
 			(function() {
-				const buttons = document.querySelectorAll('button.Button--link');
-				console.log('当前找到', buttons.length, '个 button.Button--link 按钮');
-				return buttons.length;
+				const textOf = (el) => (el.textContent || el.innerText || '').trim();
+				const isVisible = (el) => !!(el.offsetWidth || el.offsetHeight || el.getClientRects().length);
+				const debug = [];
+				
+				// 方式1：查找文本包含"解析"的可见按钮
+				const allButtons = Array.from(document.querySelectorAll('button'));
+				debug.push('页面按钮总数: ' + allButtons.length);
+				const parseButton = allButtons.find(btn => isVisible(btn) && textOf(btn).includes('解析'));
+				if (parseButton) {
+					const text = textOf(parseButton);
+					parseButton.click();
+					return { success: true, method: 'text', buttonText: text, debug: debug };
+				}
+				
+				// 方式2：查找包含"Markdown"提示的弹层容器，点击其中的确认按钮
+				const containers = document.querySelectorAll('[role="dialog"], .Modal, .Popover-content, .Notification, .Toast');
+				debug.push('候选弹层数: ' + containers.length);
+				for (const container of containers) {
+					if (!isVisible(container)) continue;
+					const containerText = textOf(container);
+					if (!/markdown/i.test(containerText)) continue;
+					debug.push('发现Markdown提示弹层: ' + containerText.substring(0, 50));
+					const confirm = Array.from(container.querySelectorAll('button'))
+						.find(btn => /^(确认|确定|是)$/.test(textOf(btn)));
+					if (confirm) {
+						const text = textOf(confirm);
+						confirm.click();
+						return { success: true, method: 'dialog', buttonText: text, debug: debug };
+					}
+				}
+				
+				// 方式3（兜底）：button.Button--link 数量 >= 4 时点击最后一个
+				const linkButtons = document.querySelectorAll('button.Button--link');
+				debug.push('Button--link 按钮数: ' + linkButtons.length);
+				if (linkButtons.length >= 4) {
+					const lastButton = linkButtons[linkButtons.length - 1];
+					const text = textOf(lastButton);
+					lastButton.click();
+					return { success: true, method: 'count', buttonText: text, debug: debug };
+				}
+				
+				return { success: false, debug: debug };
 			})()
 		`)
 		
 		if err != nil {
-			log.Printf("[知乎] ⚠️ 检查按钮数量失败: %v", err)
-			time.Sleep(checkInterval)
-			continue
-		}
-		
-		// 转换为整数
-		var count int
-		switch v := buttonCount.(type) {
-		case float64:
-			count = int(v)
-		case int:
-			count = v
-		default:
-			log.Printf("[知乎] ⚠️ 无法解析按钮数量: %T %v", buttonCount, buttonCount)
+			log.Printf("[知乎] ⚠️ 检测解析按钮失败 (第%d次): %v", checkCount, err)
 			time.Sleep(checkInterval)
 			continue
 		}
 		
-		log.Printf("[知乎] 检测到 %d 个 button.Button--link 按钮", count)
-		
-		if count >= 4 {
-			// 出现了解析按钮（4个按钮），点击最后一个
-			log.Printf("[知乎] ✅ 检测到解析按钮已出现（%d个按钮），准备点击最后一个", count)
-			
-			// 使用JavaScript点击最后一个按钮
-			clickResult, err := p.page.Evaluate(`
-				(function() {
-					const buttons = document.querySelectorAll('button.Button--link');
-					if (buttons.length >= 4) {
-						const lastButton = buttons[buttons.length - 1];
-						const buttonText = lastButton.textContent || lastButton.innerText || '';
-						console.log('准备点击最后一个按钮，文本:', buttonText);
-						
-						// 点击按钮
-						lastButton.click();
-						
-						return {
-							success: true,
-							buttonText: buttonText,
-							buttonCount: buttons.length
-						};
-					}
-					return {
-						success: false,
-						error: '按钮数量不足',
-						buttonCount: buttons.length
-					};
-				})()
-			`)
-			
-			if err != nil {
-				log.Printf("[知乎] ⚠️ 点击解析按钮失败: %v", err)
-				time.Sleep(checkInterval)
-				continue
+		if result, ok := clickResult.(map[string]interface{}); ok {
+			if debugInfo, ok := result["debug"].([]interface{}); ok {
+				for _, info := range debugInfo {
+					log.Printf("[知乎] [解析按钮检测 #%d] %v", checkCount, info)
+				}
 			}
 			
-			// 检查点击结果
-			if result, ok := clickResult.(map[string]interface{}); ok {
-				if success, _ := result["success"].(bool); success {
-					buttonText, _ := result["buttonText"].(string)
-					log.Printf("[知乎] ✅ 成功点击解析按钮，按钮文本: '%s'", buttonText)
-					
-					// 等待解析完成
-					time.Sleep(3 * time.Second)
-					return nil
-				} else {
-					errorMsg, _ := result["error"].(string)
-					log.Printf("[知乎] ⚠️ 点击失败: %s", errorMsg)
-				}
+			if success, _ := result["success"].(bool); success {
+				method, _ := result["method"].(string)
+				buttonText, _ := result["buttonText"].(string)
+				log.Printf("[知乎] ✅ 成功点击解析按钮（检测方式: %s），按钮文本: '%s'", method, buttonText)
+				
+				// 等待解析完成
+				time.Sleep(3 * time.Second)
+				return nil
 			}
-		} else if count == 2 {
-			// 只有2个按钮，说明还没出现解析按钮，继续等待
-			log.Printf("[知乎] 只有 %d 个按钮，解析按钮尚未出现，继续等待...", count)
-		} else {
-			// 其他情况，打印调试信息
-			log.Printf("[知乎] 发现 %d 个按钮，继续监控...", count)
 		}
 		
 		// 等待后重新检查
@@ -2205,32 +2391,20 @@ func (p *Publisher) waitAndClickMarkdownParseButtonNew() error {
 	
 	// 超时后尝试打印调试信息
 	log.Printf("[知乎] ⚠️ 等待解析按钮超时，打印当前页面按钮信息...")
-	_, err := p.page.Evaluate(`
+	buttonInfo, err := p.page.Evaluate(`
 		(function() {
-			console.log('=== 调试信息 ===');
-			const allButtons = document.querySelectorAll('button');
-			console.log('页面总按钮数:', allButtons.length);
-			
-			const linkButtons = document.querySelectorAll('button.Button--link');
-			console.log('Button--link 按钮数:', linkButtons.length);
-			linkButtons.forEach((btn, i) => {
-				console.log('Button--link', i + ':', btn.textContent, btn.className);
-			});
-			
-			// 查找可能的解析按钮
-			const parseButtons = document.querySelectorAll('button');
-			parseButtons.forEach((btn, i) => {
-				const text = btn.textContent || btn.innerText || '';
-				if (text.includes('解析') || text.includes('确认')) {
-					console.log('可能的解析按钮', i + ':', text, btn.className);
-				}
-			});
-			
-			return true;
+			return Array.from(document.querySelectorAll('button'))
+				.map(btn => ((btn.textContent || '').trim() + ' <' + btn.className + '>'))
+				.filter(text => text.length > 0)
+				.slice(0, 30);
 		})()
 	`)
 	if err != nil {
 		log.Printf("[知乎] ⚠️ 调试信息输出失败: %v", err)
+	} else if buttons, ok := buttonInfo.([]interface{}); ok {
+		for i, info := range buttons {
+			log.Printf("[知乎] - 按钮 %d: %v", i+1, info)
+		}
 	}
 	
 	return fmt.Errorf("等待解析按钮超时")
@@ -2261,12 +2435,9 @@ func (p *Publisher) typeSafely(text string) error {
 // focusZhihuEditor 锁定知乎编辑器焦点
 func (p *Publisher) focusZhihuEditor() error {
 	// 等待可编辑区域出现
-	editableLocator := p.page.Locator("div.Editable-content").First()
+	editableLocator := p.page.Locator(p.editorSelector()).First()
 
-	if err := editableLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(10000), // 10秒超时
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	if err := common.WaitVisible(editableLocator, 10*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器超时: %v", err)
 	}
 
@@ -2362,27 +2533,23 @@ func (p *Publisher) fillContentWithImages(art *article.Article) error {
 func (p *Publisher) processZhihuImages(art *article.Article) error {
 	log.Printf("[知乎] 开始处理带图片的文章，共 %d 行，%d 张图片", len(art.Content), len(art.Images))
 
+	// 暂时不插入图片，用文字描述代替，避免光标跳转（行内其它文字保留）
+	lines := art.ContentWithPlaceholders(func(index int, img article.Image) string {
+		return fmt.Sprintf("[图片: %s]", img.AltText)
+	})
+
 	// 新策略：逐行输入，遇到图片行时直接插入图片，避免占位符和查找导致的光标跳转
-	for i, line := range art.Content {
-		// 检查这一行是否应该是图片
-		var targetImage *article.Image
-		for j := range art.Images {
-			if art.Images[j].LineIndex == i {
-				targetImage = &art.Images[j]
-				break
+	for i, line := range lines {
+		if imageIndexes := art.ImagesOnLine(i); len(imageIndexes) > 0 {
+			// 这一行包含图片，为了避免光标跳转问题，暂时使用文字描述代替
+			for _, index := range imageIndexes {
+				log.Printf("[知乎] 第 %d 行是图片: %s", i+1, art.Images[index].AbsolutePath)
 			}
-		}
-
-		if targetImage != nil {
-			// 这一行是图片，为了避免光标跳转问题，暂时使用文字描述代替
-			log.Printf("[知乎] 第 %d 行是图片: %s", i+1, targetImage.AbsolutePath)
 
-			// 暂时不插入图片，用文字描述代替，避免光标跳转
-			imageText := fmt.Sprintf("[图片: %s]", targetImage.AltText)
-			if err := p.typeLineRealistically(imageText); err != nil {
+			if err := p.typeLineRealistically(line); err != nil {
 				log.Printf("[知乎] ⚠️ 输入图片描述文本失败: %v", err)
 			} else {
-				log.Printf("[知乎] ✅ 已输入图片描述: %s", imageText)
+				log.Printf("[知乎] ✅ 已输入图片描述: %s", line)
 			}
 		} else {
 			// 普通文本行
@@ -2455,15 +2622,15 @@ func (p *Publisher) insertImageDirectly(img *article.Image) error {
 // getCurrentContentLength 获取当前编辑器内容长度
 func (p *Publisher) getCurrentContentLength() (int, error) {
 	result, err := p.page.Evaluate(`
-		(function() {
-			const editor = document.querySelector('div.Editable-content');
+		(function(selector) {
+			const editor = document.querySelector(selector);
 			if (editor) {
 				const text = editor.textContent || editor.innerText || '';
 				return text.length;
 			}
 			return 0;
-		})()
-	`)
+		})
+	`, p.editorSelector())
 
 	if err != nil {
 		return 0, err
@@ -2486,8 +2653,8 @@ func (p *Publisher) getCurrentContentLength() (int, error) {
 func (p *Publisher) ensureCursorAtEnd() error {
 	// 使用JavaScript将光标移动到编辑器末尾
 	_, err := p.page.Evaluate(`
-		(function() {
-			const editor = document.querySelector('div.Editable-content');
+		(function(selector) {
+			const editor = document.querySelector(selector);
 			if (editor) {
 				// 聚焦编辑器
 				editor.focus();
@@ -2516,8 +2683,8 @@ func (p *Publisher) ensureCursorAtEnd() error {
 				return true;
 			}
 			return false;
-		})()
-	`)
+		})
+	`, p.editorSelector())
 
 	return err
 }
@@ -2554,10 +2721,7 @@ func (p *Publisher) uploadZhihuFile(imagePath string) error {
 	fileInputLocator := p.page.Locator(`input[type="file"][accept="image/*"]`)
 
 	// 等待file input元素出现
-	if err := fileInputLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(5000),
-		State:   playwright.WaitForSelectorStateAttached,
-	}); err != nil {
+	if err := common.WaitAttached(fileInputLocator, 5*time.Second); err != nil {
 		return fmt.Errorf("等待文件输入框超时: %v", err)
 	}
 
@@ -2612,8 +2776,8 @@ func (p *Publisher) SetContent(content string) error {
 func (p *Publisher) FindAndSelectText(text string) error {
 	// 知乎编辑器的文本查找和选择
 	jsCode := `
-		(function(searchText) {
-			const editor = document.querySelector('div.Editable-content');
+		(function([searchText, selector]) {
+			const editor = document.querySelector(selector);
 			if (!editor) return false;
 			
 			// 获取编辑器文本内容
@@ -2652,7 +2816,7 @@ func (p *Publisher) FindAndSelectText(text string) error {
 						selection.addRange(range);
 						
 						// 确保焦点在编辑器
-						const editableContent = document.querySelector('div.Editable-content');
+						const editableContent = document.querySelector(selector);
 						if (editableContent) {
 							editableContent.focus();
 						}
@@ -2668,7 +2832,7 @@ func (p *Publisher) FindAndSelectText(text string) error {
 		})
 	`
 
-	result, err := p.page.Evaluate(jsCode, text)
+	result, err := p.page.Evaluate(jsCode, []interface{}{text, p.editorSelector()})
 	if err != nil {
 		return fmt.Errorf("查找文本失败: %v", err)
 	}
@@ -2684,20 +2848,14 @@ func (p *Publisher) FindAndSelectText(text string) error {
 // WaitForEditor 等待编辑器加载完成
 func (p *Publisher) WaitForEditor() error {
 	// 等待标题输入框
-	titleLocator := p.page.Locator("textarea.Input")
-	if err := titleLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(15000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	titleLocator := p.page.Locator(selectors.Get(Name, selectors.Title))
+	if err := common.WaitVisible(titleLocator, 15*time.Second); err != nil {
 		return fmt.Errorf("等待标题输入框超时: %v", err)
 	}
 
 	// 等待可编辑内容区域
-	editableLocator := p.page.Locator("div.Editable-content")
-	if err := editableLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(15000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
+	editableLocator := p.page.Locator(p.editorSelector())
+	if err := common.WaitVisible(editableLocator, 15*time.Second); err != nil {
 		return fmt.Errorf("等待编辑器超时: %v", err)
 	}
 
@@ -2705,63 +2863,61 @@ func (p *Publisher) WaitForEditor() error {
 	return nil
 }
 
-// WaitForInsertImageButton 等待"插入图片"按钮可点击并点击
+// insertImageButtonTexts 图片弹窗确认按钮的文案，按优先级排列（不同弹窗形态的文案不同）
+var insertImageButtonTexts = []string{"插入图片", "插入", "确定", "完成"}
+
+// WaitForInsertImageButton 等待图片上传完成后点击弹窗的插入按钮。
+// 知乎的图片弹窗有多种形态（带加载条、拖拽上传、无加载条），以下任一信号出现且没有加载条时视为上传完成：
+// 弹窗中出现预览图、插入按钮变为可点击。超时时保存截图便于排查
 func (p *Publisher) WaitForInsertImageButton() error {
 	log.Printf("[知乎] ⏳ 等待图片上传完成...")
 
-	// 循环检查 CircleLoadingBar 是否存在
-	// 有这个class = 还在上传，没有 = 上传完成
-	startTime := time.Now()
-	timeout := 30 * time.Second
+	timeout := common.Timeout(30 * time.Second)
+	deadline := time.Now().Add(timeout)
 	checkCount := 0
+	uploaded := false
 
-	for time.Since(startTime) < timeout {
+	for time.Now().Before(deadline) {
 		checkCount++
-
-		// 检查是否存在 CircleLoadingBar
-		loadingBarLocator := p.page.Locator(".CircleLoadingBar")
-		loadingBarCount, err := loadingBarLocator.Count()
-
+		state, err := p.insertImageDialogState()
 		if err != nil {
-			log.Printf("[知乎] ⚠️ 检查加载条状态失败 (第%d次): %v", checkCount, err)
+			log.Printf("[知乎] ⚠️ 检查图片弹窗状态失败 (第%d次): %v", checkCount, err)
 			time.Sleep(500 * time.Millisecond)
 			continue
 		}
 
-		if loadingBarCount == 0 {
-			// 没有加载条了，说明上传完成
-			log.Printf("[知乎] ✅ 加载条已消失（第%d次检查），图片上传完成", checkCount)
+		if !state.loading && (state.preview || state.buttonReady) {
+			log.Printf("[知乎] ✅ 图片上传完成（第%d次检查，预览图=%t，按钮可点击=%t）", checkCount, state.preview, state.buttonReady)
+			uploaded = true
 			break
 		}
 
-		// 还有加载条，继续等待
 		if checkCount%3 == 0 {
-			log.Printf("[知乎] 图片还在上传中，检测到 %d 个加载条... (第%d次检查)", loadingBarCount, checkCount)
+			log.Printf("[知乎] 图片还在上传中（加载中=%t，预览图=%t）... (第%d次检查)", state.loading, state.preview, checkCount)
 		}
 		time.Sleep(1 * time.Second)
 	}
 
-	if time.Since(startTime) >= timeout {
-		log.Printf("[知乎] ⚠️ 等待图片上传完成超时，继续尝试点击插入按钮")
+	if !uploaded {
+		log.Printf("[知乎] ⚠️ 等待图片上传完成超时（%v），继续尝试点击插入按钮", timeout)
+		p.saveScreenshot("insert-image-timeout")
 	}
 
 	// 短暂等待确保状态更新
 	time.Sleep(500 * time.Millisecond)
 
-	// 现在点击插入图片按钮
-	insertButtonLocator := p.page.Locator(`button:has-text("插入图片")`)
-
-	// 等待按钮出现并可见
-	if err := insertButtonLocator.WaitFor(playwright.LocatorWaitForOptions{
-		Timeout: playwright.Float(5000),
-		State:   playwright.WaitForSelectorStateVisible,
-	}); err != nil {
-		return fmt.Errorf("等待插入图片按钮出现超时: %v", err)
-	}
-
-	// 点击插入图片按钮
-	if err := insertButtonLocator.Click(); err != nil {
-		return fmt.Errorf("点击插入图片按钮失败: %v", err)
+	// 按文案优先级点击弹窗中的插入按钮，按钮刚变为可点击时可能还需要片刻
+	var clickErr error
+	buttonDeadline := time.Now().Add(common.Timeout(5 * time.Second))
+	for {
+		if clickErr = common.ClickButtonByText(p.page, ".Modal", insertImageButtonTexts...); clickErr == nil {
+			break
+		}
+		if time.Now().After(buttonDeadline) {
+			path := p.saveScreenshot("insert-image-button")
+			return fmt.Errorf("点击插入图片按钮失败: %v（截图: %s）", clickErr, path)
+		}
+		time.Sleep(500 * time.Millisecond)
 	}
 
 	log.Printf("[知乎] ✅ 已点击插入图片按钮")
@@ -2772,6 +2928,58 @@ func (p *Publisher) WaitForInsertImageButton() error {
 	return nil
 }
 
+// insertImageDialogState 图片弹窗的上传状态
+type insertImageDialogState struct {
+	loading     bool // 存在可见的加载条或进度条
+	preview     bool // 弹窗中出现了已加载的预览图
+	buttonReady bool // 插入按钮存在且可点击
+}
+
+// insertImageDialogState 读取图片弹窗的上传状态，找不到弹窗时在整个页面中检查
+func (p *Publisher) insertImageDialogState() (insertImageDialogState, error) {
+	result, err := p.page.Evaluate(`
+		(texts) => {
+			const isVisible = (el) => el && el.offsetParent !== null;
+			const textOf = (el) => (el.innerText || el.textContent || '').trim();
+			const scope = document.querySelector('.Modal') || document;
+
+			const loading = Array.from(scope.querySelectorAll('.CircleLoadingBar, [class*="Loading"], [class*="loading"], [class*="Progress"], [class*="progress"]'))
+				.some(isVisible);
+			const preview = Array.from(scope.querySelectorAll('img'))
+				.some(img => isVisible(img) && img.complete && img.naturalWidth > 0 && /^(blob:|data:|https?:)/.test(img.src));
+			const buttons = Array.from(scope.querySelectorAll('button')).filter(isVisible);
+			const button = texts.map(text => buttons.find(el => textOf(el) === text)).find(Boolean);
+			const buttonReady = !!button && !button.disabled && button.getAttribute('aria-disabled') !== 'true';
+
+			return { loading, preview, buttonReady };
+		}
+	`, insertImageButtonTexts)
+	if err != nil {
+		return insertImageDialogState{}, err
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return insertImageDialogState{}, fmt.Errorf("图片弹窗状态返回了无法识别的结果: %v", result)
+	}
+	state := insertImageDialogState{}
+	state.loading, _ = resultMap["loading"].(bool)
+	state.preview, _ = resultMap["preview"].(bool)
+	state.buttonReady, _ = resultMap["buttonReady"].(bool)
+	return state, nil
+}
+
+// saveScreenshot 保存当前页面截图用于排查，返回截图路径，保存失败时返回空字符串
+func (p *Publisher) saveScreenshot(scene string) string {
+	path, err := common.SaveScreenshot(p.page, Name, scene)
+	if err != nil {
+		log.Printf("[知乎] ⚠️ %v", err)
+		return ""
+	}
+	log.Printf("[知乎] 📸 已保存截图: %s", path)
+	return path
+}
+
 // replaceImagePlaceholders 替换图片占位符为真实图片
 func (p *Publisher) replaceImagePlaceholders(art *article.Article) error {
 	log.Printf("[知乎] 开始替换 %d 个图片占位符", len(art.Images))
@@ -2780,7 +2988,7 @@ func (p *Publisher) replaceImagePlaceholders(art *article.Article) error {
 	time.Sleep(2 * time.Second)
 	
 	for j, img := range art.Images {
-		placeholder := fmt.Sprintf("[IMAGE_PLACEHOLDER_%d_%s]", j, strings.ReplaceAll(img.AltText, " ", "_"))
+		placeholder := article.LabeledPlaceholderFor(j, img.AltText)
 		log.Printf("[知乎] 处理图片 %d: %s -> %s", j+1, placeholder, img.AbsolutePath)
 		
 		if err := p.replaceOnePlaceholder(placeholder, img.AbsolutePath); err != nil {
@@ -2806,7 +3014,7 @@ func (p *Publisher) replaceOnePlaceholder(placeholder, imagePath string) error {
 	}
 	
 	// 2. 在编辑器中查找并选中占位符
-	editableLocator := p.page.Locator("div.Editable-content").First()
+	editableLocator := p.page.Locator(p.editorSelector()).First()
 	
 	// 确保编辑器有焦点
 	if err := editableLocator.Click(); err != nil {
@@ -2816,8 +3024,8 @@ func (p *Publisher) replaceOnePlaceholder(placeholder, imagePath string) error {
 	
 	// 3. 使用JavaScript查找并选中占位符文本
 	found, err := p.page.Evaluate(`
-		(function(placeholderText) {
-			const editor = document.querySelector('div.Editable-content');
+		(function([placeholderText, selector]) {
+			const editor = document.querySelector(selector);
 			if (!editor) return false;
 			
 			// 查找占位符文本
@@ -2853,7 +3061,7 @@ func (p *Publisher) replaceOnePlaceholder(placeholder, imagePath string) error {
 			console.log('未找到占位符:', placeholderText);
 			return false;
 		})
-	`, placeholder)
+	`, []interface{}{placeholder, p.editorSelector()})
 	
 	if err != nil {
 		return fmt.Errorf("查找占位符失败: %v", err)
@@ -2865,12 +3073,17 @@ func (p *Publisher) replaceOnePlaceholder(placeholder, imagePath string) error {
 	
 	log.Printf("[知乎] ✅ 找到并选中占位符: %s", placeholder)
 	
+	// 先删除占位符再粘贴（与博客园、掘金一致），避免粘贴只替换部分选区
+	if err := p.page.Keyboard().Press("Delete"); err != nil {
+		return fmt.Errorf("删除占位符失败: %v", err)
+	}
+	
 	// 4. 复制图片文件到剪贴板
 	if err := p.copyImageToClipboard(imagePath); err != nil {
 		return fmt.Errorf("复制图片到剪贴板失败: %v", err)
 	}
 	
-	// 5. 粘贴图片替换选中的占位符
+	// 5. 在占位符原位置粘贴图片
 	log.Printf("[知乎] 粘贴图片替换占位符...")
 	if err := p.page.Keyboard().Press("Meta+v"); err != nil {
 		log.Printf("[知乎] Meta+v失败，尝试Control+v: %v", err)
@@ -2879,6 +3092,12 @@ func (p *Publisher) replaceOnePlaceholder(placeholder, imagePath string) error {
 		}
 	}
 	
+	// 6. 清理残留的占位符片段
+	time.Sleep(1 * time.Second)
+	if err := p.removeResidualPlaceholder(placeholder); err != nil {
+		log.Printf("[知乎] ⚠️ %v", err)
+	}
+	
 	log.Printf("[知乎] ✅ 占位符 %s 已替换为图片", placeholder)
 	return nil
 }
@@ -3083,9 +3302,9 @@ func (p *Publisher) waitForImageUploadComplete() error {
 	// 等待图片出现在编辑器中
 	for i := 0; i < 10; i++ { // 最多等待10秒
 		result, err := p.page.Evaluate(`
-			(function() {
+			(function(selector) {
 				// 检查知乎编辑器中是否有图片
-				const editor = document.querySelector('div.Editable-content');
+				const editor = document.querySelector(selector);
 				if (editor) {
 					// 检查是否有img标签
 					const images = editor.querySelectorAll('img');
@@ -3107,8 +3326,8 @@ func (p *Publisher) waitForImageUploadComplete() error {
 				}
 				
 				return { success: false };
-			})()
-		`)
+			})
+		`, p.editorSelector())
 		
 		if err != nil {
 			log.Printf("[知乎] 检查图片状态失败: %v", err)
@@ -0,0 +1,72 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// maxResidualCleanups 单个占位符最多清理的残留片段数，避免选中失败时死循环
+const maxResidualCleanups = 5
+
+// removeResidualPlaceholder 图片插入后再扫描一遍编辑器，删除残留的占位符文本。
+// 知乎编辑器在粘贴图片时偶尔只替换了部分选区，留下 [IMAGE_PLACEHOLDER_... 这样的片段
+func (p *Publisher) removeResidualPlaceholder(placeholder string) error {
+	core := strings.TrimSuffix(strings.TrimPrefix(placeholder, "["), "]")
+
+	for removed := 0; removed < maxResidualCleanups; removed++ {
+		result, err := p.page.Evaluate(`
+			(args) => {
+				const editor = document.querySelector(args.editor);
+				if (!editor) {
+					return { found: false };
+				}
+
+				// 占位符后不能紧跟数字（IMAGE_PLACEHOLDER_1 不应匹配 IMAGE_PLACEHOLDER_10），
+				// 可带 _alt 后缀和首尾方括号
+				const escaped = args.core.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+				const pattern = new RegExp('\\[?' + escaped + '(?![0-9])(_[^\\]\\s]*)?\\]?');
+
+				const walker = document.createTreeWalker(editor, NodeFilter.SHOW_TEXT, null, false);
+				let node;
+				while (node = walker.nextNode()) {
+					const match = pattern.exec(node.textContent);
+					if (!match) continue;
+
+					const range = document.createRange();
+					range.setStart(node, match.index);
+					range.setEnd(node, match.index + match[0].length);
+					const selection = window.getSelection();
+					selection.removeAllRanges();
+					selection.addRange(range);
+					editor.focus();
+					return { found: true, text: match[0] };
+				}
+				return { found: false };
+			}
+		`, map[string]interface{}{
+			"editor": p.editorSelector(),
+			"core":   core,
+		})
+		if err != nil {
+			return fmt.Errorf("扫描残留占位符失败: %v", err)
+		}
+
+		resultMap, _ := result.(map[string]interface{})
+		if found, _ := resultMap["found"].(bool); !found {
+			if removed > 0 {
+				log.Printf("[知乎] 🧹 已清理 %d 处残留占位符: %s", removed, placeholder)
+			}
+			return nil
+		}
+
+		log.Printf("[知乎] 🧹 发现残留占位符片段: %v", resultMap["text"])
+		if err := p.page.Keyboard().Press("Delete"); err != nil {
+			return fmt.Errorf("删除残留占位符失败: %v", err)
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	return fmt.Errorf("残留占位符清理 %d 次后仍存在: %s", maxResidualCleanups, placeholder)
+}
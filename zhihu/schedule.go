@@ -0,0 +1,78 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// SchedulePublish 在发布设置中开启定时发布并填入发布日期和时间
+func (p *Publisher) SchedulePublish(publishAt time.Time) error {
+	result, err := p.page.Evaluate(`
+		(schedule) => {
+			const isVisible = (el) => el && el.offsetParent !== null;
+			const textOf = (el) => (el.innerText || el.textContent || '').trim();
+
+			// 1. 确保发布设置已展开
+			const expander = Array.from(document.querySelectorAll('button, div[role="button"], span'))
+				.find(el => isVisible(el) && textOf(el) === '发布设置');
+			const hasSchedule = () => Array.from(document.querySelectorAll('label, div, span, li'))
+				.some(el => isVisible(el) && textOf(el) === '定时发布');
+			if (!hasSchedule() && expander) {
+				expander.click();
+			}
+
+			// 2. 选择"定时发布"（单选项或开关）
+			const option = Array.from(document.querySelectorAll('label, div, span, li'))
+				.filter(el => isVisible(el) && textOf(el).includes('定时发布') && textOf(el).length < 20)
+				.sort((a, b) => textOf(a).length - textOf(b).length)[0];
+			if (!option) {
+				return { success: false, error: '未找到定时发布选项' };
+			}
+			const toggle = option.querySelector('input[type="radio"], input[type="checkbox"], [role="switch"], [role="radio"]') || option;
+			const checked = toggle.checked === true || toggle.getAttribute('aria-checked') === 'true';
+			if (!checked) {
+				toggle.click();
+			}
+
+			// 3. 填写日期和时间（React 受控输入框需要通过原生 setter 赋值并触发事件）
+			const setValue = (input, value) => {
+				const setter = Object.getOwnPropertyDescriptor(HTMLInputElement.prototype, 'value').set;
+				input.focus();
+				setter.call(input, value);
+				input.dispatchEvent(new Event('input', { bubbles: true }));
+				input.dispatchEvent(new Event('change', { bubbles: true }));
+				input.blur();
+			};
+			const inputs = Array.from(document.querySelectorAll('input'))
+				.filter(el => isVisible(el) && el.type !== 'checkbox' && el.type !== 'radio');
+			const dateInput = inputs.find(el => /日期|date/i.test((el.placeholder || '') + (el.name || '') + el.type));
+			const timeInput = inputs.find(el => /时间|time/i.test((el.placeholder || '') + (el.name || '') + el.type) && el !== dateInput);
+			if (!dateInput || !timeInput) {
+				return { success: false, error: '未找到定时发布的日期或时间输入框' };
+			}
+			setValue(dateInput, schedule.date);
+			setValue(timeInput, schedule.time);
+
+			return { success: true, date: dateInput.value, time: timeInput.value };
+		}
+	`, map[string]interface{}{
+		"date": publishAt.Format("2006-01-02"),
+		"time": publishAt.Format("15:04"),
+	})
+	if err != nil {
+		return fmt.Errorf("设置定时发布失败: %v", err)
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("设置定时发布返回了无法识别的结果: %v", result)
+	}
+	if success, _ := resultMap["success"].(bool); !success {
+		errorMsg, _ := resultMap["error"].(string)
+		return fmt.Errorf("%s", errorMsg)
+	}
+
+	log.Printf("[知乎] [发布设置] 定时发布 -> %v %v", resultMap["date"], resultMap["time"])
+	return nil
+}
@@ -0,0 +1,61 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+)
+
+// cleanPastedStyles 清理从临时页粘贴带入的内联样式：移除 style 属性、非编辑器自身的 class，
+// 并展开 <font> 标签，只保留段落、标题、列表、代码块等结构，让正文回到知乎默认排版
+func (p *Publisher) cleanPastedStyles() error {
+	result, err := p.page.Evaluate(`
+		(selector) => {
+			const editor = document.querySelector(selector);
+			if (!editor) {
+				return { success: false, error: '找不到编辑器元素' };
+			}
+
+			// 知乎编辑器（Draft.js）和代码高亮自身使用的 class，移除后会破坏编辑器状态
+			const editorClass = /^(public-|Draft|Editable|ztext|RichText|Image|Code|hljs|language-)/;
+			let styles = 0, classes = 0, fonts = 0;
+
+			for (const el of Array.from(editor.querySelectorAll('[style]'))) {
+				el.removeAttribute('style');
+				styles++;
+			}
+			for (const el of Array.from(editor.querySelectorAll('[class]'))) {
+				const kept = Array.from(el.classList).filter(name => editorClass.test(name));
+				if (kept.length === el.classList.length) continue;
+				if (kept.length === 0) {
+					el.removeAttribute('class');
+				} else {
+					el.className = kept.join(' ');
+				}
+				classes++;
+			}
+			for (const font of Array.from(editor.querySelectorAll('font'))) {
+				font.replaceWith(...Array.from(font.childNodes));
+				fonts++;
+			}
+
+			const changed = styles + classes + fonts > 0;
+			if (changed) {
+				editor.dispatchEvent(new Event('input', { bubbles: true }));
+			}
+			return { success: true, changed: changed, styles: styles, classes: classes, fonts: fonts };
+		}
+	`, p.editorSelector())
+	if err != nil {
+		return fmt.Errorf("清理粘贴样式失败: %v", err)
+	}
+	if err := evaluateError(result); err != nil {
+		return err
+	}
+
+	resultMap := result.(map[string]interface{})
+	if changed, _ := resultMap["changed"].(bool); changed {
+		log.Printf("[知乎] 🧹 已清理粘贴带入的样式：%v 处内联样式，%v 处 class，%v 个 font 标签",
+			resultMap["styles"], resultMap["classes"], resultMap["fonts"])
+	}
+	return nil
+}
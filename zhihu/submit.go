@@ -0,0 +1,67 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+)
+
+// publishTimeout 点击发布后等待跳转到文章页的最长时间
+const publishTimeout = 15 * time.Second
+
+// Submit 草稿模式下等待知乎自动保存草稿；发布模式下点击"发布"（回答点击"发布回答"）
+func (p *Publisher) Submit(mode platform.PublishMode) error {
+	if mode != platform.PublishModePublish {
+		if p.answerMode {
+			log.Printf("[知乎] 📝 回答已填写完成（知乎会自动保存回答草稿），请审核后手动点击发布回答")
+			return nil
+		}
+		// 知乎编辑器输入后自动保存草稿，没有单独的保存按钮
+		if common.WaitForPageText(p.page, 10*time.Second, "草稿已保存", "已保存") == "" {
+			log.Printf("[知乎] ⚠️ 未检测到草稿保存提示，请在草稿箱中确认")
+			return nil
+		}
+		log.Printf("[知乎] 📝 已保存到草稿箱")
+		return nil
+	}
+
+	if p.answerMode {
+		return p.submitAnswer()
+	}
+
+	// 设置了定时发布时按钮文字为"定时发布"
+	if err := common.ClickButtonByText(p.page, "", "发布", "定时发布"); err != nil {
+		return err
+	}
+
+	// 发布成功后从写作页跳转到文章页
+	deadline := time.Now().Add(common.Timeout(publishTimeout))
+	for time.Now().Before(deadline) {
+		url := p.page.URL()
+		if !strings.Contains(url, "/write") && !strings.Contains(url, "/edit") {
+			log.Printf("[知乎] 🎉 文章已发布: %s", url)
+			p.shareToPin(url)
+			return nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return fmt.Errorf("点击发布后仍停留在编辑页，可能发布失败（如话题未选择）")
+}
+
+// shareToPin 开启了同步想法且拿到了文章链接时发一条想法，失败不影响文章的发布结果
+func (p *Publisher) shareToPin(url string) {
+	if !p.options.SharePin {
+		return
+	}
+	if !articleURLRegex.MatchString(url) {
+		log.Printf("[知乎] ⏭️ 未跳转到文章页（如定时发布），跳过同步想法")
+		return
+	}
+	if err := p.sharePin(url); err != nil {
+		log.Printf("[知乎] ⚠️ 同步想法失败: %v", err)
+	}
+}
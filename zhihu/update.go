@@ -0,0 +1,61 @@
+package zhihu
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/auto-blog/common"
+	"github.com/playwright-community/playwright-go"
+)
+
+// EditURL 返回专栏文章的编辑页地址，articleURL 可以是文章页或编辑页链接
+func EditURL(articleURL string) (string, error) {
+	match := articleURLRegex.FindStringSubmatch(articleURL)
+	if match == nil {
+		return "", fmt.Errorf("不是知乎专栏文章链接: %s", articleURL)
+	}
+	return fmt.Sprintf("https://zhuanlan.zhihu.com/p/%s/edit", match[1]), nil
+}
+
+// OpenForUpdate 打开已发布文章的编辑页，等待编辑器就绪后清空原正文（标题在填写时整体替换）
+func (p *Publisher) OpenForUpdate(articleURL string) error {
+	p.answerMode = false
+	editURL, err := EditURL(articleURL)
+	if err != nil {
+		return err
+	}
+	if _, err := common.HumanGoto(p.page, editURL); err != nil {
+		return fmt.Errorf("打开文章编辑页失败: %v", err)
+	}
+	p.page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+		State: playwright.LoadStateNetworkidle,
+	})
+	if err := p.WaitForEditor(); err != nil {
+		return err
+	}
+	if err := common.ClearEditor(p.page, p.editorSelector()); err != nil {
+		return fmt.Errorf("清空原正文失败: %v", err)
+	}
+	log.Printf("[知乎] ✏️ 已打开文章编辑页并清空原正文: %s", editURL)
+	return nil
+}
+
+// SubmitUpdate 点击"发布更新"保存修改，成功后从编辑页跳转回文章页
+func (p *Publisher) SubmitUpdate() (string, error) {
+	if err := common.ClickButtonByText(p.page, "", "发布更新", "更新", "发布"); err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(common.Timeout(publishTimeout))
+	for time.Now().Before(deadline) {
+		url := p.page.URL()
+		if !strings.Contains(url, "/edit") && articleURLRegex.MatchString(url) {
+			log.Printf("[知乎] 🎉 文章已更新: %s", url)
+			return url, nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return "", fmt.Errorf("点击发布更新后仍停留在编辑页，可能更新失败")
+}
@@ -0,0 +1,33 @@
+package zhihu
+
+import (
+	"log"
+
+	"github.com/auto-blog/common"
+	"github.com/auto-blog/platform"
+)
+
+// 知乎创作中心的草稿列表和文章列表
+const (
+	draftsURL   = "https://www.zhihu.com/creator/manage/creation/draft"
+	articlesURL = "https://www.zhihu.com/creator/manage/creation/article"
+)
+
+// VerifySubmitted 提交后确认文章确实出现且标题匹配：发布后已跳转到文章页时直接在文章页确认，
+// 否则（草稿、定时发布）到创作中心的列表中查找。回答没有独立标题，不做验证
+func (p *Publisher) VerifySubmitted(title string, mode platform.PublishMode) (string, error) {
+	if p.answerMode {
+		log.Printf("[知乎] ⏭️ 回答没有独立标题，跳过发布验证")
+		return "", nil
+	}
+	if mode != platform.PublishModePublish {
+		return common.VerifyArticleListed(p.page, Name, draftsURL, title)
+	}
+	if url := p.page.URL(); articleURLRegex.MatchString(url) {
+		if _, err := common.VerifyArticleListed(p.page, Name, "", title); err != nil {
+			return "", err
+		}
+		return url, nil
+	}
+	return common.VerifyArticleListed(p.page, Name, articlesURL, title)
+}